@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/FabricSoul/auto-resume/internal/cli"
 	"github.com/FabricSoul/auto-resume/internal/models"
 	"github.com/FabricSoul/auto-resume/internal/types"
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,9 +14,15 @@ import (
 func main() {
 	pm, err := types.NewPrejectManager()
 	if err != nil {
-		fmt.Errorf("Failed to init pm: %v \n", err)
+		fmt.Fprintf(os.Stderr, "Failed to init pm: %v\n", err)
 		os.Exit(1)
 	}
+
+	// Any argument selects the command line interface instead of the TUI.
+	if len(os.Args) > 1 {
+		os.Exit(cli.Run(pm, os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+	}
+
 	p := tea.NewProgram(models.NewMainModel(pm), tea.WithMouseCellMotion(),
 		tea.WithAltScreen())
 
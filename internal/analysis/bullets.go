@@ -0,0 +1,60 @@
+package analysis
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// bulletLine matches the start of a bullet point: a plain \item or a
+// template macro such as \resumeItem.
+var bulletLine = regexp.MustCompile(`^\s*\\(item\b|[a-zA-Z]*[iI]tem\s*\{)`)
+
+// Bullet is a bullet point of a LaTeX resume.
+type Bullet struct {
+	// Line is the line of the bullet, counted from 1.
+	Line int
+	Text string
+	// Matched is the number of job keywords the bullet mentions.
+	Matched int
+}
+
+// Bullets returns the bullet points in the body of a LaTeX resume, least
+// relevant to the keywords first. Among equally relevant ones the longest
+// come first, as they gain the most from condensing.
+func Bullets(latex string, keywords []string) []Bullet {
+	var bullets []Bullet
+	lines := strings.Split(latex, "\n")
+	start := 0
+	// Macros defined in the preamble use \item too.
+	for i, line := range lines {
+		if strings.Contains(line, `\begin{document}`) {
+			start = i + 1
+			break
+		}
+	}
+	for i := start; i < len(lines); i++ {
+		text := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(text, "%") || !bulletLine.MatchString(text) || !hasWords(text) {
+			continue
+		}
+		bullets = append(bullets, Bullet{
+			Line:    i + 1,
+			Text:    text,
+			Matched: len(KeywordScore(text, keywords).Matched),
+		})
+	}
+	sort.SliceStable(bullets, func(i, j int) bool {
+		a, b := bullets[i], bullets[j]
+		if a.Matched != b.Matched {
+			return a.Matched < b.Matched
+		}
+		return len(a.Text) > len(b.Text)
+	})
+	return bullets
+}
+
+// hasWords reports whether a line has text besides LaTeX commands.
+func hasWords(line string) bool {
+	return len(Tokens(latexCommand.ReplaceAllString(line, " "))) > 0
+}
@@ -0,0 +1,144 @@
+// Package analysis scores generated resumes against job descriptions.
+package analysis
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// MaxKeywords is the number of keywords taken from a job description.
+const MaxKeywords = 30
+
+var latexCommand = regexp.MustCompile(`\\[a-zA-Z@]+\*?`)
+
+// stopwords are common English and job posting words that say nothing about
+// the role.
+var stopwords = toSet(`a about above after again all also an and any are as at be because been
+before being below between both but by can could did do does doing down during each etc few for
+from further had has have having he her here hers him his how i if in into is it its itself just
+me more most must my no nor not now of off on once only or other our ours out over own per same
+she should so some such than that the their theirs them then there these they this those through
+to too under until up very via was we well were what when where which while who whom why will
+with would you your yours able ability across apply candidate candidates company day days join
+looking new opportunity position preferred required requirements responsibilities role team
+teams work working year years plus including strong excellent good great within using based
+job qualifications benefits salary us am eg ie`)
+
+func toSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
+
+// Tokens splits text into lowercase words. Symbols that are part of
+// technology names, as in "c++", "c#" or "node.js", are kept.
+func Tokens(text string) []string {
+	var tokens []string
+	var b strings.Builder
+	flush := func() {
+		t := strings.Trim(b.String(), ".-")
+		if t != "" {
+			tokens = append(tokens, t)
+		}
+		b.Reset()
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case (r == '+' || r == '#') && b.Len() > 0:
+			b.WriteRune(r)
+		case (r == '.' || r == '-') && b.Len() > 0:
+			b.WriteRune(r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
+
+// Keywords returns the most frequent meaningful words of a job description,
+// most frequent first.
+func Keywords(jobDescription string) []string {
+	counts := make(map[string]int)
+	first := make(map[string]int)
+	for i, t := range Tokens(jobDescription) {
+		if stopwords[t] || isNumber(t) || len([]rune(t)) < 2 {
+			continue
+		}
+		if _, ok := first[t]; !ok {
+			first[t] = i
+		}
+		counts[t]++
+	}
+
+	keywords := make([]string, 0, len(counts))
+	for k := range counts {
+		keywords = append(keywords, k)
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		a, b := keywords[i], keywords[j]
+		if counts[a] != counts[b] {
+			return counts[a] > counts[b]
+		}
+		return first[a] < first[b]
+	})
+	if len(keywords) > MaxKeywords {
+		keywords = keywords[:MaxKeywords]
+	}
+	return keywords
+}
+
+func isNumber(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) && r != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// Score is how well a text covers a set of keywords.
+type Score struct {
+	Matched []string
+	Missing []string
+}
+
+// Percent returns the share of matched keywords from 0 to 100.
+func (s Score) Percent() int {
+	total := len(s.Matched) + len(s.Missing)
+	if total == 0 {
+		return 0
+	}
+	return len(s.Matched) * 100 / total
+}
+
+// KeywordScore checks which keywords appear in text. LaTeX commands are
+// ignored so markup does not count as a match.
+func KeywordScore(text string, keywords []string) Score {
+	present := make(map[string]bool)
+	for _, t := range Tokens(latexCommand.ReplaceAllString(text, " ")) {
+		present[t] = true
+	}
+	var score Score
+	for _, k := range keywords {
+		if present[k] {
+			score.Matched = append(score.Matched, k)
+		} else {
+			score.Missing = append(score.Missing, k)
+		}
+	}
+	return score
+}
+
+// EstimateTokens approximates the number of LLM tokens in text, at roughly
+// four characters per token. Providers do not report usage through the
+// client, so this is what is shown.
+func EstimateTokens(text string) int {
+	return (len([]rune(text)) + 3) / 4
+}
@@ -0,0 +1,202 @@
+// Package batch generates tailored outputs for many job descriptions in one
+// run. Jobs are queued in the project directory so an interrupted batch can
+// be resumed.
+package batch
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/FabricSoul/auto-resume/internal/jobdesc"
+)
+
+// Job is a single job description to generate an output for.
+type Job struct {
+	Name        string `toml:"name"`
+	Description string `toml:"description"`
+	// Source is the file, and for CSV and JSONL the line, the job came from.
+	Source string `toml:"source"`
+}
+
+// Column and key names recognised in CSV and JSONL files.
+var (
+	nameKeys        = []string{"name", "output"}
+	titleKeys       = []string{"title", "role", "position"}
+	companyKeys     = []string{"company", "employer"}
+	descriptionKeys = []string{"description", "job_description", "job", "text"}
+)
+
+var ErrNoJobs = errors.New("no job descriptions found")
+
+// LoadJobs reads job descriptions from path, which is a directory of .md,
+// .txt, .pdf or saved .html files, a .csv file with a header row, or a .jsonl
+// file with one object per line.
+func LoadJobs(path string) ([]Job, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []Job
+	switch {
+	case info.IsDir():
+		jobs, err = loadDir(path)
+	case strings.EqualFold(filepath.Ext(path), ".csv"):
+		jobs, err = loadCSV(path)
+	case strings.EqualFold(filepath.Ext(path), ".jsonl"), strings.EqualFold(filepath.Ext(path), ".ndjson"):
+		jobs, err = loadJSONL(path)
+	default:
+		return nil, fmt.Errorf("unsupported job source %s, expected a directory, .csv or .jsonl", filepath.Base(path))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, ErrNoJobs
+	}
+	return jobs, nil
+}
+
+func loadDir(dir string) ([]Job, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var jobs []Job
+	for _, e := range entries {
+		ext := strings.ToLower(filepath.Ext(e.Name()))
+		if e.IsDir() || !jobdesc.Extensions[ext] {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		posting, err := jobdesc.Load(context.Background(), path)
+		if errors.Is(err, jobdesc.ErrEmpty) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, Job{
+			Name:        strings.TrimSuffix(e.Name(), filepath.Ext(e.Name())),
+			Description: posting.Description,
+			Source:      path,
+		})
+	}
+	return jobs, nil
+}
+
+func loadCSV(path string) ([]Job, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, h := range header {
+		columns[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+
+	var jobs []Job
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		field := func(keys []string) string {
+			for _, k := range keys {
+				if i, ok := columns[k]; ok && i < len(record) {
+					return strings.TrimSpace(record[i])
+				}
+			}
+			return ""
+		}
+		if job, ok := newJob(field, fmt.Sprintf("%s:%d", path, line)); ok {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+func loadJSONL(path string) ([]Job, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var jobs []Job
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var object map[string]any
+		if err := json.Unmarshal([]byte(text), &object); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", filepath.Base(path), line, err)
+		}
+		field := func(keys []string) string {
+			for _, k := range keys {
+				if v, ok := object[k].(string); ok {
+					return strings.TrimSpace(v)
+				}
+			}
+			return ""
+		}
+		if job, ok := newJob(field, fmt.Sprintf("%s:%d", path, line)); ok {
+			jobs = append(jobs, job)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// newJob builds a job from a CSV row or JSONL object. Rows without a
+// description are skipped; the name falls back to "title @ company".
+func newJob(field func([]string) string, source string) (Job, bool) {
+	description := field(descriptionKeys)
+	if description == "" {
+		return Job{}, false
+	}
+
+	name := field(nameKeys)
+	if name == "" {
+		title, company := field(titleKeys), field(companyKeys)
+		switch {
+		case title != "" && company != "":
+			name = title + " @ " + company
+		case title != "":
+			name = title
+		default:
+			name = company
+		}
+	}
+	if name == "" {
+		name = filepath.Base(source)
+	}
+	return Job{Name: name, Description: description, Source: source}, true
+}
@@ -0,0 +1,153 @@
+package batch
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/vcs"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// QueueFile is the file in the project directory holding the current batch.
+// It is kept out of the project history.
+const QueueFile = "batch.toml"
+
+// Status is the state of a queued job.
+type Status string
+
+const (
+	StatusQueued  Status = "queued"
+	StatusRunning Status = "running"
+	StatusDone    Status = "done"
+	StatusFailed  Status = "failed"
+)
+
+// Entry is a job together with its progress.
+type Entry struct {
+	Job        Job       `toml:"job"`
+	Status     Status    `toml:"status"`
+	Error      string    `toml:"error"`
+	OutputSlug string    `toml:"output_slug"`
+	StartedAt  time.Time `toml:"started_at"`
+	FinishedAt time.Time `toml:"finished_at"`
+}
+
+// Duration returns how long the job ran, or has been running so far.
+func (e Entry) Duration() time.Duration {
+	switch {
+	case e.StartedAt.IsZero():
+		return 0
+	case e.FinishedAt.IsZero():
+		return time.Since(e.StartedAt)
+	default:
+		return e.FinishedAt.Sub(e.StartedAt)
+	}
+}
+
+// Queue is a batch of jobs generated with one model.
+type Queue struct {
+	Model     string    `toml:"model"`
+	Source    string    `toml:"source"`
+	CreatedAt time.Time `toml:"created_at"`
+	Entries   []Entry   `toml:"jobs"`
+}
+
+// NewQueue queues jobs for generation with the named model.
+func NewQueue(model, source string, jobs []Job) *Queue {
+	q := &Queue{Model: model, Source: source, CreatedAt: time.Now()}
+	for _, job := range jobs {
+		q.Entries = append(q.Entries, Entry{Job: job, Status: StatusQueued})
+	}
+	return q
+}
+
+// LoadQueue reads the batch of the project in projectDir. It returns nil
+// without an error when there is none. Jobs that were running when the app
+// exited are queued again.
+func LoadQueue(projectDir string) (*Queue, error) {
+	data, err := os.ReadFile(filepath.Join(projectDir, QueueFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read batch queue: %w", err)
+	}
+
+	var q Queue
+	if err := toml.Unmarshal(data, &q); err != nil {
+		return nil, fmt.Errorf("failed to parse batch queue: %w", err)
+	}
+	for i := range q.Entries {
+		if q.Entries[i].Status == StatusRunning {
+			q.Entries[i].Status = StatusQueued
+			q.Entries[i].StartedAt = time.Time{}
+		}
+	}
+	return &q, nil
+}
+
+// Save writes the queue to the project directory.
+func (q *Queue) Save(projectDir string) error {
+	data, err := toml.Marshal(q)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch queue: %w", err)
+	}
+	path := filepath.Join(projectDir, QueueFile)
+	tmp := path + ".tmp"
+	for _, name := range []string{QueueFile, filepath.Base(tmp)} {
+		if err := vcs.Exclude(projectDir, name); err != nil {
+			return fmt.Errorf("failed to exclude batch queue from history: %w", err)
+		}
+	}
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write batch queue: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write batch queue: %w", err)
+	}
+	return nil
+}
+
+// RemoveQueue deletes the batch of the project in projectDir.
+func RemoveQueue(projectDir string) error {
+	err := os.Remove(filepath.Join(projectDir, QueueFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// Counts returns the number of jobs in each status.
+func (q *Queue) Counts() map[Status]int {
+	counts := make(map[Status]int)
+	for _, e := range q.Entries {
+		counts[e.Status]++
+	}
+	return counts
+}
+
+// Pending reports whether jobs are left to run.
+func (q *Queue) Pending() bool {
+	for _, e := range q.Entries {
+		if e.Status == StatusQueued || e.Status == StatusRunning {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryFailed queues every failed job again and returns how many there were.
+func (q *Queue) RetryFailed() int {
+	n := 0
+	for i := range q.Entries {
+		if q.Entries[i].Status == StatusFailed {
+			q.Entries[i] = Entry{Job: q.Entries[i].Job, Status: StatusQueued}
+			n++
+		}
+	}
+	return n
+}
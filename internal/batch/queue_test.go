@@ -0,0 +1,120 @@
+package batch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestQueueSaveLoad(t *testing.T) {
+	started := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		status Status
+		want   Status
+	}{
+		{"queued", StatusQueued, StatusQueued},
+		{"done", StatusDone, StatusDone},
+		{"failed", StatusFailed, StatusFailed},
+		{"running is queued again", StatusRunning, StatusQueued},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			q := NewQueue("Model 1", "jobs.csv", []Job{{Name: "Acme", Description: "Go developer"}})
+			q.Entries[0].Status = tt.status
+			q.Entries[0].StartedAt = started
+			if err := q.Save(dir); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := os.Stat(filepath.Join(dir, QueueFile+".tmp")); !os.IsNotExist(err) {
+				t.Errorf("temporary file left behind: %v", err)
+			}
+
+			loaded, err := LoadQueue(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if loaded.Model != "Model 1" || loaded.Source != "jobs.csv" || len(loaded.Entries) != 1 {
+				t.Fatalf("loaded %+v", loaded)
+			}
+			e := loaded.Entries[0]
+			if e.Status != tt.want {
+				t.Errorf("status = %s, want %s", e.Status, tt.want)
+			}
+			if e.Job.Name != "Acme" || e.Job.Description != "Go developer" {
+				t.Errorf("job = %+v", e.Job)
+			}
+			if tt.status == StatusRunning && !e.StartedAt.IsZero() {
+				t.Errorf("requeued job keeps its start time %v", e.StartedAt)
+			}
+		})
+	}
+}
+
+func TestLoadQueueErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content *string
+		wantNil bool
+		wantErr bool
+	}{
+		{name: "no batch", wantNil: true},
+		{name: "invalid TOML", content: ptr("model = "), wantNil: true, wantErr: true},
+		{name: "empty file", content: ptr("")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.content != nil {
+				if err := os.WriteFile(filepath.Join(dir, QueueFile), []byte(*tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			q, err := LoadQueue(dir)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if (q == nil) != tt.wantNil {
+				t.Errorf("queue = %+v, want nil %v", q, tt.wantNil)
+			}
+		})
+	}
+}
+
+func TestRemoveQueue(t *testing.T) {
+	dir := t.TempDir()
+	if err := RemoveQueue(dir); err != nil {
+		t.Errorf("removing a missing queue: %v", err)
+	}
+	if err := NewQueue("Model 1", "", nil).Save(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := RemoveQueue(dir); err != nil {
+		t.Fatal(err)
+	}
+	if q, err := LoadQueue(dir); q != nil || err != nil {
+		t.Errorf("LoadQueue after removal = %v, %v", q, err)
+	}
+}
+
+func TestRetryFailed(t *testing.T) {
+	q := NewQueue("Model 1", "", []Job{{Name: "a"}, {Name: "b"}, {Name: "c"}})
+	q.Entries[0].Status = StatusDone
+	q.Entries[1].Status = StatusFailed
+	q.Entries[1].Error = "rate limit"
+	if n := q.RetryFailed(); n != 1 {
+		t.Errorf("RetryFailed() = %d, want 1", n)
+	}
+	if e := q.Entries[1]; e.Status != StatusQueued || e.Error != "" {
+		t.Errorf("retried entry = %+v", e)
+	}
+	if !q.Pending() {
+		t.Error("Pending() = false with queued jobs")
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,183 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/generator"
+	"github.com/FabricSoul/auto-resume/internal/jobdesc"
+	"github.com/FabricSoul/auto-resume/internal/latex"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/FabricSoul/auto-resume/internal/vcs"
+)
+
+// Event reports a change of one job, or the end of the run when Finished is
+// set.
+type Event struct {
+	Index    int
+	Entry    Entry
+	Finished bool
+	Err      error
+}
+
+// Runner generates the queued jobs of a project.
+type Runner struct {
+	ProjectDir string
+	Model      types.AIModel
+	// Limit is the number of jobs run at once against the model's provider.
+	Limit int
+	Queue *Queue
+
+	// mu guards Queue and the project files while jobs run concurrently.
+	mu     sync.Mutex
+	resume string
+	print  latex.Fingerprint
+}
+
+// providerSlots limits concurrent generations per provider across every
+// runner in the process.
+var (
+	slotsMu       sync.Mutex
+	providerSlots = make(map[string]chan struct{})
+)
+
+func slots(provider string, limit int) chan struct{} {
+	slotsMu.Lock()
+	defer slotsMu.Unlock()
+	ch, ok := providerSlots[provider]
+	if !ok || cap(ch) != limit {
+		ch = make(chan struct{}, limit)
+		providerSlots[provider] = ch
+	}
+	return ch
+}
+
+// Run generates every queued job, sending an Event to events whenever a job
+// changes. events is closed when the run ends. Cancelling ctx stops the run;
+// interrupted jobs are queued again so the batch can be resumed. The queue
+// must not be read elsewhere during the run, use the entries of the events.
+func (r *Runner) Run(ctx context.Context, events chan<- Event) {
+	defer close(events)
+
+	config, err := types.LoadProjectConfig(r.ProjectDir)
+	if err == nil && config.ResumeInput == "" {
+		err = errors.New("project has no base resume")
+	}
+	if err != nil {
+		events <- Event{Finished: true, Err: err}
+		return
+	}
+	r.resume = config.ResumeInput
+	r.print = latex.NewFingerprint(config.ResumeInput)
+
+	limit := r.Limit
+	if limit < 1 {
+		limit = 1
+	}
+	sem := slots(r.Model.Provider, limit)
+
+	var wg sync.WaitGroup
+	for i := range r.Queue.Entries {
+		if r.Queue.Entries[i].Status != StatusQueued {
+			continue
+		}
+		acquired := false
+		select {
+		case sem <- struct{}{}:
+			acquired = true
+		case <-ctx.Done():
+		}
+		if !acquired {
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			r.runJob(ctx, i, events)
+		}(i)
+	}
+	wg.Wait()
+
+	r.mu.Lock()
+	err = r.Queue.Save(r.ProjectDir)
+	r.mu.Unlock()
+	events <- Event{Finished: true, Err: err}
+}
+
+func (r *Runner) runJob(ctx context.Context, i int, events chan<- Event) {
+	job := r.update(i, events, func(e *Entry) {
+		e.Status = StatusRunning
+		e.Error = ""
+		e.StartedAt = time.Now()
+	}).Job
+
+	jobCtx, cancel := context.WithTimeout(ctx, generator.Timeout)
+	defer cancel()
+	meta := jobdesc.Extract(job.Description)
+	response, err := generator.GenerateResume(jobCtx, r.Model, r.resume, job.Description, meta)
+
+	if err != nil && ctx.Err() != nil {
+		// The batch was cancelled, not the job.
+		r.update(i, events, func(e *Entry) {
+			*e = Entry{Job: e.Job, Status: StatusQueued}
+		})
+		return
+	}
+	if err == nil {
+		var slug string
+		slug, err = r.saveOutput(job, meta, response)
+		if err == nil {
+			r.update(i, events, func(e *Entry) {
+				e.Status = StatusDone
+				e.OutputSlug = slug
+				e.FinishedAt = time.Now()
+			})
+			return
+		}
+	}
+	r.update(i, events, func(e *Entry) {
+		e.Status = StatusFailed
+		e.Error = err.Error()
+		e.FinishedAt = time.Now()
+	})
+}
+
+// saveOutput adds the generated resume to the project and commits it.
+func (r *Runner) saveOutput(job Job, meta types.JobMetadata, response string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	output, err := types.AppendOutput(r.ProjectDir, types.Output{
+		Name:              job.Name,
+		JobDescription:    job.Description,
+		GeneratedOutput:   response,
+		GeneratedAt:       time.Now(),
+		ResumeFingerprint: r.print,
+		Job:               meta,
+	})
+	if err != nil {
+		return "", err
+	}
+	commitMsg := fmt.Sprintf("Generate %s with %s (%s/%s)",
+		output.Name, r.Model.Name, r.Model.Provider, r.Model.Model)
+	if err := vcs.CommitIfEnabled(r.ProjectDir, commitMsg); err != nil {
+		return "", fmt.Errorf("failed to commit project: %w", err)
+	}
+	return output.Slug, nil
+}
+
+// update applies change to entry i, persists the queue and reports it.
+func (r *Runner) update(i int, events chan<- Event, change func(*Entry)) Entry {
+	r.mu.Lock()
+	change(&r.Queue.Entries[i])
+	entry := r.Queue.Entries[i]
+	err := r.Queue.Save(r.ProjectDir)
+	r.mu.Unlock()
+
+	events <- Event{Index: i, Entry: entry, Err: err}
+	return entry
+}
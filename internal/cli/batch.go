@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"github.com/FabricSoul/auto-resume/internal/batch"
+	"github.com/FabricSoul/auto-resume/internal/types"
+)
+
+type batchJSON struct {
+	Name   string       `json:"name"`
+	Status batch.Status `json:"status"`
+	Slug   string       `json:"slug,omitempty"`
+	Error  string       `json:"error,omitempty"`
+}
+
+// runBatch generates an output for every job in a directory, CSV or JSONL
+// file, or resumes the unfinished batch of the project.
+func (a *app) runBatch(args []string) error {
+	fs := a.newFlagSet("batch")
+	projectName := fs.String("project", "", "project to generate outputs for (required)")
+	jobsPath := fs.String("jobs", "", "directory, .csv or .jsonl of job descriptions")
+	modelName := fs.String("model", "", "model to use, defaults to the project's model")
+	concurrency := fs.Int("concurrency", 0, "jobs run at once, defaults to the provider's limit")
+	resume := fs.Bool("resume", false, "continue the unfinished batch of the project")
+	retry := fs.Bool("retry-failed", false, "with --resume, run failed jobs again")
+	positional, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageErrorf("batch: unexpected argument %q", positional[0])
+	}
+	if *projectName == "" || (*jobsPath == "") == !*resume {
+		return usageErrorf("batch: --project and one of --jobs or --resume are required")
+	}
+
+	project, err := a.pm.GetProject(*projectName)
+	if err != nil {
+		return err
+	}
+
+	var queue *batch.Queue
+	if *resume {
+		if queue, err = batch.LoadQueue(project.Path); err != nil {
+			return err
+		}
+		if queue == nil {
+			return fmt.Errorf("project '%s' has no batch to resume", project.Name)
+		}
+		if *retry {
+			queue.RetryFailed()
+		}
+		if *modelName == "" {
+			*modelName = queue.Model
+		}
+	} else {
+		if existing, err := batch.LoadQueue(project.Path); err != nil {
+			return err
+		} else if existing != nil && existing.Pending() {
+			return fmt.Errorf("project '%s' has an unfinished batch, pass --resume", project.Name)
+		}
+		jobs, err := batch.LoadJobs(*jobsPath)
+		if err != nil {
+			return fmt.Errorf("failed to load jobs: %w", err)
+		}
+		if *modelName == "" {
+			cfg, err := types.LoadProjectConfig(project.Path)
+			if err != nil {
+				return err
+			}
+			*modelName = cfg.Model
+		}
+		source, _ := filepath.Abs(*jobsPath)
+		queue = batch.NewQueue(*modelName, source, jobs)
+	}
+
+	if *modelName == "" {
+		return usageErrorf("batch: project '%s' has no model, pass --model", project.Name)
+	}
+	model, err := a.findModel(*modelName)
+	if err != nil {
+		return err
+	}
+	queue.Model = model.Name
+	if err := queue.Save(project.Path); err != nil {
+		return err
+	}
+
+	limit := *concurrency
+	if limit <= 0 {
+		limit = a.pm.ProviderConcurrency(model.Provider)
+	}
+
+	// Interrupting leaves the remaining jobs queued for --resume.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	runner := &batch.Runner{ProjectDir: project.Path, Model: model, Limit: limit, Queue: queue}
+	events := make(chan batch.Event, 2*len(queue.Entries)+1)
+	go runner.Run(ctx, events)
+
+	var runErr error
+	for event := range events {
+		if event.Finished {
+			runErr = event.Err
+			continue
+		}
+		if !a.json && event.Entry.Status != batch.StatusRunning {
+			line := fmt.Sprintf("[%d/%d] %-7s %s", event.Index+1, len(queue.Entries), event.Entry.Status, event.Entry.Job.Name)
+			if event.Entry.Error != "" {
+				line += ": " + event.Entry.Error
+			}
+			fmt.Fprintln(a.stderr, line)
+		}
+	}
+	if runErr != nil {
+		return runErr
+	}
+
+	counts := queue.Counts()
+	if a.json {
+		results := make([]batchJSON, 0, len(queue.Entries))
+		for _, e := range queue.Entries {
+			results = append(results, batchJSON{Name: e.Job.Name, Status: e.Status, Slug: e.OutputSlug, Error: e.Error})
+		}
+		a.printJSON(map[string]any{"project": project.Name, "model": model.Name, "jobs": results})
+	} else {
+		fmt.Fprintf(a.stdout, "%d done, %d failed, %d queued\n",
+			counts[batch.StatusDone], counts[batch.StatusFailed], counts[batch.StatusQueued])
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		return fmt.Errorf("batch interrupted, %d jobs left, resume with --resume", counts[batch.StatusQueued])
+	case counts[batch.StatusFailed] > 0:
+		return llmError{fmt.Errorf("%d of %d jobs failed", counts[batch.StatusFailed], len(queue.Entries))}
+	}
+	return nil
+}
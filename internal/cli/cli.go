@@ -0,0 +1,180 @@
+// Package cli implements the non-interactive subcommands of auto-resume, so
+// projects, models and generation can be driven from scripts. It shares the
+// ProjectManager and generation code with the TUI.
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/FabricSoul/auto-resume/internal/latex"
+	"github.com/FabricSoul/auto-resume/internal/types"
+)
+
+// Exit codes returned by Run.
+const (
+	ExitOK       = 0
+	ExitError    = 1
+	ExitUsage    = 2
+	ExitNotFound = 3
+	ExitLLM      = 4
+	ExitCompile  = 5
+)
+
+const usage = `Usage: auto-resume [command]
+
+Without a command the interactive interface is started.
+
+Commands:
+  project list                     List projects
+  project create NAME              Create a project
+  project delete NAME              Delete a project and its files
+  model list                       List configured models
+  model add                        Add a model
+  model test NAME                  Send a test prompt to a model
+  generate                         Generate a tailored resume
+  batch                            Generate outputs for many job descriptions
+  compile                          Compile outputs or a .tex file to PDF
+  export                           Export outputs to Markdown, HTML, plain text or JSON Resume
+  reminders                        List follow-ups and deadlines that are due
+
+Every command accepts --json to print machine readable output.
+Run 'auto-resume COMMAND -h' for the flags of a command.
+`
+
+// errUsage marks errors caused by invalid arguments.
+var errUsage = errors.New("invalid usage")
+
+// llmError wraps failures reported by the model provider.
+type llmError struct{ err error }
+
+func (e llmError) Error() string { return e.err.Error() }
+func (e llmError) Unwrap() error { return e.err }
+
+type app struct {
+	pm     *types.ProjectManager
+	stdout io.Writer
+	stderr io.Writer
+	stdin  io.Reader
+	json   bool
+}
+
+// Run executes the subcommand in args and returns the process exit code.
+func Run(pm *types.ProjectManager, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	a := &app{pm: pm, stdin: stdin, stdout: stdout, stderr: stderr}
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(stdout, usage)
+		return ExitOK
+	}
+
+	var err error
+	switch args[0] {
+	case "project":
+		err = a.runProject(args[1:])
+	case "model":
+		err = a.runModel(args[1:])
+	case "generate":
+		err = a.runGenerate(args[1:])
+	case "batch":
+		err = a.runBatch(args[1:])
+	case "compile":
+		err = a.runCompile(args[1:])
+	case "export":
+		err = a.runExport(args[1:])
+	case "reminders":
+		err = a.runReminders(args[1:])
+	default:
+		err = usageErrorf("unknown command %q", args[0])
+	}
+	return a.exit(err)
+}
+
+// exit reports err and maps it to an exit code.
+func (a *app) exit(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return ExitOK
+	}
+
+	code := ExitError
+	var compileErr *latex.CompileError
+	var llmErr llmError
+	switch {
+	case errors.Is(err, errUsage):
+		code = ExitUsage
+	case errors.Is(err, types.ErrProjectNotFound), errors.Is(err, types.ErrModelNotFound),
+		errors.Is(err, types.ErrOutputNotFound):
+		code = ExitNotFound
+	case errors.As(err, &llmErr):
+		code = ExitLLM
+	case errors.As(err, &compileErr), errors.Is(err, latex.ErrNoEngine):
+		code = ExitCompile
+	}
+
+	message := strings.TrimPrefix(err.Error(), errUsage.Error()+": ")
+	if a.json {
+		a.printJSON(map[string]any{"error": message, "code": code})
+	} else {
+		fmt.Fprintln(a.stderr, "auto-resume:", message)
+		if code == ExitUsage {
+			fmt.Fprintln(a.stderr, "Run 'auto-resume help' for usage.")
+		}
+	}
+	return code
+}
+
+func usageErrorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", errUsage, fmt.Sprintf(format, args...))
+}
+
+// newFlagSet creates the flag set of a subcommand with the shared --json flag.
+func (a *app) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("auto-resume "+name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.BoolVar(&a.json, "json", false, "print machine readable JSON")
+	return fs
+}
+
+// parse parses args allowing flags after positional arguments, and returns
+// the positional arguments.
+func parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %v", errUsage, err)
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		if args[0] == "--" {
+			return append(positional, args[1:]...), nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func (a *app) printJSON(v any) {
+	enc := json.NewEncoder(a.stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+// splitSub returns the subcommand of a command group, such as "list" in
+// "project list".
+func splitSub(group string, args []string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", nil, usageErrorf("%s: missing subcommand", group)
+	}
+	return args[0], args[1:], nil
+}
@@ -0,0 +1,203 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/coverletter"
+	"github.com/FabricSoul/auto-resume/internal/generator"
+	"github.com/FabricSoul/auto-resume/internal/latex"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/FabricSoul/auto-resume/internal/vcs"
+)
+
+// compileTimeout bounds a compile or export command as a whole, each LaTeX
+// run is bounded by latex.Timeout as well.
+const compileTimeout = 15 * time.Minute
+
+type compileJSON struct {
+	Output string `json:"output,omitempty"`
+	PDF    string `json:"pdf"`
+	// Pages is set for projects with a page limit, Shortened counts the
+	// times the model shortened the output to fit it.
+	Pages     int `json:"pages,omitempty"`
+	Shortened int `json:"shortened,omitempty"`
+}
+
+// runCompile compiles either outputs of a project or a standalone .tex file.
+func (a *app) runCompile(args []string) error {
+	fs := a.newFlagSet("compile")
+	projectName := fs.String("project", "", "compile outputs of this project")
+	outputName := fs.String("output", "", "name or slug of the output to compile, defaults to all")
+	engine := fs.String("engine", "", "LaTeX engine, defaults to the project's or the first installed")
+	letters := fs.Bool("cover-letter", false, "compile the cover letters of the outputs instead of the resumes")
+	modelName := fs.String("model", "", "model shortening outputs over the project's page limit, defaults to the project's model")
+	noFit := fs.Bool("no-fit", false, "report outputs over the page limit instead of shortening them")
+	positional, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), compileTimeout)
+	defer cancel()
+
+	var results []compileJSON
+	switch {
+	case *projectName != "" && len(positional) == 0:
+		results, err = a.compileProject(ctx, *projectName, *outputName, *engine, *letters, *modelName, !*noFit)
+	case *projectName == "" && len(positional) == 1:
+		var pdf string
+		pdf, err = latex.Compile(ctx, positional[0], latex.CompileOptions{Engine: *engine})
+		results = []compileJSON{{PDF: pdf}}
+	default:
+		return usageErrorf("compile: pass either --project or a single .tex file")
+	}
+	if err != nil {
+		return err
+	}
+
+	if a.json {
+		a.printJSON(results)
+		return nil
+	}
+	for _, r := range results {
+		fmt.Fprintln(a.stdout, r.PDF)
+	}
+	return nil
+}
+
+func (a *app) compileProject(ctx context.Context, projectName, outputName, engine string, letters bool, modelName string, fit bool) ([]compileJSON, error) {
+	project, err := a.pm.GetProject(projectName)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := types.LoadProjectConfig(project.Path)
+	if err != nil {
+		return nil, err
+	}
+	if engine != "" {
+		cfg.Engine = engine
+	}
+
+	var results []compileJSON
+	var shortened []types.Output
+	for i, out := range cfg.Outputs {
+		if outputName != "" && out.Name != outputName && out.Slug != outputName {
+			continue
+		}
+		compile := types.CompileOutput
+		if letters {
+			if out.CoverLetter == "" {
+				continue
+			}
+			compile = coverletter.Compile
+		} else if out.GeneratedOutput == "" {
+			continue
+		}
+		if !letters && cfg.PageLimit > 0 {
+			result, err := a.compileToLimit(ctx, project, cfg, &cfg.Outputs[i], modelName, fit)
+			if err != nil {
+				return nil, fmt.Errorf("output '%s': %w", out.Name, err)
+			}
+			if result.Shortened > 0 {
+				shortened = append(shortened, cfg.Outputs[i])
+			}
+			results = append(results, result)
+			continue
+		}
+		pdf, err := compile(ctx, project.Path, cfg, out)
+		if err != nil {
+			return nil, fmt.Errorf("output '%s': %w", out.Name, err)
+		}
+		results = append(results, compileJSON{Output: out.Name, PDF: pdf})
+	}
+	if len(shortened) > 0 {
+		if err := saveShortened(project.Path, shortened); err != nil {
+			return nil, err
+		}
+		names := make([]string, len(shortened))
+		for i, out := range shortened {
+			names[i] = out.Name
+		}
+		commitMsg := fmt.Sprintf("Shorten %s to %d page(s)", strings.Join(names, ", "), cfg.PageLimit)
+		if err := vcs.CommitIfEnabled(project.Path, commitMsg); err != nil {
+			return nil, fmt.Errorf("failed to commit project: %w", err)
+		}
+	}
+	if outputName != "" && len(results) == 0 {
+		return nil, fmt.Errorf("%w: '%s' (or not generated yet)", types.ErrOutputNotFound, outputName)
+	}
+	return results, nil
+}
+
+// saveShortened writes the LaTeX of the shortened outputs into the project
+// config as it is on disk, leaving the rest of it, such as the engine, as
+// saved rather than as overridden for this compile.
+func saveShortened(projectDir string, shortened []types.Output) error {
+	cfg, err := types.LoadProjectConfig(projectDir)
+	if err != nil {
+		return err
+	}
+	for _, out := range shortened {
+		for i := range cfg.Outputs {
+			if cfg.Outputs[i].Slug == out.Slug {
+				cfg.Outputs[i].GeneratedOutput = out.GeneratedOutput
+			}
+		}
+	}
+	return types.SaveProjectConfig(projectDir, cfg)
+}
+
+// compileToLimit compiles out and, when it runs over the project's page
+// limit and fit is set, has the model shorten it. out is updated with the
+// shortened LaTeX. Outputs left over the limit are reported on stderr.
+func (a *app) compileToLimit(ctx context.Context, project types.Project, cfg types.ProjectConfig, out *types.Output, modelName string, fit bool) (compileJSON, error) {
+	pdf, pages, err := generator.CompiledPages(ctx, project.Path, cfg, *out)
+	if err != nil {
+		return compileJSON{}, err
+	}
+	result := compileJSON{Output: out.Name, PDF: pdf, Pages: pages}
+
+	if pages > cfg.PageLimit && fit {
+		if modelName == "" {
+			modelName = cfg.Model
+		}
+		if modelName == "" {
+			return result, usageErrorf("compile: project '%s' has no model to shorten outputs with, pass --model or --no-fit", project.Name)
+		}
+		model, err := a.findModel(modelName)
+		if err != nil {
+			return result, err
+		}
+		fitCtx, cancel := context.WithTimeout(ctx, generator.Timeout)
+		defer cancel()
+		fitted, fitResult, err := generator.FitToPages(fitCtx, model, project.Path, cfg, *out, pages, cfg.PageLimit)
+		if err != nil {
+			var compileErr *latex.CompileError
+			if !errors.As(err, &compileErr) {
+				err = llmError{err}
+			}
+			return result, err
+		}
+		if fitResult.Stopped != nil {
+			fmt.Fprintf(a.stderr, "auto-resume: output '%s': stopped shortening, kept the last draft that compiled: %v\n",
+				out.Name, fitResult.Stopped)
+		}
+		*out = fitted
+		result.Shortened = fitResult.Attempts
+		if fitResult.Attempts > 0 {
+			// Drafts are compiled aside, compile the shortened output in place.
+			if result.PDF, result.Pages, err = generator.CompiledPages(ctx, project.Path, cfg, *out); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	if result.Pages > cfg.PageLimit && !a.json {
+		fmt.Fprintf(a.stderr, "auto-resume: output '%s' has %d pages, over the limit of %d\n", out.Name, result.Pages, cfg.PageLimit)
+	}
+	return result, nil
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/FabricSoul/auto-resume/internal/export"
+	"github.com/FabricSoul/auto-resume/internal/jsonresume"
+	"github.com/FabricSoul/auto-resume/internal/types"
+)
+
+type exportJSON struct {
+	Output string `json:"output"`
+	Path   string `json:"path"`
+}
+
+// runExport converts generated outputs to Markdown, HTML, plain text or
+// JSON Resume.
+func (a *app) runExport(args []string) error {
+	fs := a.newFlagSet("export")
+	projectName := fs.String("project", "", "export outputs of this project")
+	outputName := fs.String("output", "", "name or slug of the output to export, defaults to all")
+	formatName := fs.String("format", "md", "md, html, txt or json")
+	pandoc := fs.Bool("pandoc", false, "convert with pandoc instead of the built-in converter")
+	positional, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if *projectName == "" || len(positional) > 0 {
+		return usageErrorf("export: pass --project and no other arguments")
+	}
+	// JSON Resume is structured data rather than a rendering of the
+	// document, so it has no pandoc variant.
+	toJSON := strings.EqualFold(*formatName, "json")
+	if toJSON && *pandoc {
+		return usageErrorf("export: --pandoc does not apply to json")
+	}
+	var format export.Format
+	if !toJSON {
+		if format, err = export.ParseFormat(*formatName); err != nil {
+			return usageErrorf("export: %v", err)
+		}
+	}
+
+	project, err := a.pm.GetProject(*projectName)
+	if err != nil {
+		return err
+	}
+	cfg, err := types.LoadProjectConfig(project.Path)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), compileTimeout)
+	defer cancel()
+
+	var results []exportJSON
+	for _, out := range cfg.Outputs {
+		if *outputName != "" && out.Name != *outputName && out.Slug != *outputName {
+			continue
+		}
+		if out.GeneratedOutput == "" {
+			continue
+		}
+		var path string
+		if toJSON {
+			path, err = jsonresume.ExportOutput(project.Path, out)
+		} else {
+			path, err = export.ExportOutput(ctx, project.Path, out, format, *pandoc)
+		}
+		if err != nil {
+			return fmt.Errorf("output '%s': %w", out.Name, err)
+		}
+		results = append(results, exportJSON{Output: out.Name, Path: path})
+	}
+	if *outputName != "" && len(results) == 0 {
+		return fmt.Errorf("%w: '%s' (or not generated yet)", types.ErrOutputNotFound, *outputName)
+	}
+
+	if a.json {
+		a.printJSON(results)
+		return nil
+	}
+	for _, r := range results {
+		fmt.Fprintln(a.stdout, r.Path)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/generator"
+	"github.com/FabricSoul/auto-resume/internal/jobdesc"
+	"github.com/FabricSoul/auto-resume/internal/latex"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/FabricSoul/auto-resume/internal/vcs"
+)
+
+func (a *app) runGenerate(args []string) error {
+	fs := a.newFlagSet("generate")
+	projectName := fs.String("project", "", "project whose base resume is tailored (required)")
+	jobPath := fs.String("job", "", "job description as .txt, .md, .pdf or .html file, or - for stdin (required)")
+	modelName := fs.String("model", "", "model to use, defaults to the project's model")
+	outPath := fs.String("out", "-", "where to write the generated LaTeX, - for stdout")
+	name := fs.String("name", "", "name of the saved output, defaults to the detected title and company or the job file name")
+	noSave := fs.Bool("no-save", false, "do not add the result to the project's outputs")
+	positional, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageErrorf("generate: unexpected argument %q", positional[0])
+	}
+	if *projectName == "" || *jobPath == "" {
+		return usageErrorf("generate: --project and --job are required")
+	}
+
+	project, err := a.pm.GetProject(*projectName)
+	if err != nil {
+		return err
+	}
+	cfg, err := types.LoadProjectConfig(project.Path)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(cfg.ResumeInput) == "" {
+		return fmt.Errorf("project '%s' has no base resume", project.Name)
+	}
+
+	if *modelName == "" {
+		*modelName = cfg.Model
+	}
+	if *modelName == "" {
+		return usageErrorf("generate: project '%s' has no model, pass --model", project.Name)
+	}
+	model, err := a.findModel(*modelName)
+	if err != nil {
+		return err
+	}
+
+	posting, err := a.readPosting(*jobPath)
+	if err != nil {
+		return fmt.Errorf("failed to read job description: %w", err)
+	}
+	job := posting.Description
+
+	ctx, cancel := context.WithTimeout(context.Background(), generator.Timeout)
+	defer cancel()
+	start := time.Now()
+	response, err := generator.GenerateResume(ctx, model, cfg.ResumeInput, job, posting.Job)
+	if err != nil {
+		return llmError{err}
+	}
+	latency := time.Since(start)
+
+	output := types.Output{
+		Name:              *name,
+		JobDescription:    job,
+		GeneratedOutput:   response,
+		GeneratedAt:       time.Now(),
+		ResumeFingerprint: latex.NewFingerprint(cfg.ResumeInput),
+		Job:               posting.Job,
+	}
+	if output.Name == "" {
+		output.Name = posting.SuggestName()
+	}
+	if output.Name == "" {
+		output.Name = outputName(*jobPath)
+	}
+	if !*noSave {
+		if output, err = types.AppendOutput(project.Path, output); err != nil {
+			return err
+		}
+		commitMsg := fmt.Sprintf("Generate %s with %s (%s/%s)",
+			output.Name, model.Name, model.Provider, model.Model)
+		if err := vcs.CommitIfEnabled(project.Path, commitMsg); err != nil {
+			return fmt.Errorf("failed to commit project: %w", err)
+		}
+	}
+
+	// With --json on stdout the LaTeX is part of the JSON document.
+	if *outPath != "-" {
+		if err := os.WriteFile(*outPath, []byte(response), 0644); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	} else if !a.json {
+		fmt.Fprint(a.stdout, response)
+	}
+
+	if a.json {
+		result := map[string]any{
+			"project":    project.Name,
+			"model":      model.Name,
+			"name":       output.Name,
+			"saved":      !*noSave,
+			"latency_ms": latency.Milliseconds(),
+		}
+		if !*noSave {
+			result["slug"] = output.Slug
+			result["path"] = types.OutputTexPath(project.Path, output)
+		}
+		if *outPath == "-" {
+			result["latex"] = response
+		} else {
+			result["out"] = *outPath
+		}
+		a.printJSON(result)
+	} else if *outPath != "-" {
+		fmt.Fprintf(a.stderr, "Wrote %s\n", *outPath)
+	}
+	return nil
+}
+
+// readPosting reads the job posting at path, or from stdin when path is "-".
+func (a *app) readPosting(path string) (jobdesc.Posting, error) {
+	if path != "-" {
+		return jobdesc.Load(context.Background(), path)
+	}
+	data, err := io.ReadAll(a.stdin)
+	if err != nil {
+		return jobdesc.Posting{}, err
+	}
+	posting := jobdesc.Parse(string(data))
+	if strings.TrimSpace(posting.Description) == "" {
+		return jobdesc.Posting{}, jobdesc.ErrEmpty
+	}
+	return posting, nil
+}
+
+// outputName derives an output name from the job description file, falling
+// back to a timestamp like the TUI does.
+func outputName(jobPath string) string {
+	if jobPath == "-" {
+		return time.Now().Format("2006-01-02-15-04-05")
+	}
+	base := filepath.Base(jobPath)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/generator"
+	"github.com/FabricSoul/auto-resume/internal/types"
+)
+
+// testPrompt is sent by 'model test' to check that a model answers.
+const testPrompt = "Reply with the single word OK."
+
+type modelJSON struct {
+	Name     string `json:"name"`
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+	APIKey   string `json:"api_key"`
+}
+
+// maskKey hides all but the last four characters of an API key.
+func maskKey(key string) string {
+	if len(key) <= 4 {
+		return strings.Repeat("*", len(key))
+	}
+	return strings.Repeat("*", 8) + key[len(key)-4:]
+}
+
+// findModel returns the configured model with the given name.
+func (a *app) findModel(name string) (types.AIModel, error) {
+	for _, m := range a.pm.GetModels() {
+		if m.Name == name {
+			return m, nil
+		}
+	}
+	return types.AIModel{}, fmt.Errorf("%w: '%s'", types.ErrModelNotFound, name)
+}
+
+func (a *app) runModel(args []string) error {
+	sub, args, err := splitSub("model", args)
+	if err != nil {
+		return err
+	}
+	switch sub {
+	case "list":
+		return a.modelList(args)
+	case "add":
+		return a.modelAdd(args)
+	case "test":
+		return a.modelTest(args)
+	}
+	return usageErrorf("unknown model subcommand %q", sub)
+}
+
+func (a *app) modelList(args []string) error {
+	fs := a.newFlagSet("model list")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+
+	models := a.pm.GetModels()
+	if a.json {
+		out := make([]modelJSON, 0, len(models))
+		for _, m := range models {
+			out = append(out, modelJSON{Name: m.Name, Provider: m.Provider, Model: m.Model, APIKey: maskKey(m.APIKey)})
+		}
+		a.printJSON(out)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tPROVIDER\tMODEL\tAPI KEY")
+	for _, m := range models {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", m.Name, m.Provider, m.Model, maskKey(m.APIKey))
+	}
+	return w.Flush()
+}
+
+func (a *app) modelAdd(args []string) error {
+	fs := a.newFlagSet("model add")
+	name := fs.String("name", "", "display name of the model")
+	provider := fs.String("provider", "", "provider, e.g. openai, anthropic, ollama")
+	model := fs.String("model", "", "model identifier of the provider")
+	apiKey := fs.String("api-key", "", "API key, defaults to $AUTO_RESUME_API_KEY")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+
+	if *name == "" {
+		return types.ErrEmptyModelName
+	}
+	if *provider == "" || *model == "" {
+		return usageErrorf("model add: --provider and --model are required")
+	}
+	if *apiKey == "" {
+		*apiKey = os.Getenv("AUTO_RESUME_API_KEY")
+	}
+
+	models := a.pm.GetModels()
+	for _, m := range models {
+		if m.Name == *name {
+			return fmt.Errorf("model with name '%s' already exists", *name)
+		}
+	}
+	added := types.AIModel{Name: *name, Provider: *provider, Model: *model, APIKey: *apiKey}
+	if err := a.pm.SaveModels(append(models, added)); err != nil {
+		return err
+	}
+
+	if a.json {
+		a.printJSON(modelJSON{Name: added.Name, Provider: added.Provider, Model: added.Model, APIKey: maskKey(added.APIKey)})
+	} else {
+		fmt.Fprintf(a.stdout, "Added model %s (%s/%s)\n", added.Name, added.Provider, added.Model)
+	}
+	return nil
+}
+
+func (a *app) modelTest(args []string) error {
+	fs := a.newFlagSet("model test")
+	timeout := fs.Duration("timeout", time.Minute, "how long to wait for the response")
+	positional, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("model test: expected exactly one model name")
+	}
+
+	model, err := a.findModel(positional[0])
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	start := time.Now()
+	response, err := generator.Complete(ctx, model, testPrompt)
+	if err != nil {
+		return llmError{err}
+	}
+	latency := time.Since(start)
+
+	if a.json {
+		a.printJSON(map[string]any{
+			"model":      model.Name,
+			"ok":         true,
+			"latency_ms": latency.Milliseconds(),
+			"response":   strings.TrimSpace(response),
+		})
+	} else {
+		fmt.Fprintf(a.stdout, "%s responded in %s: %s\n", model.Name, latency.Round(time.Millisecond), strings.TrimSpace(response))
+	}
+	return nil
+}
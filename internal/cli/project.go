@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/templates"
+	"github.com/FabricSoul/auto-resume/internal/types"
+)
+
+type projectJSON struct {
+	Name       string    `json:"name"`
+	Path       string    `json:"path"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastOpened time.Time `json:"last_opened"`
+}
+
+func toProjectJSON(p types.Project) projectJSON {
+	return projectJSON{Name: p.Name, Path: p.Path, CreatedAt: p.CreatedAt, LastOpened: p.LastOpened}
+}
+
+func (a *app) runProject(args []string) error {
+	sub, args, err := splitSub("project", args)
+	if err != nil {
+		return err
+	}
+	switch sub {
+	case "list":
+		return a.projectList(args)
+	case "create":
+		return a.projectCreate(args)
+	case "delete":
+		return a.projectDelete(args)
+	}
+	return usageErrorf("unknown project subcommand %q", sub)
+}
+
+func (a *app) projectList(args []string) error {
+	fs := a.newFlagSet("project list")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+
+	if a.json {
+		projects := make([]projectJSON, 0, len(a.pm.Projects))
+		for _, p := range a.pm.Projects {
+			projects = append(projects, toProjectJSON(p))
+		}
+		a.printJSON(projects)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tLAST OPENED\tPATH")
+	for _, p := range a.pm.Projects {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", p.Name, p.LastOpened.Format("2006-01-02 15:04"), p.Path)
+	}
+	return w.Flush()
+}
+
+func (a *app) projectCreate(args []string) error {
+	fs := a.newFlagSet("project create")
+	templateID := fs.String("template", "", "start from this resume template, see the new project screen")
+	positional, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("project create: expected exactly one project name")
+	}
+
+	name := positional[0]
+	if err := types.CheckProjectName(name); err != nil {
+		return err
+	}
+	var tmpl templates.Template
+	if *templateID != "" {
+		if tmpl, err = templates.Find(templates.Dir(), *templateID); err != nil {
+			return usageErrorf("project create: %v, use one of %s", err, strings.Join(templateIDs(templates.Dir()), ", "))
+		}
+	}
+	if err := a.pm.AddProject(name); err != nil {
+		return err
+	}
+	project, err := a.pm.GetProject(name)
+	if err != nil {
+		return err
+	}
+	if *templateID != "" {
+		err := templates.Apply(project.Path, tmpl)
+		switch {
+		case errors.Is(err, templates.ErrEngineMissing):
+			fmt.Fprintln(a.stderr, "auto-resume:", err)
+		case err != nil:
+			return fmt.Errorf("failed to apply template %s: %w", tmpl.Name, err)
+		}
+	}
+
+	if a.json {
+		a.printJSON(toProjectJSON(project))
+	} else {
+		fmt.Fprintf(a.stdout, "Created project %s at %s\n", project.Name, project.Path)
+	}
+	return nil
+}
+
+func (a *app) projectDelete(args []string) error {
+	fs := a.newFlagSet("project delete")
+	keepFiles := fs.Bool("keep-files", false, "only remove the project from the list, keep its directory")
+	positional, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("project delete: expected exactly one project name")
+	}
+
+	project, err := a.pm.GetProject(positional[0])
+	if err != nil {
+		return err
+	}
+	if err := a.pm.DeleteProject(project.Name, !*keepFiles); err != nil {
+		return err
+	}
+
+	if a.json {
+		a.printJSON(map[string]any{"deleted": project.Name, "files_removed": !*keepFiles})
+	} else {
+		fmt.Fprintf(a.stdout, "Deleted project %s\n", project.Name)
+	}
+	return nil
+}
+
+// templateIDs lists the IDs of the available resume templates.
+func templateIDs(dir string) []string {
+	list, _ := templates.List(dir)
+	ids := make([]string, len(list))
+	for i, t := range list {
+		ids[i] = t.ID
+	}
+	return ids
+}
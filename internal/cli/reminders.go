@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/reminders"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/FabricSoul/auto-resume/pkg/config"
+)
+
+type reminderJSON struct {
+	Project  string    `json:"project"`
+	Output   string    `json:"output"`
+	Reminder string    `json:"reminder"`
+	Status   string    `json:"status"`
+	Due      time.Time `json:"due"`
+	Overdue  bool      `json:"overdue"`
+	Reason   string    `json:"reason"`
+}
+
+// runReminders lists the reminders that are due. Nothing is printed when
+// none are, so it can run from cron.
+func (a *app) runReminders(args []string) error {
+	fs := a.newFlagSet("reminders")
+	projectName := fs.String("project", "", "only check this project")
+	all := fs.Bool("all", false, "also list upcoming reminders")
+	ics := fs.String("ics", "", "write all reminders to this iCalendar file, - for stdout")
+	positional, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageErrorf("reminders: unexpected argument %q", positional[0])
+	}
+
+	projects := a.pm.Projects
+	if *projectName != "" {
+		project, err := a.pm.GetProject(*projectName)
+		if err != nil {
+			return err
+		}
+		projects = []types.Project{project}
+	}
+	found, skipped := reminders.Collect(projects, a.pm.ReminderRules())
+	if skipped > 0 {
+		fmt.Fprintf(a.stderr, "auto-resume: %d project(s) could not be loaded\n", skipped)
+	}
+
+	now := time.Now()
+	if *ics != "" {
+		if err := a.writeICS(*ics, found, now); err != nil {
+			return err
+		}
+		if *ics == "-" {
+			return nil
+		}
+	}
+	if !*all {
+		found = reminders.Due(found, now)
+	}
+
+	if a.json {
+		results := make([]reminderJSON, 0, len(found))
+		for _, r := range found {
+			results = append(results, reminderJSON{
+				Project:  r.Project.Name,
+				Output:   r.Output.Name,
+				Reminder: r.Rule.Title(),
+				Status:   string(r.Output.Application.CurrentStatus()),
+				Due:      r.Due,
+				Overdue:  r.Overdue(now),
+				Reason:   r.Reason,
+			})
+		}
+		a.printJSON(results)
+		return nil
+	}
+	if len(found) == 0 {
+		return nil
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "DUE\tREMINDER\tPROJECT\tOUTPUT\tREASON")
+	for _, r := range found {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.Due.Format("2006-01-02"), r.Rule.Title(), r.Project.Name, r.Subject(), r.Reason)
+	}
+	return w.Flush()
+}
+
+// writeICS writes the reminders to path, or to stdout for "-".
+func (a *app) writeICS(path string, found []reminders.Reminder, now time.Time) error {
+	if path == "-" {
+		return reminders.WriteICS(a.stdout, found, now)
+	}
+	f, err := os.Create(config.ExpandHome(path))
+	if err != nil {
+		return fmt.Errorf("failed to write calendar: %w", err)
+	}
+	if err := reminders.WriteICS(f, found, now); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write calendar: %w", err)
+	}
+	return f.Close()
+}
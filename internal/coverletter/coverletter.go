@@ -0,0 +1,210 @@
+// Package coverletter builds the prompt for cover letters and renders the
+// generated text into a LaTeX letter. Both templates can be overridden per
+// project by placing cover_letter_prompt.txt or cover_letter_template.tex in
+// the project directory.
+package coverletter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/latex"
+	"github.com/FabricSoul/auto-resume/internal/types"
+)
+
+// Files in the project directory that replace the default templates.
+const (
+	PromptFile   = "cover_letter_prompt.txt"
+	TemplateFile = "cover_letter_template.tex"
+)
+
+// Templates use [[ and ]] so LaTeX braces need no escaping.
+const (
+	leftDelim  = "[["
+	rightDelim = "]]"
+)
+
+const defaultPrompt = `You are a professional career writer. Write a cover letter for the job below,
+based on the tailored resume.[[if .Job.Title]]
+The role is [[.Job.Title]][[if .Job.Company]] at [[.Job.Company]][[end]].[[end]]
+Follow these rules:
+1. Three to four short paragraphs, under 350 words
+2. Refer to concrete experience from the resume that matches the job
+3. Do not invent experience, employers or skills
+4. Plain text only: no LaTeX, no markdown, no salutation and no signature
+5. Separate paragraphs with a blank line
+
+Tailored Resume:
+[[.Resume]]
+
+Job Description:
+[[.JobDescription]]`
+
+const defaultTemplate = `\documentclass[11pt]{article}
+\usepackage[a4paper,margin=2.5cm]{geometry}
+\usepackage[T1]{fontenc}
+\usepackage[utf8]{inputenc}
+\setlength{\parindent}{0pt}
+\setlength{\parskip}{0.8em}
+\pagestyle{empty}
+
+\begin{document}
+
+[[if .Sender]]{\Large\bfseries [[.Sender]]}\par[[end]]
+[[.Date]]
+
+[[if .Company]][[.Company]]\par[[end]]
+
+Dear Hiring Manager,
+
+[[range .Paragraphs]][[.]]
+
+[[end]]Sincerely,
+
+[[.Sender]]
+
+\end{document}
+`
+
+// PromptData is available to the prompt template. Job holds the details
+// extracted from the job description, such as [[.Job.Company]] or
+// [[.Job.Required]].
+type PromptData struct {
+	Resume         string
+	JobDescription string
+	OutputName     string
+	Job            types.JobMetadata
+}
+
+// LetterData is available to the LaTeX template. All fields are already
+// escaped for LaTeX.
+type LetterData struct {
+	Sender     string
+	Date       string
+	Paragraphs []string
+	OutputName string
+	Company    string
+	Role       string
+	Location   string
+}
+
+// Prompt returns the prompt asking for a cover letter for out, which must
+// already have a generated resume.
+func Prompt(projectDir string, out types.Output) (string, error) {
+	if strings.TrimSpace(out.GeneratedOutput) == "" {
+		return "", fmt.Errorf("generate the resume of '%s' before its cover letter", out.Name)
+	}
+	tmpl, err := load(projectDir, PromptFile, defaultPrompt)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, PromptData{
+		Resume:         out.GeneratedOutput,
+		JobDescription: out.JobDescription,
+		OutputName:     out.Name,
+		Job:            out.Job,
+	})
+	return buf.String(), err
+}
+
+// Render turns the cover letter text of out into a LaTeX document.
+func Render(projectDir string, out types.Output) (string, error) {
+	tmpl, err := load(projectDir, TemplateFile, defaultTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var paragraphs []string
+	for _, p := range regexp.MustCompile(`\n\s*\n`).Split(strings.TrimSpace(out.CoverLetter), -1) {
+		if p = strings.TrimSpace(p); p != "" {
+			paragraphs = append(paragraphs, latex.Escape(strings.Join(strings.Fields(p), " ")))
+		}
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, LetterData{
+		Sender:     latex.Escape(SenderName(out.GeneratedOutput)),
+		Date:       time.Now().Format("January 2, 2006"),
+		Paragraphs: paragraphs,
+		OutputName: latex.Escape(out.Name),
+		Company:    latex.Escape(out.Job.Company),
+		Role:       latex.Escape(out.Job.Title),
+		Location:   latex.Escape(out.Job.Location),
+	})
+	return buf.String(), err
+}
+
+// Compile renders the cover letter of out to outputs/<slug>/cover_letter.tex
+// and compiles it, returning the PDF path.
+func Compile(ctx context.Context, projectDir string, config types.ProjectConfig, out types.Output) (string, error) {
+	if strings.TrimSpace(out.CoverLetter) == "" {
+		return "", fmt.Errorf("output '%s' has no cover letter yet", out.Name)
+	}
+	if out.Slug == "" {
+		return "", fmt.Errorf("output '%s' has not been saved yet", out.Name)
+	}
+	source, err := Render(projectDir, out)
+	if err != nil {
+		return "", fmt.Errorf("failed to render cover letter: %w", err)
+	}
+
+	texPath := types.CoverLetterTexPath(projectDir, out)
+	if err := os.MkdirAll(filepath.Dir(texPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(texPath, []byte(source), 0644); err != nil {
+		return "", fmt.Errorf("failed to write cover letter: %w", err)
+	}
+	return latex.Compile(ctx, texPath, latex.CompileOptions{
+		Engine:      config.Engine,
+		SearchPaths: []string{projectDir},
+	})
+}
+
+func load(projectDir, name, fallback string) (*template.Template, error) {
+	text := fallback
+	data, err := os.ReadFile(filepath.Join(projectDir, name))
+	switch {
+	case err == nil:
+		text = string(data)
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	tmpl, err := template.New(name).Delims(leftDelim, rightDelim).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// Patterns that commonly hold the candidate's name in resume classes.
+var senderPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\\name\{([^}]*)\}\{([^}]*)\}`),
+	regexp.MustCompile(`\\name\{([^}]*)\}`),
+	regexp.MustCompile(`\\author\{([^}]*)\}`),
+	regexp.MustCompile(`\\(?:Huge|huge|LARGE)\s*(?:\\[a-z]+\s*)*\{?([^}\\\n]+)`),
+}
+
+// SenderName guesses the candidate's name from a LaTeX resume, or returns ""
+// when none is found.
+func SenderName(resume string) string {
+	for _, re := range senderPatterns {
+		if m := re.FindStringSubmatch(resume); m != nil {
+			name := strings.TrimSpace(strings.Join(m[1:], " "))
+			if name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,71 @@
+// Package export converts generated LaTeX resumes to Markdown, standalone
+// HTML and plain text for application portals that do not take a PDF. The
+// LaTeX is parsed into a small document structure which each format renders,
+// or, when installed, converted with pandoc.
+package export
+
+import "strings"
+
+// Span is a run of text with a single style.
+type Span struct {
+	Text   string
+	Bold   bool
+	Italic bool
+	URL    string
+}
+
+// Line is a line of inline content.
+type Line []Span
+
+// String returns the text of the line without styling.
+func (l Line) String() string {
+	var sb strings.Builder
+	for _, s := range l {
+		sb.WriteString(s.Text)
+	}
+	return sb.String()
+}
+
+// BlockKind says how a block is laid out.
+type BlockKind int
+
+const (
+	Paragraph BlockKind = iota
+	List
+	Heading
+)
+
+// Block is a paragraph, a list or a heading within a section.
+type Block struct {
+	Kind BlockKind
+	// Lines of a paragraph or heading.
+	Lines []Line
+	// Items of a list.
+	Items   []Item
+	Ordered bool
+}
+
+// Item is an entry of a list. Lines are its text, Blocks hold nested lists
+// and any text that follows them.
+type Item struct {
+	Lines  []Line
+	Blocks []Block
+}
+
+// Section is a titled part of the resume.
+type Section struct {
+	Title  string
+	Blocks []Block
+}
+
+// Document is a resume parsed from LaTeX.
+type Document struct {
+	Name     string
+	Contact  []Line
+	Sections []Section
+}
+
+// IsEmpty reports whether nothing could be read from the resume.
+func (d Document) IsEmpty() bool {
+	return d.Name == "" && len(d.Contact) == 0 && len(d.Sections) == 0
+}
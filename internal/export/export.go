@@ -0,0 +1,149 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/FabricSoul/auto-resume/internal/types"
+)
+
+// Format is a file format resumes can be exported to.
+type Format string
+
+const (
+	FormatMarkdown Format = "md"
+	FormatHTML     Format = "html"
+	FormatText     Format = "txt"
+)
+
+// Formats lists the export formats in menu order.
+var Formats = []Format{FormatMarkdown, FormatHTML, FormatText}
+
+var (
+	// ErrNoPandoc is returned when pandoc is requested but not installed.
+	ErrNoPandoc = errors.New("pandoc is not installed")
+	// ErrEmpty is returned when nothing could be read from the resume.
+	ErrEmpty = errors.New("no content found in the resume")
+)
+
+// ParseFormat accepts a format by extension or name, such as "md" or
+// "markdown".
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimPrefix(strings.TrimSpace(s), ".")) {
+	case "md", "markdown":
+		return FormatMarkdown, nil
+	case "html", "htm":
+		return FormatHTML, nil
+	case "txt", "text", "plain":
+		return FormatText, nil
+	}
+	return "", fmt.Errorf("unknown export format %q, use md, html or txt", s)
+}
+
+// Title returns the name of the format for display.
+func (f Format) Title() string {
+	switch f {
+	case FormatMarkdown:
+		return "Markdown"
+	case FormatHTML:
+		return "HTML"
+	default:
+		return "Plain text"
+	}
+}
+
+// Convert renders LaTeX source in format f.
+func Convert(source string, f Format) (string, error) {
+	doc := Parse(source)
+	if doc.IsEmpty() {
+		return "", ErrEmpty
+	}
+	switch f {
+	case FormatMarkdown:
+		return Markdown(doc), nil
+	case FormatHTML:
+		return HTML(doc), nil
+	default:
+		return Text(doc), nil
+	}
+}
+
+// HasPandoc reports whether pandoc is installed.
+func HasPandoc() bool {
+	_, err := exec.LookPath("pandoc")
+	return err == nil
+}
+
+// Pandoc converts LaTeX source in format f with pandoc, which understands
+// more of LaTeX but knows nothing of resume classes.
+func Pandoc(ctx context.Context, source string, f Format) (string, error) {
+	path, err := exec.LookPath("pandoc")
+	if err != nil {
+		return "", ErrNoPandoc
+	}
+	args := []string{"--from", "latex", "--wrap", "none"}
+	switch f {
+	case FormatMarkdown:
+		args = append(args, "--to", "gfm")
+	case FormatHTML:
+		args = append(args, "--to", "html5", "--standalone", "--metadata", "pagetitle=Resume")
+	default:
+		args = append(args, "--to", "plain")
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdin = strings.NewReader(source)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("pandoc: %s", msg)
+		}
+		return "", fmt.Errorf("pandoc: %w", err)
+	}
+	return stdout.String(), nil
+}
+
+// OutputPath returns where the export of an output in format f is written,
+// next to its PDF.
+func OutputPath(projectDir string, out types.Output, f Format) string {
+	return filepath.Join(types.OutputDir(projectDir, out.Slug), "resume."+string(f))
+}
+
+// ExportOutput converts the generated resume of out and writes it next to
+// its PDF, returning the path.
+func ExportOutput(ctx context.Context, projectDir string, out types.Output, f Format, pandoc bool) (string, error) {
+	if out.GeneratedOutput == "" {
+		return "", fmt.Errorf("output '%s' has not been generated yet", out.Name)
+	}
+	if out.Slug == "" {
+		return "", fmt.Errorf("output '%s' has not been saved yet", out.Name)
+	}
+
+	var text string
+	var err error
+	if pandoc {
+		text, err = Pandoc(ctx, out.GeneratedOutput, f)
+	} else {
+		text, err = Convert(out.GeneratedOutput, f)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	path := OutputPath(projectDir, out, f)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s export: %w", f.Title(), err)
+	}
+	return path, nil
+}
@@ -0,0 +1,182 @@
+package export
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// TestConvertGolden converts each resume in testdata to every format and
+// compares the result with the golden file next to it. Run the test with
+// -update after an intended change to the output.
+func TestConvertGolden(t *testing.T) {
+	sources, err := filepath.Glob(filepath.Join("testdata", "*.tex"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sources) == 0 {
+		t.Fatal("no resumes in testdata")
+	}
+	for _, source := range sources {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range Formats {
+			golden := strings.TrimSuffix(source, ".tex") + "." + string(f)
+			t.Run(filepath.Base(golden), func(t *testing.T) {
+				got, err := Convert(string(data), f)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if *update {
+					if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+						t.Fatal(err)
+					}
+					return
+				}
+				want, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != string(want) {
+					t.Errorf("output differs from %s:\n%s", golden, got)
+				}
+			})
+		}
+	}
+}
+
+func TestConvertEmpty(t *testing.T) {
+	for _, source := range []string{"", "% only a comment\n", `\documentclass{article}\begin{document}\vspace{1em}\end{document}`} {
+		if _, err := Convert(source, FormatText); !errors.Is(err, ErrEmpty) {
+			t.Errorf("Convert(%q) err = %v, want ErrEmpty", source, err)
+		}
+	}
+}
+
+func TestParseMacros(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{
+			name:   "arguments",
+			source: `\newcommand{\role}[2]{#1 at #2}\role{Engineer}{Acme}`,
+			want:   "Engineer at Acme",
+		},
+		{
+			name:   "optional argument default",
+			source: `\newcommand{\tag}[2][none]{#1: #2}\tag{a} \tag[x]{b}`,
+			want:   "none: a x: b",
+		},
+		{
+			name:   "nested macros",
+			source: `\newcommand\inner[1]{(#1)}\newcommand\outer[1]{\inner{#1}!}\outer{go}`,
+			want:   "(go)!",
+		},
+		{
+			name:   "native commands are not redefined",
+			source: `\renewcommand{\textbf}[1]{#1 twice #1}\textbf{Go}`,
+			want:   "Go",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strings.TrimSpace(Text(Parse(tt.source))); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecursiveMacroTerminates(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		marker string
+	}{
+		{"self", `\newcommand{\again}{x\again}\again`, "x"},
+		{"mutual", `\newcommand{\ping}{p\pong}\newcommand{\pong}{q\ping}\ping`, "p"},
+		{"growing", `\newcommand{\twice}{y\twice\twice}\twice`, "y"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Text(Parse(tt.source))
+			n := strings.Count(got, tt.marker)
+			if n == 0 || n > 1<<maxExpansions {
+				t.Errorf("expanded %q %d times:\n%s", tt.marker, n, got)
+			}
+		})
+	}
+}
+
+func TestDroppedArguments(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"vspace", `before\vspace{-4pt} after`, "before after"},
+		{"starred hspace", `a\hspace*{1em}b`, "ab"},
+		{"setlength", `\setlength{\tabcolsep}{0in}text`, "text"},
+		{"titleformat", `\titleformat{\section}{\large}{}{0em}{}kept`, "kept"},
+		{"definecolor", `\definecolor{dark}{RGB}{0,0,0}kept`, "kept"},
+		{"textcolor", `\textcolor{blue}{kept}`, "kept"},
+		{"colorbox", `\colorbox{gray}{kept}`, "kept"},
+		{"resizebox", `\resizebox{2cm}{!}{kept}`, "kept"},
+		{"multicolumn", `\multicolumn{2}{l}{kept}`, "kept"},
+		{"makebox", `\makebox{kept}`, "kept"},
+		{"unknown command keeps its arguments", `\cvitem{Go}{expert}`, "Go — expert"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strings.TrimSpace(Text(Parse(tt.source))); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscaping(t *testing.T) {
+	source := `\section{R\&D}
+\textbf{a\_b} *c* [d](e) \textless{}script\textgreater{} "q" \& \textbackslash{}`
+	tests := []struct {
+		name   string
+		render func(Document) string
+		want   []string
+	}{
+		{
+			name:   "markdown",
+			render: Markdown,
+			want:   []string{"## R&D", `**a\_b** \*c\* \[d\](e) \<script> "q" & \\`},
+		},
+		{
+			name:   "html",
+			render: HTML,
+			want:   []string{"<h2>R&amp;D</h2>", `<strong>a_b</strong> *c* [d](e) &lt;script&gt; &#34;q&#34; &amp; \`},
+		},
+		{
+			name:   "text",
+			render: Text,
+			want:   []string{"R&D", `a_b *c* [d](e) <script> "q" & \`},
+		},
+	}
+	doc := Parse(source)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.render(doc)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output does not contain %q:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
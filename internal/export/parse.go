@@ -0,0 +1,897 @@
+package export
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/FabricSoul/auto-resume/internal/latex"
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxExpansions bounds macro expansion so recursive definitions end.
+const maxExpansions = 10
+
+// macro is a command defined with \newcommand in the resume.
+type macro struct {
+	args       int
+	hasDefault bool
+	def        string
+	body       string
+}
+
+var (
+	newCommandPattern = regexp.MustCompile(`\\(?:re|provide)?newcommand\*?\s*\{?\s*\\([a-zA-Z@]+)\s*\}?\s*(?:\[(\d)\])?\s*(?:\[([^\]]*)\])?\s*\{`)
+	headerNamePattern = regexp.MustCompile(`\\(?:name|author)\s*\{([^}]*)\}(?:\s*\{([^}]*)\})?`)
+	headerInfoPattern = regexp.MustCompile(`\\(email|phone|mobile|homepage|address)\s*(?:\[[^\]]*\])?\s*\{([^}]*)\}`)
+)
+
+// native lists the commands the converter understands itself. Resumes
+// sometimes redefine them for styling, those definitions are not expanded.
+var native = map[string]bool{
+	"section": true, "subsection": true, "subsubsection": true, "item": true,
+	"begin": true, "end": true, "href": true, "url": true, "textbf": true,
+	"textit": true, "emph": true, "par": true, "newline": true,
+}
+
+// ignored are commands dropped together with their arguments.
+var ignored = map[string]int{
+	"vspace": 1, "hspace": 1, "setlength": 2, "addtolength": 2, "setcounter": 2,
+	"pagestyle": 1, "thispagestyle": 1, "label": 1, "color": 1, "pagecolor": 1,
+	"fontsize": 2, "usepackage": 1, "geometry": 1, "includegraphics": 1,
+	"rule": 2, "phantom": 1, "hphantom": 1, "vphantom": 1, "extracolsep": 1,
+	"cline": 1, "addcontentsline": 3, "titleformat": 5, "titlespacing": 4,
+	"definecolor": 3, "input": 1, "include": 1, "photo": 1,
+}
+
+// prefixArgs are commands whose leading arguments are dropped while the
+// remaining argument is kept as text, such as the colour of \textcolor.
+var prefixArgs = map[string]int{
+	"textcolor": 1, "colorbox": 1, "raisebox": 1, "parbox": 1, "makebox": 0,
+	"scalebox": 1, "resizebox": 2, "fcolorbox": 2, "multicolumn": 2,
+}
+
+// symbols are commands standing for a character or word.
+var symbols = map[string]string{
+	"textbar": "|", "vert": "|", "mid": "|", "textbullet": "•", "bullet": "•",
+	"cdot": "·", "textperiodcentered": "·", "ldots": "…", "dots": "…",
+	"textellipsis": "…", "LaTeX": "LaTeX", "TeX": "TeX", "textasciitilde": "~",
+	"sim": "~", "textbackslash": "\\", "textendash": "–", "textemdash": "—",
+	"pm": "±", "times": "×", "rightarrow": "→", "to": "→", "leftarrow": "←",
+	"textregistered": "®", "copyright": "©", "textcopyright": "©",
+	"textdegree": "°", "euro": "€", "EUR": "€", "pounds": "£", "S": "§",
+	"textquotesingle": "'", "quad": " ", "qquad": " ", "enspace": " ",
+	"space": " ", "i": "i", "j": "j", "ss": "ß", "ae": "æ", "AE": "Æ",
+	"o": "ø", "O": "Ø", "aa": "å", "AA": "Å", "l": "ł", "L": "Ł",
+	"textless": "<", "textgreater": ">", "textunderscore": "_",
+}
+
+// accents maps accent commands to Unicode combining marks.
+var accents = map[string]rune{
+	"'": '\u0301', "`": '\u0300', "^": '\u0302', "\"": '\u0308', "~": '\u0303',
+	"=": '\u0304', ".": '\u0307', "c": '\u0327', "v": '\u030C', "u": '\u0306',
+	"H": '\u030B', "r": '\u030A', "k": '\u0328',
+}
+
+// Parse reads the structure of a LaTeX resume: the name and contact lines
+// before the first section, then the sections with their paragraphs and
+// lists. Macros the resume defines itself are expanded first, other unknown
+// commands are reduced to their arguments.
+func Parse(source string) Document {
+	source = latex.StripComments(source)
+	macros := readMacros(source)
+	preamble, body := splitDocument(source)
+
+	c := &converter{}
+	c.run(expandMacros(removeDefinitions(body), macros))
+	c.finish()
+
+	var doc Document
+	sections := c.sections
+	if len(sections) > 0 && sections[0].Title == "" {
+		header := flatten(sections[0].Blocks)
+		sections = sections[1:]
+		if len(header) > 0 {
+			doc.Name = strings.TrimSpace(header[0].String())
+			doc.Contact = header[1:]
+		}
+	}
+	if doc.Name == "" {
+		doc.Name, doc.Contact = preambleHeader(preamble)
+	}
+	for _, s := range sections {
+		if len(s.Blocks) > 0 {
+			doc.Sections = append(doc.Sections, s)
+		}
+	}
+	return doc
+}
+
+// splitDocument separates the preamble from the document body. Fragments
+// without \begin{document} are all body.
+func splitDocument(source string) (string, string) {
+	const begin, end = `\begin{document}`, `\end{document}`
+	i := strings.Index(source, begin)
+	if i < 0 {
+		return "", source
+	}
+	preamble, body := source[:i], source[i+len(begin):]
+	if j := strings.Index(body, end); j >= 0 {
+		body = body[:j]
+	}
+	return preamble, body
+}
+
+// preambleHeader reads the name and contact details that classes such as
+// moderncv take as preamble commands.
+func preambleHeader(preamble string) (string, []Line) {
+	var name string
+	if m := headerNamePattern.FindStringSubmatch(preamble); m != nil {
+		name = strings.TrimSpace(plain(m[1] + " " + m[2]))
+	}
+	var contact []Line
+	for _, m := range headerInfoPattern.FindAllStringSubmatch(preamble, -1) {
+		text := plain(m[2])
+		if text == "" {
+			continue
+		}
+		span := Span{Text: text}
+		if m[1] == "email" {
+			span.URL = "mailto:" + text
+		}
+		contact = append(contact, Line{span})
+	}
+	return name, contact
+}
+
+// flatten returns all lines of blocks in reading order.
+func flatten(blocks []Block) []Line {
+	var lines []Line
+	for _, b := range blocks {
+		lines = append(lines, b.Lines...)
+		for _, it := range b.Items {
+			lines = append(lines, it.Lines...)
+			lines = append(lines, flatten(it.Blocks)...)
+		}
+	}
+	return lines
+}
+
+// readMacros collects the commands defined with \newcommand and friends.
+func readMacros(source string) map[string]macro {
+	macros := make(map[string]macro)
+	for _, loc := range newCommandPattern.FindAllStringSubmatchIndex(source, -1) {
+		name := source[loc[2]:loc[3]]
+		body, _, ok := group(source, loc[1]-1)
+		if !ok || native[name] {
+			continue
+		}
+		m := macro{body: body}
+		if loc[4] >= 0 {
+			m.args = int(source[loc[4]] - '0')
+		}
+		if loc[6] >= 0 {
+			m.hasDefault = true
+			m.def = source[loc[6]:loc[7]]
+		}
+		macros[name] = m
+	}
+	return macros
+}
+
+// removeDefinitions drops the \newcommand definitions from s, so that
+// fragments defining their own macros do not expand them in place.
+func removeDefinitions(s string) string {
+	var sb strings.Builder
+	last := 0
+	for _, loc := range newCommandPattern.FindAllStringIndex(s, -1) {
+		if loc[0] < last {
+			continue
+		}
+		_, end, ok := group(s, loc[1]-1)
+		if !ok {
+			continue
+		}
+		sb.WriteString(s[last:loc[0]])
+		last = end
+	}
+	sb.WriteString(s[last:])
+	return sb.String()
+}
+
+// expandMacros replaces uses of macros with their bodies until none are
+// left or the expansion limit is reached.
+func expandMacros(s string, macros map[string]macro) string {
+	if len(macros) == 0 {
+		return s
+	}
+	for pass := 0; pass < maxExpansions; pass++ {
+		var sb strings.Builder
+		changed := false
+		for i := 0; i < len(s); {
+			if s[i] != '\\' {
+				sb.WriteByte(s[i])
+				i++
+				continue
+			}
+			name, end := commandName(s, i)
+			m, ok := macros[name]
+			if !ok {
+				sb.WriteString(s[i:end])
+				i = end
+				continue
+			}
+			args, next := macroArgs(s, end, m)
+			sb.WriteString(substitute(m.body, args))
+			i = next
+			changed = true
+		}
+		s = sb.String()
+		if !changed {
+			break
+		}
+	}
+	return s
+}
+
+// macroArgs reads the arguments of a use of m starting at pos.
+func macroArgs(s string, pos int, m macro) ([]string, int) {
+	args := make([]string, m.args)
+	k := 0
+	if m.hasDefault && m.args > 0 {
+		args[0] = m.def
+		if opt, next, ok := optional(s, pos); ok {
+			args[0], pos = opt, next
+		}
+		k = 1
+	}
+	for ; k < m.args; k++ {
+		args[k], pos = argument(s, pos)
+	}
+	return args, pos
+}
+
+// substitute fills the parameters #1 to #9 of a macro body.
+func substitute(body string, args []string) string {
+	var sb strings.Builder
+	for i := 0; i < len(body); i++ {
+		if body[i] != '#' || i+1 == len(body) {
+			sb.WriteByte(body[i])
+			continue
+		}
+		next := body[i+1]
+		switch {
+		case next == '#':
+			sb.WriteByte('#')
+			i++
+		case next >= '1' && next <= '9':
+			if n := int(next - '1'); n < len(args) {
+				// TeX reads the body before substituting, so a control
+				// word such as \small#1 does not run into the argument.
+				if arg := args[n]; arg != "" && isLetter(arg[0]) && endsWithControlWord(sb.String()) {
+					sb.WriteByte(' ')
+				}
+				sb.WriteString(args[n])
+			}
+			i++
+		default:
+			sb.WriteByte('#')
+		}
+	}
+	return sb.String()
+}
+
+// endsWithControlWord reports whether s ends with a command named by
+// letters.
+func endsWithControlWord(s string) bool {
+	i := len(s)
+	for i > 0 && isLetter(s[i-1]) {
+		i--
+	}
+	return i < len(s) && i > 0 && s[i-1] == '\\' && (i < 2 || s[i-2] != '\\')
+}
+
+// commandName reads the name of the command starting with the backslash at
+// i and returns it with the position after it.
+func commandName(s string, i int) (string, int) {
+	j := i + 1
+	if j >= len(s) {
+		return "", j
+	}
+	if !isLetter(s[j]) {
+		return s[j : j+1], j + 1
+	}
+	for j < len(s) && isLetter(s[j]) {
+		j++
+	}
+	return s[i+1 : j], j
+}
+
+func isLetter(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b == '@'
+}
+
+// group returns the content of the brace group opening at open and the
+// position after it.
+func group(s string, open int) (string, int, bool) {
+	return delimited(s, open, '{', '}')
+}
+
+// optional reads an optional [argument] at pos, skipping spaces.
+func optional(s string, pos int) (string, int, bool) {
+	i := skipSpaces(s, pos)
+	if i < len(s) && s[i] == '[' {
+		if content, end, ok := delimited(s, i, '[', ']'); ok {
+			return content, end, true
+		}
+	}
+	return "", pos, false
+}
+
+// argument reads a mandatory argument at pos: a group, a command or a
+// single character.
+func argument(s string, pos int) (string, int) {
+	i := skipSpaces(s, pos)
+	if i >= len(s) {
+		return "", i
+	}
+	switch s[i] {
+	case '{':
+		if content, end, ok := group(s, i); ok {
+			return content, end
+		}
+	case '\\':
+		_, end := commandName(s, i)
+		return s[i:end], end
+	}
+	return s[i : i+1], i + 1
+}
+
+// delimited returns the text between the delimiter at open and its match.
+// Braces nest, escaped characters are skipped.
+func delimited(s string, open int, left, right byte) (string, int, bool) {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if right == '}' && depth == 0 {
+				return s[open+1 : i], i + 1, true
+			}
+		case ']':
+			if right == ']' && depth == 0 {
+				return s[open+1 : i], i + 1, true
+			}
+		}
+	}
+	return "", len(s), false
+}
+
+// skipSpaces skips spaces and a single line break, as TeX does between
+// arguments. A blank line ends the skip.
+func skipSpaces(s string, i int) int {
+	newline := false
+	for i < len(s) {
+		switch s[i] {
+		case ' ', '\t', '\r':
+		case '\n':
+			if newline {
+				return i
+			}
+			newline = true
+		default:
+			return i
+		}
+		i++
+	}
+	return i
+}
+
+// plain converts LaTeX to unstyled text on a single line.
+func plain(s string) string {
+	c := &converter{}
+	c.run(s)
+	c.finish()
+	var parts []string
+	for _, section := range c.sections {
+		for _, l := range flatten(section.Blocks) {
+			parts = append(parts, l.String())
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+type style struct {
+	bold, italic bool
+	url          string
+}
+
+// converter turns LaTeX into blocks. Groups restore the style on exit, so
+// switches such as \bfseries end with the group they appear in.
+type converter struct {
+	style      style
+	line       Line
+	sepPending bool
+	lines      []Line
+	lists      []*Block
+	sections   []Section
+}
+
+func (c *converter) run(s string) {
+	for i := 0; i < len(s); {
+		switch ch := s[i]; {
+		case ch == '\\':
+			i = c.command(s, i)
+		case ch == '{':
+			content, end, _ := group(s, i)
+			saved := c.style
+			c.run(content)
+			c.style = saved
+			i = end
+		case ch == '}' || ch == '^' || ch == '_':
+			i++
+		case ch == '$':
+			// Math is rendered as text, which covers separators like $|$.
+			end := closingDollar(s, i+1)
+			if end < 0 {
+				i++
+				continue
+			}
+			c.run(s[i+1 : end])
+			i = end + 1
+		case ch == '&':
+			c.cell()
+			i++
+		case ch == '~':
+			c.text(" ")
+			i++
+		case ch == '\n':
+			j := i + 1
+			for j < len(s) && (s[j] == ' ' || s[j] == '\t' || s[j] == '\r') {
+				j++
+			}
+			if j < len(s) && s[j] == '\n' {
+				c.paragraphBreak()
+			} else {
+				c.text(" ")
+			}
+			i = j
+		case strings.HasPrefix(s[i:], "---"):
+			c.text("—")
+			i += 3
+		case strings.HasPrefix(s[i:], "--"):
+			c.text("–")
+			i += 2
+		case strings.HasPrefix(s[i:], "``"):
+			c.text("“")
+			i += 2
+		case strings.HasPrefix(s[i:], "''"):
+			c.text("”")
+			i += 2
+		case ch == '`':
+			c.text("‘")
+			i++
+		default:
+			j := i + 1
+			for j < len(s) && !strings.ContainsRune("\\{}^_$&~\n-`'", rune(s[j])) {
+				j++
+			}
+			c.text(s[i:j])
+			i = j
+		}
+	}
+}
+
+// closingDollar returns the position of the next unescaped $ from i, or -1.
+func closingDollar(s string, i int) int {
+	for ; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '$':
+			return i
+		}
+	}
+	return -1
+}
+
+// command handles the command starting at i and returns the position after
+// it and its arguments.
+func (c *converter) command(s string, i int) int {
+	name, pos := commandName(s, i)
+	if pos < len(s) && s[pos] == '*' {
+		pos++
+	}
+	if len(name) == 1 && !isLetter(name[0]) {
+		return c.symbol(s, name, pos)
+	}
+	for pos < len(s) && (s[pos] == ' ' || s[pos] == '\t') {
+		pos++
+	}
+
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, "section") && !strings.Contains(lower, "subsection"):
+		_, pos, _ = optional(s, pos)
+		title, next := argument(s, pos)
+		c.startSection(plain(title))
+		return next
+	case strings.Contains(lower, "subsection"):
+		_, pos, _ = optional(s, pos)
+		title, next := argument(s, pos)
+		c.heading(plain(title))
+		return next
+	}
+
+	switch name {
+	case "begin":
+		env, next := argument(s, pos)
+		return c.beginEnvironment(s, env, next)
+	case "end":
+		env, next := argument(s, pos)
+		c.endEnvironment(env)
+		return next
+	case "item":
+		label, next, _ := optional(s, pos)
+		c.item(plain(label))
+		return next
+	case "textbf", "textit", "emph", "textsl":
+		arg, next := argument(s, pos)
+		saved := c.style
+		if name == "textbf" {
+			c.style.bold = true
+		} else {
+			c.style.italic = true
+		}
+		c.run(arg)
+		c.style = saved
+		return next
+	case "bf", "bfseries":
+		c.style.bold = true
+	case "it", "itshape", "em", "sl", "slshape":
+		c.style.italic = true
+	case "href":
+		url, next := argument(s, pos)
+		text, next := argument(s, next)
+		saved := c.style
+		c.style.url = unescapeURL(url)
+		c.run(text)
+		c.style = saved
+		return next
+	case "url":
+		url, next := argument(s, pos)
+		saved := c.style
+		c.style.url = unescapeURL(url)
+		c.text(c.style.url)
+		c.style = saved
+		return next
+	case "par":
+		c.paragraphBreak()
+	case "newline", "linebreak":
+		_, pos, _ = optional(s, pos)
+		c.endLine()
+	case "hfill":
+		c.cell()
+	default:
+		if mark, ok := accents[name]; ok {
+			return c.accent(s, mark, pos)
+		}
+		if text, ok := symbols[name]; ok {
+			c.text(text)
+			return pos
+		}
+		if n, ok := ignored[name]; ok {
+			_, pos, _ = optional(s, pos)
+			for k := 0; k < n; k++ {
+				_, pos = argument(s, pos)
+				_, pos, _ = optional(s, pos)
+			}
+			return pos
+		}
+		if n, ok := prefixArgs[name]; ok {
+			_, pos, _ = optional(s, pos)
+			for k := 0; k < n; k++ {
+				_, pos = argument(s, pos)
+				_, pos, _ = optional(s, pos)
+			}
+		}
+		return c.unknown(s, pos)
+	}
+	return pos
+}
+
+// unknown renders the brace groups following an unknown command. Several
+// arguments are laid out like table cells.
+func (c *converter) unknown(s string, pos int) int {
+	first := true
+	for {
+		i := skipSpaces(s, pos)
+		if i >= len(s) || s[i] != '{' {
+			return pos
+		}
+		content, next, _ := group(s, i)
+		if strings.TrimSpace(plain(content)) != "" {
+			if !first {
+				c.cell()
+			}
+			saved := c.style
+			c.run(content)
+			c.style = saved
+			first = false
+		}
+		pos = next
+	}
+}
+
+// symbol handles commands named by a single non-letter character.
+func (c *converter) symbol(s, name string, pos int) int {
+	switch name {
+	case "\\":
+		_, pos, _ = optional(s, pos)
+		c.endLine()
+	case "&", "%", "$", "#", "_", "{", "}":
+		c.text(name)
+	case ",", ";", ":", "!", " ":
+		c.text(" ")
+	default:
+		if mark, ok := accents[name]; ok {
+			return c.accent(s, mark, pos)
+		}
+	}
+	return pos
+}
+
+// accent applies an accent command to its argument.
+func (c *converter) accent(s string, mark rune, pos int) int {
+	arg, next := argument(s, pos)
+	text := []rune(plain(arg))
+	if len(text) == 0 {
+		return next
+	}
+	c.text(norm.NFC.String(string(text[0]) + string(mark) + string(text[1:])))
+	return next
+}
+
+func (c *converter) beginEnvironment(s, env string, pos int) int {
+	name := strings.TrimSuffix(env, "*")
+	switch {
+	case strings.Contains(name, "itemize") || strings.Contains(name, "enumerate") || strings.Contains(name, "description"):
+		_, pos, _ = optional(s, pos)
+		c.beginList(strings.Contains(name, "enumerate"))
+	case name == "tabular" || name == "tabularx" || name == "tabulary" || name == "array" || name == "longtable":
+		if env == "tabular*" || name == "tabularx" || name == "tabulary" {
+			_, pos = argument(s, pos)
+		}
+		_, pos, _ = optional(s, pos)
+		_, pos = argument(s, pos)
+		c.paragraphBreak()
+	case name == "minipage":
+		_, pos, _ = optional(s, pos)
+		_, pos = argument(s, pos)
+		c.paragraphBreak()
+	case name == "multicols":
+		_, pos = argument(s, pos)
+		c.paragraphBreak()
+	default:
+		c.paragraphBreak()
+	}
+	return pos
+}
+
+func (c *converter) endEnvironment(env string) {
+	if strings.Contains(env, "itemize") || strings.Contains(env, "enumerate") || strings.Contains(env, "description") {
+		c.endList()
+		return
+	}
+	c.paragraphBreak()
+}
+
+// text adds text in the current style. Runs of white space are collapsed.
+func (c *converter) text(t string) {
+	words := strings.Fields(t)
+	if len(words) == 0 {
+		c.space()
+		return
+	}
+	if unicode.IsSpace(rune(t[0])) {
+		c.space()
+	}
+	if c.sepPending {
+		c.sepPending = false
+		c.line = append(trimLine(c.line), Span{Text: " — "})
+	}
+	c.appendSpan(strings.Join(words, " "))
+	if unicode.IsSpace(rune(t[len(t)-1])) {
+		c.space()
+	}
+}
+
+// space adds a single space between words of the line.
+func (c *converter) space() {
+	if n := len(c.line); n > 0 && !c.sepPending && !strings.HasSuffix(c.line[n-1].Text, " ") {
+		c.appendSpan(" ")
+	}
+}
+
+// appendSpan adds text to the line, extending the last span when the style
+// is the same.
+func (c *converter) appendSpan(t string) {
+	span := Span{Text: t, Bold: c.style.bold, Italic: c.style.italic, URL: c.style.url}
+	if n := len(c.line); n > 0 {
+		last := &c.line[n-1]
+		if last.Bold == span.Bold && last.Italic == span.Italic && last.URL == span.URL {
+			last.Text += t
+			return
+		}
+	}
+	c.line = append(c.line, span)
+}
+
+// trimLine removes white space at both ends of a line and empty spans.
+func trimLine(l Line) Line {
+	var out Line
+	for _, s := range l {
+		if s.Text != "" {
+			out = append(out, s)
+		}
+	}
+	for len(out) > 0 {
+		out[0].Text = strings.TrimLeft(out[0].Text, " ")
+		if out[0].Text != "" {
+			break
+		}
+		out = out[1:]
+	}
+	for len(out) > 0 {
+		last := &out[len(out)-1]
+		last.Text = strings.TrimRight(last.Text, " ")
+		if last.Text != "" {
+			break
+		}
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+// cell separates table cells and similar side-by-side content. The
+// separator is only added before further text, so empty cells vanish.
+func (c *converter) cell() {
+	if len(trimLine(c.line)) > 0 {
+		c.sepPending = true
+	}
+}
+
+// endLine finishes the current line.
+func (c *converter) endLine() {
+	if line := trimLine(c.line); len(line) > 0 {
+		c.lines = append(c.lines, line)
+	}
+	c.line = nil
+	c.sepPending = false
+}
+
+// paragraphBreak finishes the current paragraph. Within a list its lines
+// belong to the current item.
+func (c *converter) paragraphBreak() {
+	c.endLine()
+	if len(c.lines) == 0 {
+		return
+	}
+	lines := c.lines
+	c.lines = nil
+	if n := len(c.lists); n > 0 {
+		it := c.currentItem(c.lists[n-1])
+		if len(it.Blocks) == 0 {
+			it.Lines = append(it.Lines, lines...)
+		} else {
+			it.Blocks = append(it.Blocks, Block{Kind: Paragraph, Lines: lines})
+		}
+		return
+	}
+	c.addBlock(Block{Kind: Paragraph, Lines: lines})
+}
+
+// currentItem returns the last item of list, starting one for text that
+// comes before the first \item.
+func (c *converter) currentItem(list *Block) *Item {
+	if len(list.Items) == 0 {
+		list.Items = append(list.Items, Item{})
+	}
+	return &list.Items[len(list.Items)-1]
+}
+
+// addBlock adds a finished block to the open list item or section.
+func (c *converter) addBlock(b Block) {
+	if n := len(c.lists); n > 0 {
+		it := c.currentItem(c.lists[n-1])
+		it.Blocks = append(it.Blocks, b)
+		return
+	}
+	if len(c.sections) == 0 {
+		c.sections = append(c.sections, Section{})
+	}
+	s := &c.sections[len(c.sections)-1]
+	s.Blocks = append(s.Blocks, b)
+}
+
+func (c *converter) beginList(ordered bool) {
+	c.paragraphBreak()
+	c.lists = append(c.lists, &Block{Kind: List, Ordered: ordered})
+}
+
+func (c *converter) endList() {
+	c.paragraphBreak()
+	n := len(c.lists)
+	if n == 0 {
+		return
+	}
+	list := c.lists[n-1]
+	c.lists = c.lists[:n-1]
+	var items []Item
+	for _, it := range list.Items {
+		if len(it.Lines) > 0 || len(it.Blocks) > 0 {
+			items = append(items, it)
+		}
+	}
+	if len(items) == 0 {
+		return
+	}
+	list.Items = items
+	c.addBlock(*list)
+}
+
+// item starts a list item. Description labels are kept in bold, bullet
+// symbols are dropped.
+func (c *converter) item(label string) {
+	c.paragraphBreak()
+	if len(c.lists) == 0 {
+		c.beginList(false)
+	}
+	list := c.lists[len(c.lists)-1]
+	list.Items = append(list.Items, Item{})
+	switch label = strings.TrimSpace(label); label {
+	case "", "•", "-", "–", "—", "·", "*", "○", "▪":
+	default:
+		saved := c.style
+		c.style.bold = true
+		c.text(label + " ")
+		c.style = saved
+	}
+}
+
+func (c *converter) heading(title string) {
+	c.paragraphBreak()
+	if title = strings.TrimSpace(title); title != "" {
+		c.addBlock(Block{Kind: Heading, Lines: []Line{{{Text: title}}}})
+	}
+}
+
+func (c *converter) startSection(title string) {
+	c.closeLists()
+	c.sections = append(c.sections, Section{Title: strings.TrimSpace(title)})
+}
+
+func (c *converter) closeLists() {
+	for len(c.lists) > 0 {
+		c.endList()
+	}
+	c.paragraphBreak()
+}
+
+// finish closes everything still open and makes sure there is a section to
+// read from.
+func (c *converter) finish() {
+	c.closeLists()
+	if len(c.sections) == 0 {
+		c.sections = append(c.sections, Section{})
+	}
+}
+
+// unescapeURL removes the escapes LaTeX needs in URLs.
+func unescapeURL(url string) string {
+	return strings.NewReplacer(`\#`, "#", `\%`, "%", `\_`, "_", `\&`, "&", `\~`, "~").Replace(strings.TrimSpace(url))
+}
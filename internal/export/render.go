@@ -0,0 +1,268 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Markdown renders doc as Markdown.
+func Markdown(doc Document) string {
+	var sb strings.Builder
+	if doc.Name != "" {
+		sb.WriteString("# " + escapeMarkdown(doc.Name) + "\n\n")
+	}
+	if len(doc.Contact) > 0 {
+		sb.WriteString(markdownLines(doc.Contact, "") + "\n\n")
+	}
+	for _, s := range doc.Sections {
+		sb.WriteString("## " + escapeMarkdown(s.Title) + "\n\n")
+		markdownBlocks(&sb, s.Blocks, "")
+	}
+	return strings.TrimSpace(sb.String()) + "\n"
+}
+
+func markdownBlocks(sb *strings.Builder, blocks []Block, indent string) {
+	for _, b := range blocks {
+		switch b.Kind {
+		case Heading:
+			sb.WriteString(indent + "### " + markdownLines(b.Lines, indent) + "\n\n")
+		case Paragraph:
+			sb.WriteString(indent + markdownLines(b.Lines, indent) + "\n\n")
+		case List:
+			markdownList(sb, b, indent)
+			if indent == "" {
+				sb.WriteString("\n")
+			}
+		}
+	}
+}
+
+// markdownList writes a tight list. Nested lists are indented below their
+// item.
+func markdownList(sb *strings.Builder, list Block, indent string) {
+	for n, it := range list.Items {
+		marker := "- "
+		if list.Ordered {
+			marker = fmt.Sprintf("%d. ", n+1)
+		}
+		inner := indent + strings.Repeat(" ", len(marker))
+		sb.WriteString(indent + marker + markdownLines(it.Lines, inner) + "\n")
+		for _, b := range it.Blocks {
+			if b.Kind == List {
+				markdownList(sb, b, inner)
+			} else {
+				sb.WriteString(inner + markdownLines(b.Lines, inner) + "\n")
+			}
+		}
+	}
+}
+
+// markdownLines joins lines with hard line breaks.
+func markdownLines(lines []Line, indent string) string {
+	parts := make([]string, len(lines))
+	for i, l := range lines {
+		parts[i] = markdownLine(l)
+	}
+	return strings.Join(parts, "  \n"+indent)
+}
+
+func markdownLine(l Line) string {
+	var sb strings.Builder
+	for _, s := range l {
+		lead, text, trail := splitSpace(s.Text)
+		text = escapeMarkdown(text)
+		if text != "" {
+			switch {
+			case s.Bold && s.Italic:
+				text = "***" + text + "***"
+			case s.Bold:
+				text = "**" + text + "**"
+			case s.Italic:
+				text = "*" + text + "*"
+			}
+			if s.URL != "" {
+				text = "[" + text + "](" + s.URL + ")"
+			}
+		}
+		sb.WriteString(lead + text + trail)
+	}
+	return sb.String()
+}
+
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "`", "\\`", "<", `\<`)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// splitSpace separates the white space around s, which must stay outside
+// of emphasis markers.
+func splitSpace(s string) (string, string, string) {
+	text := strings.TrimLeft(s, " ")
+	lead := s[:len(s)-len(text)]
+	trimmed := strings.TrimRight(text, " ")
+	return lead, trimmed, text[len(trimmed):]
+}
+
+// htmlPage is the standalone page around an HTML export.
+const htmlPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>%s</title>
+<style>
+body { max-width: 48rem; margin: 2rem auto; padding: 0 1rem; font-family: Georgia, "Times New Roman", serif; line-height: 1.45; color: #222; }
+header { text-align: center; margin-bottom: 1.5rem; }
+h1 { margin: 0 0 .25rem; font-size: 2rem; }
+h2 { font-size: 1.1rem; text-transform: uppercase; letter-spacing: .05em; border-bottom: 1px solid #999; padding-bottom: .15rem; margin: 1.5rem 0 .5rem; }
+h3 { font-size: 1rem; margin: .75rem 0 .25rem; }
+p { margin: .25rem 0 .5rem; }
+ul, ol { margin: .25rem 0 .5rem; padding-left: 1.25rem; }
+li { margin: .15rem 0; }
+a { color: #1a4f8b; }
+@media print { body { margin: 0; } a { color: inherit; text-decoration: none; } }
+</style>
+</head>
+<body>
+%s</body>
+</html>
+`
+
+// HTML renders doc as a standalone page with an embedded stylesheet.
+func HTML(doc Document) string {
+	var sb strings.Builder
+	if doc.Name != "" || len(doc.Contact) > 0 {
+		sb.WriteString("<header>\n")
+		if doc.Name != "" {
+			sb.WriteString("<h1>" + html.EscapeString(doc.Name) + "</h1>\n")
+		}
+		if len(doc.Contact) > 0 {
+			sb.WriteString("<p>" + htmlLines(doc.Contact) + "</p>\n")
+		}
+		sb.WriteString("</header>\n")
+	}
+	for _, s := range doc.Sections {
+		sb.WriteString("<section>\n<h2>" + html.EscapeString(s.Title) + "</h2>\n")
+		htmlBlocks(&sb, s.Blocks)
+		sb.WriteString("</section>\n")
+	}
+	title := doc.Name
+	if title == "" {
+		title = "Resume"
+	}
+	return fmt.Sprintf(htmlPage, html.EscapeString(title), sb.String())
+}
+
+func htmlBlocks(sb *strings.Builder, blocks []Block) {
+	for _, b := range blocks {
+		switch b.Kind {
+		case Heading:
+			sb.WriteString("<h3>" + htmlLines(b.Lines) + "</h3>\n")
+		case Paragraph:
+			sb.WriteString("<p>" + htmlLines(b.Lines) + "</p>\n")
+		case List:
+			tag := "ul"
+			if b.Ordered {
+				tag = "ol"
+			}
+			sb.WriteString("<" + tag + ">\n")
+			for _, it := range b.Items {
+				sb.WriteString("<li>" + htmlLines(it.Lines))
+				if len(it.Blocks) > 0 {
+					sb.WriteString("\n")
+					htmlBlocks(sb, it.Blocks)
+				}
+				sb.WriteString("</li>\n")
+			}
+			sb.WriteString("</" + tag + ">\n")
+		}
+	}
+}
+
+func htmlLines(lines []Line) string {
+	parts := make([]string, len(lines))
+	for i, l := range lines {
+		parts[i] = htmlLine(l)
+	}
+	return strings.Join(parts, "<br>\n")
+}
+
+func htmlLine(l Line) string {
+	var sb strings.Builder
+	for _, s := range l {
+		text := html.EscapeString(s.Text)
+		if s.Italic {
+			text = "<em>" + text + "</em>"
+		}
+		if s.Bold {
+			text = "<strong>" + text + "</strong>"
+		}
+		if s.URL != "" {
+			text = `<a href="` + html.EscapeString(s.URL) + `">` + text + "</a>"
+		}
+		sb.WriteString(text)
+	}
+	return sb.String()
+}
+
+// Text renders doc as plain text for applicant tracking systems: no
+// columns or decoration, section titles in capitals and hyphens for list
+// items.
+func Text(doc Document) string {
+	var sb strings.Builder
+	if doc.Name != "" {
+		sb.WriteString(doc.Name + "\n")
+	}
+	for _, l := range doc.Contact {
+		sb.WriteString(textLine(l) + "\n")
+	}
+	for _, s := range doc.Sections {
+		sb.WriteString("\n" + strings.ToUpper(s.Title) + "\n\n")
+		textBlocks(&sb, s.Blocks, "")
+	}
+	return strings.TrimSpace(sb.String()) + "\n"
+}
+
+func textBlocks(sb *strings.Builder, blocks []Block, indent string) {
+	for i, b := range blocks {
+		switch b.Kind {
+		case Heading, Paragraph:
+			if i > 0 && indent == "" {
+				sb.WriteString("\n")
+			}
+			for _, l := range b.Lines {
+				sb.WriteString(indent + textLine(l) + "\n")
+			}
+		case List:
+			for _, it := range b.Items {
+				for n, l := range it.Lines {
+					marker := "- "
+					if n > 0 {
+						marker = "  "
+					}
+					sb.WriteString(indent + marker + textLine(l) + "\n")
+				}
+				textBlocks(sb, it.Blocks, indent+"  ")
+			}
+		}
+	}
+}
+
+// textLine writes links as "text (url)" unless the text already shows
+// the address.
+func textLine(l Line) string {
+	var sb strings.Builder
+	for i, s := range l {
+		sb.WriteString(s.Text)
+		if s.URL == "" || (i+1 < len(l) && l[i+1].URL == s.URL) {
+			continue
+		}
+		address := strings.TrimPrefix(strings.TrimPrefix(s.URL, "mailto:"), "tel:")
+		if !strings.Contains(strings.ToLower(address), strings.ToLower(strings.TrimSpace(s.Text))) {
+			sb.WriteString(" (" + address + ")")
+		}
+	}
+	return sb.String()
+}
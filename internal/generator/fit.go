@@ -0,0 +1,144 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/FabricSoul/auto-resume/internal/analysis"
+	"github.com/FabricSoul/auto-resume/internal/latex"
+	"github.com/FabricSoul/auto-resume/internal/pdf"
+	"github.com/FabricSoul/auto-resume/internal/types"
+)
+
+// MaxFitAttempts caps how often a resume is shortened to fit its page limit.
+const MaxFitAttempts = 3
+
+const condensePrompt = `You are a professional resume writer. The resume below compiles to %d pages but must fit on %d.
+Shorten it by condensing the bullet points listed below, which are the least relevant to the job description.
+Follow these rules:
+1. Rewrite the listed bullet points in fewer words, merge them or drop the weakest ones
+2. Leave everything else unchanged
+3. Keep the same LaTeX format
+4. Do not invent new experiences
+
+Bullet points to condense, least relevant first:
+%s
+
+Resume:
+%s
+
+Job Description:
+%s
+
+Please provide the complete shortened resume in LaTeX format.`
+
+// FitResult describes how an output was fitted to its page limit.
+type FitResult struct {
+	// Pages is the page count of the last draft that compiled.
+	Pages int
+	// Attempts is the number of times the model was asked to shorten.
+	Attempts int
+	// Stopped is why shortening stopped before fitting when an earlier
+	// draft was kept, such as a later draft that does not compile.
+	Stopped error
+}
+
+// Fits reports whether the last compile stayed within limit pages.
+func (r FitResult) Fits(limit int) bool {
+	return r.Pages <= limit
+}
+
+// CompiledPages compiles out and returns its PDF and page count.
+func CompiledPages(ctx context.Context, projectDir string, cfg types.ProjectConfig, out types.Output) (string, int, error) {
+	path, err := types.CompileOutput(ctx, projectDir, cfg, out)
+	if err != nil {
+		return "", 0, err
+	}
+	pages, err := pdf.PageCount(ctx, path)
+	if err != nil {
+		return path, 0, fmt.Errorf("failed to count pages of %s: %w", path, err)
+	}
+	return path, pages, nil
+}
+
+// FitToPages asks model to condense the bullet points of out that match the
+// fewest job keywords while it runs over limit pages, at most MaxFitAttempts
+// times. out compiles to pages pages. Drafts are compiled in a scratch
+// directory, the output's files are left for the caller to write. The
+// returned output carries the last LaTeX that compiled, which may still be
+// too long. When a later attempt fails, the draft before it is returned and
+// the failure is kept in FitResult.Stopped.
+func FitToPages(ctx context.Context, model types.AIModel, projectDir string, cfg types.ProjectConfig, out types.Output, pages, limit int) (types.Output, FitResult, error) {
+	result := FitResult{Pages: pages}
+	if result.Fits(limit) {
+		return out, result, nil
+	}
+	scratch, err := os.MkdirTemp("", "auto-resume-fit-")
+	if err != nil {
+		return out, result, fmt.Errorf("failed to create scratch directory: %w", err)
+	}
+	defer os.RemoveAll(scratch)
+	opts := latex.CompileOptions{
+		Engine:      cfg.Engine,
+		SearchPaths: []string{types.OutputDir(projectDir, out.Slug), projectDir},
+	}
+
+	keywords := analysis.Keywords(out.JobDescription)
+	for result.Attempts < MaxFitAttempts && !result.Fits(limit) {
+		result.Attempts++
+		bullets := analysis.Bullets(out.GeneratedOutput, keywords)
+		prompt := fmt.Sprintf(condensePrompt, result.Pages, limit,
+			condenseList(bullets, result.Attempts), out.GeneratedOutput, out.JobDescription)
+		response, err := Complete(ctx, model, prompt)
+		var pages int
+		if err == nil {
+			if pages, err = draftPages(ctx, scratch, response, opts); err != nil {
+				err = fmt.Errorf("shortened resume does not compile: %w", err)
+			}
+		}
+		if err != nil {
+			if result.Attempts == 1 {
+				return out, result, err
+			}
+			// Keep the draft of the attempt before.
+			result.Stopped = err
+			break
+		}
+		out.GeneratedOutput, result.Pages = response, pages
+	}
+	return out, result, nil
+}
+
+// draftPages compiles tex in dir and returns its page count.
+func draftPages(ctx context.Context, dir, tex string, opts latex.CompileOptions) (int, error) {
+	texPath := filepath.Join(dir, types.OutputResumeFile)
+	if err := os.WriteFile(texPath, []byte(tex), 0644); err != nil {
+		return 0, fmt.Errorf("failed to write draft: %w", err)
+	}
+	path, err := latex.Compile(ctx, texPath, opts)
+	if err != nil {
+		return 0, err
+	}
+	pages, err := pdf.PageCount(ctx, path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pages of %s: %w", path, err)
+	}
+	return pages, nil
+}
+
+// condenseList lists the bullet points to condense, a larger share of them
+// with every attempt.
+func condenseList(bullets []analysis.Bullet, attempt int) string {
+	if len(bullets) == 0 {
+		return "(no bullet points found, condense the longest descriptions instead)"
+	}
+	n := min(len(bullets), max(3, (len(bullets)*attempt+2)/3))
+	var sb strings.Builder
+	for _, b := range bullets[:n] {
+		fmt.Fprintf(&sb, "- %s\n", b.Text)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,114 @@
+// Package generator talks to the configured LLMs to produce tailored
+// resumes. It is shared by the TUI and the command line interface.
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/coverletter"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/teilomillet/gollm"
+)
+
+// Timeout bounds a single generation request.
+const Timeout = 99 * time.Minute
+
+var ErrEmptyResponse = errors.New("received empty response from LLM")
+
+// resumePrompt is rendered like the cover letter prompt, with the same
+// coverletter.PromptData.
+var resumePrompt = template.Must(template.New("resume prompt").Delims("[[", "]]").
+	Funcs(template.FuncMap{"join": strings.Join}).Parse(
+	`You are a professional resume writer. Your task is to modify the given resume to better target a specific job description.[[if .Job.Title]]
+The role is [[.Job.Title]][[if .Job.Company]] at [[.Job.Company]][[end]].[[end]]
+Follow these rules:
+1. Keep the same LaTeX format
+2. Highlight relevant skills and experiences
+3. Use keywords from the job description
+4. Be concise and professional
+5. Do not invent new experiences
+
+Original Resume:
+[[.Resume]]
+
+Job Description:
+[[.JobDescription]]
+[[- if .Job.Required]]
+
+Required skills: [[join .Job.Required ", "]][[end]]
+[[- if .Job.NiceToHave]]
+Nice to have: [[join .Job.NiceToHave ", "]][[end]]
+
+Please provide the modified resume in LaTeX format.`))
+
+// NewLLM creates an LLM client for the given model configuration.
+func NewLLM(model types.AIModel) (gollm.LLM, error) {
+	llm, err := gollm.NewLLM(
+		gollm.SetProvider(model.Provider),
+		gollm.SetModel(model.Model),
+		gollm.SetAPIKey(model.APIKey),
+		gollm.SetTimeout(Timeout),
+		gollm.SetMaxTokens(100000), // Increase max response length
+		gollm.SetTemperature(0.7),  // Adjust creativity (0.0-1.0)
+		gollm.SetMemory(100000),    // Increase context window
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LLM instance: %w", err)
+	}
+	return llm, nil
+}
+
+// Complete sends prompt to model and returns the response text.
+func Complete(ctx context.Context, model types.AIModel, prompt string) (string, error) {
+	llm, err := NewLLM(model)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := llm.Generate(ctx, gollm.NewPrompt(prompt))
+	if err != nil {
+		return "", describeError(model, err)
+	}
+	if response == "" {
+		return "", ErrEmptyResponse
+	}
+	return response, nil
+}
+
+// GenerateResume asks model to tailor resume to jobDescription, whose
+// extracted details are job, and returns the modified LaTeX.
+func GenerateResume(ctx context.Context, model types.AIModel, resume, jobDescription string, job types.JobMetadata) (string, error) {
+	var prompt strings.Builder
+	err := resumePrompt.Execute(&prompt, coverletter.PromptData{
+		Resume:         resume,
+		JobDescription: jobDescription,
+		Job:            job,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to render resume prompt: %w", err)
+	}
+	return Complete(ctx, model, prompt.String())
+}
+
+// describeError turns provider errors into messages that tell the user what
+// to fix.
+func describeError(model types.AIModel, err error) error {
+	errMsg := err.Error()
+	switch {
+	case strings.Contains(errMsg, "invalid API key"):
+		return fmt.Errorf("invalid API key for %s", model.Provider)
+	case strings.Contains(errMsg, "rate limit"):
+		return fmt.Errorf("rate limit exceeded for %s", model.Provider)
+	case strings.Contains(errMsg, "model not found"):
+		return fmt.Errorf("model %s not found for %s", model.Model, model.Provider)
+	case strings.Contains(errMsg, "connection refused"):
+		return fmt.Errorf("connection to %s failed - is the service running?", model.Provider)
+	default:
+		return fmt.Errorf("LLM error: %w", err)
+	}
+}
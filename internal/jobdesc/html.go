@@ -0,0 +1,591 @@
+package jobdesc
+
+import (
+	"encoding/json"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/net/html/charset"
+)
+
+// minDescription is the length below which a description found in the page
+// metadata or a marked-up element is not trusted to be the whole posting.
+const minDescription = 200
+
+// page holds what is read from a saved page before its boilerplate is removed.
+type page struct {
+	title    string
+	ogTitle  string
+	siteName string
+	heading  string
+	// Fields of a schema.org JobPosting embedded as JSON-LD, which most job
+	// boards include for search engines.
+	posting            types.JobMetadata
+	postingDescription string
+	hasPosting         bool
+}
+
+// FromHTML reads a posting from a saved web page. Navigation, banners,
+// footers and similar boilerplate are dropped and only the main posting
+// content is kept.
+func FromHTML(r io.Reader) (Posting, error) {
+	r, err := charset.NewReader(r, "")
+	if err != nil {
+		return Posting{}, err
+	}
+	doc, err := html.Parse(r)
+	if err != nil {
+		return Posting{}, err
+	}
+
+	var pg page
+	pg.inspect(doc)
+	strip(doc)
+
+	description := render(mainContent(doc))
+	if utf8.RuneCountInString(pg.postingDescription) >= minDescription {
+		description = pg.postingDescription
+	}
+	description = normalize(description)
+
+	job := pg.posting
+	if job.Title == "" && looksLikeTitle(pg.heading) {
+		job.Title = pg.heading
+	}
+	for _, heading := range []string{pg.ogTitle, pg.title} {
+		if job.Title != "" && job.Company != "" {
+			break
+		}
+		title, company := splitTitle(heading, []string{pg.siteName})
+		if job.Title == "" {
+			job.Title = title
+		}
+		if job.Company == "" && company != job.Title {
+			job.Company = company
+		}
+	}
+	if job.Company == "" && pg.siteName != "" && !isBoard(pg.siteName, nil) {
+		job.Company = clean(pg.siteName)
+	}
+
+	// What the page states outright wins over what is read from the text.
+	p := Posting{Job: Extract(description).Merge(job), Description: description}
+	return p, nil
+}
+
+// inspect collects the page title, Open Graph tags, the first heading and
+// any JSON-LD job posting.
+func (pg *page) inspect(n *html.Node) {
+	if n.Type == html.ElementNode {
+		switch n.DataAtom {
+		case atom.Title:
+			if pg.title == "" {
+				pg.title = clean(textContent(n))
+			}
+		case atom.Meta:
+			switch attr(n, "property") {
+			case "og:title":
+				pg.ogTitle = clean(attr(n, "content"))
+			case "og:site_name":
+				pg.siteName = clean(attr(n, "content"))
+			}
+		case atom.H1:
+			if pg.heading == "" {
+				pg.heading = clean(textContent(n))
+			}
+		case atom.Script:
+			if strings.EqualFold(attr(n, "type"), "application/ld+json") {
+				var data any
+				if json.Unmarshal([]byte(textContent(n)), &data) == nil {
+					pg.readJobPosting(data)
+				}
+			}
+			return
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		pg.inspect(c)
+	}
+}
+
+// readJobPosting looks for a schema.org JobPosting in decoded JSON-LD, which
+// may be a single object, an array or an @graph.
+func (pg *page) readJobPosting(data any) {
+	switch v := data.(type) {
+	case []any:
+		for _, item := range v {
+			pg.readJobPosting(item)
+		}
+	case map[string]any:
+		if graph, ok := v["@graph"]; ok {
+			pg.readJobPosting(graph)
+		}
+		if !hasType(v["@type"], "JobPosting") || pg.hasPosting {
+			return
+		}
+		pg.hasPosting = true
+		job := &pg.posting
+		job.Title = clean(stringValue(v["title"]))
+		switch org := v["hiringOrganization"].(type) {
+		case map[string]any:
+			job.Company = clean(stringValue(org["name"]))
+		case string:
+			job.Company = clean(org)
+		}
+		job.Location = postingLocation(v)
+		job.Salary = postingSalary(v["baseSalary"])
+		if d, ok := ParseDate(stringValue(v["validThrough"])); ok {
+			job.Deadline = d
+		}
+		pg.postingDescription = htmlToText(stringValue(v["description"]))
+	}
+}
+
+// postingLocation formats the jobLocation of a JobPosting, which may be one
+// place or a list, as "City, Region, Country".
+func postingLocation(v map[string]any) string {
+	var places []string
+	var add func(any)
+	add = func(loc any) {
+		switch l := loc.(type) {
+		case []any:
+			for _, item := range l {
+				add(item)
+			}
+		case map[string]any:
+			var parts []string
+			switch a := l["address"].(type) {
+			case string:
+				parts = append(parts, a)
+			case map[string]any:
+				for _, key := range []string{"addressLocality", "addressRegion", "addressCountry"} {
+					switch p := a[key].(type) {
+					case string:
+						parts = append(parts, p)
+					case map[string]any:
+						parts = append(parts, stringValue(p["name"]))
+					}
+				}
+			}
+			if place := clean(strings.Join(nonEmpty(parts), ", ")); place != "" {
+				places = append(places, place)
+			}
+		}
+	}
+	add(v["jobLocation"])
+
+	location := strings.Join(places, "; ")
+	if strings.EqualFold(stringValue(v["jobLocationType"]), "TELECOMMUTE") {
+		if location == "" {
+			return "Remote"
+		}
+		location += " (Remote)"
+	}
+	return location
+}
+
+// postingSalary reads a schema.org MonetaryAmount.
+func postingSalary(v any) types.SalaryRange {
+	amount, ok := v.(map[string]any)
+	if !ok {
+		return types.SalaryRange{}
+	}
+	s := types.SalaryRange{Currency: strings.ToUpper(stringValue(amount["currency"]))}
+	value, _ := amount["value"].(map[string]any)
+	if value == nil {
+		s.Min = numberValue(amount["value"])
+		return s
+	}
+	s.Min = numberValue(value["minValue"])
+	s.Max = numberValue(value["maxValue"])
+	if s.IsZero() {
+		s.Min = numberValue(value["value"])
+	}
+	switch strings.ToUpper(stringValue(value["unitText"])) {
+	case "HOUR":
+		s.Period = "hour"
+	case "MONTH":
+		s.Period = "month"
+	case "YEAR":
+		s.Period = "year"
+	}
+	return s
+}
+
+func numberValue(v any) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case string:
+		return parseAmount(n, false)
+	}
+	return 0
+}
+
+func nonEmpty(values []string) []string {
+	var out []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func hasType(t any, name string) bool {
+	switch v := t.(type) {
+	case string:
+		return v == name
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func stringValue(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+// htmlToText renders an HTML fragment, such as a JSON-LD description, as
+// text. Fragments are sometimes escaped twice.
+func htmlToText(fragment string) string {
+	if !strings.Contains(fragment, "<") && strings.Contains(fragment, "&lt;") {
+		fragment = html.UnescapeString(fragment)
+	}
+	doc, err := html.Parse(strings.NewReader(fragment))
+	if err != nil {
+		return fragment
+	}
+	return render(doc)
+}
+
+// Elements that never hold posting content.
+var dropTags = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true,
+	atom.Nav: true, atom.Header: true, atom.Footer: true, atom.Aside: true,
+	atom.Form: true, atom.Button: true, atom.Select: true, atom.Input: true,
+	atom.Svg: true, atom.Iframe: true, atom.Img: true, atom.Picture: true,
+	atom.Video: true, atom.Audio: true, atom.Canvas: true, atom.Dialog: true,
+}
+
+// Words in class and id attributes that mark boilerplate.
+var boilerplateWords = map[string]bool{
+	"nav": true, "navbar": true, "navigation": true, "menu": true, "footer": true,
+	"cookie": true, "cookies": true, "consent": true, "banner": true,
+	"sidebar": true, "share": true, "social": true, "breadcrumb": true,
+	"breadcrumbs": true, "newsletter": true, "subscribe": true, "signup": true,
+	"login": true, "modal": true, "popup": true, "related": true,
+	"similar": true, "recommended": true, "ad": true, "ads": true, "advert": true,
+}
+
+var boilerplateRoles = map[string]bool{
+	"navigation": true, "banner": true, "contentinfo": true, "dialog": true,
+	"complementary": true, "search": true,
+}
+
+// strip removes boilerplate elements from the tree.
+func strip(n *html.Node) {
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		switch {
+		case c.Type == html.CommentNode:
+			n.RemoveChild(c)
+		case c.Type == html.ElementNode && isBoilerplate(c):
+			n.RemoveChild(c)
+		default:
+			strip(c)
+		}
+		c = next
+	}
+}
+
+var attrWords = regexp.MustCompile(`[^a-z0-9]+`)
+
+func isBoilerplate(n *html.Node) bool {
+	switch n.DataAtom {
+	case atom.Html, atom.Body, atom.Main, atom.Article:
+		return false
+	}
+	if dropTags[n.DataAtom] || boilerplateRoles[attr(n, "role")] {
+		return true
+	}
+	if attr(n, "aria-hidden") == "true" || hasAttr(n, "hidden") {
+		return true
+	}
+	for _, key := range []string{"class", "id"} {
+		for _, word := range attrWords.Split(strings.ToLower(attr(n, key)), -1) {
+			if boilerplateWords[word] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// contentMarker matches class, id and similar attributes of elements that
+// hold the posting on common job boards.
+var contentMarker = regexp.MustCompile(`(?i)job[-_]?(posting|description|details|body|content)|description|posting|vacancy`)
+
+// mainContent returns the element holding the posting: the longest element
+// marked up as a job description, else the longest main or article element,
+// else the body.
+func mainContent(doc *html.Node) *html.Node {
+	var marked, landmark, body *html.Node
+	markedLen, landmarkLen := 0, 0
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch {
+			case n.DataAtom == atom.Body:
+				body = n
+			case isMarked(n):
+				if l := textLength(n); l > markedLen {
+					marked, markedLen = n, l
+				}
+			case n.DataAtom == atom.Main || n.DataAtom == atom.Article || attr(n, "role") == "main":
+				if l := textLength(n); l > landmarkLen {
+					landmark, landmarkLen = n, l
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	switch {
+	case marked != nil && markedLen >= minDescription:
+		return marked
+	case landmark != nil:
+		return landmark
+	case body != nil:
+		return body
+	}
+	return doc
+}
+
+func isMarked(n *html.Node) bool {
+	for _, key := range []string{"class", "id", "itemprop", "data-automation-id", "data-testid"} {
+		if contentMarker.MatchString(attr(n, key)) {
+			return true
+		}
+	}
+	return false
+}
+
+func textLength(n *html.Node) int {
+	return utf8.RuneCountInString(strings.Join(strings.Fields(textContent(n)), " "))
+}
+
+func textContent(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+			b.WriteByte(' ')
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return b.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func hasAttr(n *html.Node, key string) bool {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Elements rendered on lines of their own.
+var blockTags = map[atom.Atom]bool{
+	atom.Div: true, atom.Section: true, atom.Article: true, atom.Main: true,
+	atom.Tr: true, atom.Dl: true, atom.Dt: true, atom.Dd: true,
+	atom.Figure: true, atom.Figcaption: true, atom.Address: true,
+}
+
+// Elements separated from their neighbours by a blank line.
+var paragraphTags = map[atom.Atom]bool{
+	atom.P: true, atom.Ul: true, atom.Ol: true, atom.Table: true,
+	atom.Blockquote: true, atom.Pre: true,
+}
+
+var headingLevels = map[atom.Atom]int{
+	atom.H1: 1, atom.H2: 2, atom.H3: 3, atom.H4: 4, atom.H5: 5, atom.H6: 6,
+}
+
+// render turns an HTML tree into Markdown-like text: headings become "#"
+// lines and list items "-" or numbered lines.
+func render(n *html.Node) string {
+	w := &textWriter{}
+	w.node(n)
+	return w.b.String()
+}
+
+type textWriter struct {
+	b        strings.Builder
+	newlines int
+	space    bool
+	// lists holds the next number of each enclosing ordered list, or -1 for
+	// unordered lists.
+	lists []int
+	pre   int
+}
+
+func (w *textWriter) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		w.text(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		w.children(n)
+		return
+	}
+
+	if level, ok := headingLevels[n.DataAtom]; ok {
+		w.lineBreak(2)
+		w.write(strings.Repeat("#", level) + " ")
+		w.children(n)
+		w.lineBreak(2)
+		return
+	}
+
+	switch {
+	case n.DataAtom == atom.Br:
+		w.lineBreak(1)
+	case n.DataAtom == atom.Hr:
+		w.lineBreak(2)
+	case n.DataAtom == atom.Li:
+		w.lineBreak(1)
+		marker := "- "
+		if depth := len(w.lists); depth > 0 {
+			if next := w.lists[depth-1]; next >= 0 {
+				marker = strconv.Itoa(next) + ". "
+				w.lists[depth-1]++
+			}
+			marker = strings.Repeat("  ", depth-1) + marker
+		}
+		w.write(marker)
+		w.children(n)
+		w.lineBreak(1)
+	case n.DataAtom == atom.Ul || n.DataAtom == atom.Ol:
+		// Nested lists continue the item they belong to.
+		if len(w.lists) == 0 {
+			w.lineBreak(2)
+		} else {
+			w.lineBreak(1)
+		}
+		next := -1
+		if n.DataAtom == atom.Ol {
+			next = 1
+		}
+		w.lists = append(w.lists, next)
+		w.children(n)
+		w.lists = w.lists[:len(w.lists)-1]
+		if len(w.lists) == 0 {
+			w.lineBreak(2)
+		}
+	case n.DataAtom == atom.Pre:
+		w.lineBreak(2)
+		w.pre++
+		w.children(n)
+		w.pre--
+		w.lineBreak(2)
+	case paragraphTags[n.DataAtom]:
+		w.lineBreak(2)
+		w.children(n)
+		w.lineBreak(2)
+	case blockTags[n.DataAtom]:
+		w.lineBreak(1)
+		w.children(n)
+		w.lineBreak(1)
+	case n.DataAtom == atom.Td || n.DataAtom == atom.Th:
+		w.space = true
+		w.children(n)
+		w.space = true
+	default:
+		w.children(n)
+	}
+}
+
+func (w *textWriter) children(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		w.node(c)
+	}
+}
+
+// text writes a text node, collapsing whitespace outside pre elements.
+func (w *textWriter) text(s string) {
+	if w.pre > 0 {
+		w.write(s)
+		return
+	}
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		if s != "" {
+			w.space = true
+		}
+		return
+	}
+	first, _ := utf8.DecodeRuneInString(s)
+	last, _ := utf8.DecodeLastRuneInString(s)
+	if (w.space || unicode.IsSpace(first)) && w.newlines == 0 && w.b.Len() > 0 {
+		w.write(" ")
+	}
+	w.write(strings.Join(fields, " "))
+	w.space = unicode.IsSpace(last)
+}
+
+func (w *textWriter) write(s string) {
+	if s == "" {
+		return
+	}
+	w.b.WriteString(s)
+	w.space = false
+	trimmed := strings.TrimRight(s, "\n")
+	if trimmed == "" {
+		w.newlines += len(s)
+	} else {
+		w.newlines = len(s) - len(trimmed)
+	}
+}
+
+// lineBreak ends the current line so that at least n newlines precede the
+// next text.
+func (w *textWriter) lineBreak(n int) {
+	w.space = false
+	if w.b.Len() == 0 {
+		return
+	}
+	if missing := n - w.newlines; missing > 0 {
+		w.write(strings.Repeat("\n", missing))
+	}
+}
@@ -0,0 +1,263 @@
+// Package jobdesc reads job postings from text, Markdown, PDF and saved HTML
+// pages or the clipboard, and guesses the role title and company so outputs
+// can be named automatically.
+package jobdesc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/FabricSoul/auto-resume/internal/pdf"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/atotto/clipboard"
+)
+
+// Posting is a job posting reduced to its text.
+type Posting struct {
+	// Job holds the title, company and other details found in the posting.
+	Job         types.JobMetadata
+	Description string
+	// Source is the file the posting was read from, empty for the clipboard.
+	Source string
+}
+
+var ErrEmpty = errors.New("job posting has no text")
+
+// Extensions lists the file types Load understands.
+var Extensions = map[string]bool{
+	".txt": true, ".md": true, ".markdown": true,
+	".pdf":  true,
+	".html": true, ".htm": true, ".xhtml": true,
+}
+
+// SuggestName returns a name for an output made from the posting, such as
+// "Backend Engineer @ Acme", or "" when neither title nor company is known.
+func (p Posting) SuggestName() string {
+	return SuggestName(p.Job)
+}
+
+// SuggestName names an output after the title and company of a job.
+func SuggestName(job types.JobMetadata) string {
+	switch {
+	case job.Title != "" && job.Company != "":
+		return job.Title + " @ " + job.Company
+	case job.Title != "":
+		return job.Title
+	default:
+		return job.Company
+	}
+}
+
+// Load reads the posting at path. The format is chosen by file extension.
+func Load(ctx context.Context, path string) (Posting, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if !Extensions[ext] {
+		return Posting{}, fmt.Errorf("unsupported job description file %s, expected .txt, .md, .pdf or .html", filepath.Base(path))
+	}
+
+	var p Posting
+	switch ext {
+	case ".pdf":
+		text, err := pdf.Text(ctx, path)
+		if err != nil {
+			return Posting{}, err
+		}
+		p = FromText(text)
+	case ".html", ".htm", ".xhtml":
+		f, err := os.Open(path)
+		if err != nil {
+			return Posting{}, err
+		}
+		defer f.Close()
+		if p, err = FromHTML(f); err != nil {
+			return Posting{}, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+		}
+	default:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return Posting{}, err
+		}
+		p = FromText(string(data))
+	}
+
+	if strings.TrimSpace(p.Description) == "" {
+		return Posting{}, fmt.Errorf("%s: %w", filepath.Base(path), ErrEmpty)
+	}
+	p.Source = path
+	return p, nil
+}
+
+// FromClipboard reads a posting from the system clipboard. Copied HTML is
+// cleaned up like a saved page.
+func FromClipboard() (Posting, error) {
+	text, err := clipboard.ReadAll()
+	if err != nil {
+		return Posting{}, fmt.Errorf("failed to read clipboard: %w", err)
+	}
+	p := Parse(text)
+	if strings.TrimSpace(p.Description) == "" {
+		return Posting{}, fmt.Errorf("clipboard: %w", ErrEmpty)
+	}
+	return p, nil
+}
+
+// Parse reads a posting from text of unknown format, such as stdin or the
+// clipboard.
+func Parse(text string) Posting {
+	if looksLikeHTML(text) {
+		if p, err := FromHTML(strings.NewReader(text)); err == nil && p.Description != "" {
+			return p
+		}
+	}
+	return FromText(text)
+}
+
+func looksLikeHTML(text string) bool {
+	text = strings.TrimSpace(text)
+	return strings.HasPrefix(text, "<") && strings.Contains(text, "</")
+}
+
+// FromText reads a plain text or Markdown posting.
+func FromText(text string) Posting {
+	text = normalize(text)
+	return Posting{Job: Extract(text), Description: text}
+}
+
+var (
+	titleLabel   = regexp.MustCompile(`(?i)^(?:job\s+title|title|position|role)\s*:\s*(.+)$`)
+	companyLabel = regexp.MustCompile(`(?i)^(?:company|employer|organi[sz]ation|hiring\s+company)\s*:\s*(.+)$`)
+	aboutCompany = regexp.MustCompile(`^About\s+([A-Z][\w&.,'’ -]{1,40})$`)
+	// Headings like "About the role" are not company names.
+	aboutGeneric = regexp.MustCompile(`(?i)^(the|this|you|us|our|we|role|job|team|position|company|opportunity)\b`)
+	markup       = regexp.MustCompile(`^[#*>\s-]+|[*_]+`)
+)
+
+// detectLines is how far into a posting the title and company are looked for.
+const detectLines = 40
+
+// detect guesses the role title and company from labelled lines such as
+// "Company: Acme", an "About Acme" heading, or a short first line like
+// "Backend Engineer at Acme".
+func detect(text string) (title, company string) {
+	lines := strings.Split(text, "\n")
+	first := ""
+	for i, line := range lines {
+		if i >= detectLines {
+			break
+		}
+		line = strings.TrimSpace(markup.ReplaceAllString(line, ""))
+		if line == "" {
+			continue
+		}
+		if first == "" {
+			first = line
+		}
+		if m := titleLabel.FindStringSubmatch(line); m != nil && title == "" {
+			title = clean(m[1])
+		}
+		if m := companyLabel.FindStringSubmatch(line); m != nil && company == "" {
+			company = clean(m[1])
+		}
+		if m := aboutCompany.FindStringSubmatch(line); m != nil && company == "" && !aboutGeneric.MatchString(m[1]) {
+			company = clean(m[1])
+		}
+	}
+
+	if title == "" && looksLikeTitle(first) {
+		var c string
+		title, c = splitTitle(first, nil)
+		if company == "" {
+			company = c
+		}
+	}
+	return title, company
+}
+
+// looksLikeTitle reports whether line is short enough to be a heading rather
+// than the start of a paragraph.
+func looksLikeTitle(line string) bool {
+	if line == "" || len(line) > 80 || len(strings.Fields(line)) > 10 {
+		return false
+	}
+	return !strings.ContainsAny(line[len(line)-1:], ".:!?")
+}
+
+var (
+	titleSeparators = regexp.MustCompile(`\s+[|–—·-]\s+`)
+	titleAt         = regexp.MustCompile(`(?i)^(.+?)\s+(?:at|@)\s+(.+)$`)
+)
+
+// splitTitle splits headings like "Backend Engineer - Acme | Jobs" into the
+// role title and company. Parts naming a job board are dropped.
+func splitTitle(s string, ignore []string) (title, company string) {
+	var parts []string
+	for _, part := range titleSeparators.Split(s, -1) {
+		part = clean(part)
+		if part != "" && !isBoard(part, ignore) {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return "", ""
+	}
+	if m := titleAt.FindStringSubmatch(parts[0]); m != nil {
+		return clean(m[1]), clean(m[2])
+	}
+	if len(parts) > 1 {
+		return parts[0], parts[1]
+	}
+	return parts[0], ""
+}
+
+// boards are site names appended to page titles by job boards and applicant
+// tracking systems, such as "Backend Engineer - Acme | LinkedIn".
+var boards = []string{
+	"linkedin", "indeed", "glassdoor", "greenhouse", "lever", "workday",
+	"workable", "smartrecruiters", "ashby", "wellfound", "angellist",
+	"monster", "ziprecruiter", "stepstone", "welcome to the jungle",
+}
+
+// genericParts are title parts that name no role or company.
+var genericParts = map[string]bool{
+	"jobs": true, "careers": true, "job application": true, "job details": true,
+	"apply": true, "apply now": true, "job": true, "home": true,
+}
+
+func isBoard(part string, ignore []string) bool {
+	lower := strings.ToLower(part)
+	if genericParts[lower] {
+		return true
+	}
+	for _, list := range [][]string{ignore, boards} {
+		for _, b := range list {
+			b = strings.ToLower(strings.TrimSpace(b))
+			if b != "" && (lower == b || strings.HasPrefix(lower, b+" ") || strings.HasPrefix(lower, b+".")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func clean(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.Trim(s, " \t-–—|:,.")
+}
+
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// normalize trims trailing spaces and collapses runs of blank lines.
+func normalize(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t ")
+	}
+	text = strings.Join(lines, "\n")
+	return strings.TrimSpace(blankLines.ReplaceAllString(text, "\n\n"))
+}
@@ -0,0 +1,177 @@
+package jobdesc
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/FabricSoul/auto-resume/internal/pdf"
+	"github.com/FabricSoul/auto-resume/internal/types"
+)
+
+const postingBody = `We are looking for a backend engineer to build and run the services behind our
+payments platform. You will design APIs, own them in production and mentor other engineers.
+Requirements: five years of Go, PostgreSQL and Kubernetes experience.`
+
+func TestFromHTML(t *testing.T) {
+	tests := []struct {
+		name        string
+		page        string
+		title       string
+		company     string
+		location    string
+		salary      types.SalaryRange
+		deadline    string
+		contains    string
+		notContains string
+	}{
+		{
+			name: "JSON-LD job posting",
+			page: `<html><head><title>Jobs</title>
+<script type="application/ld+json">{"@context": "https://schema.org", "@type": "JobPosting",
+ "title": "Senior Backend Engineer", "hiringOrganization": {"@type": "Organization", "name": "Acme"},
+ "jobLocation": {"address": {"addressLocality": "Berlin", "addressCountry": "DE"}}, "jobLocationType": "TELECOMMUTE",
+ "baseSalary": {"currency": "eur", "value": {"minValue": 70000, "maxValue": 90000, "unitText": "YEAR"}},
+ "validThrough": "2025-06-30", "description": "<p>` + strings.ReplaceAll(postingBody, "\n", " ") + `</p>"}</script></head>
+<body><main><p>` + postingBody + `</p></main></body></html>`,
+			title:    "Senior Backend Engineer",
+			company:  "Acme",
+			location: "Berlin, DE (Remote)",
+			salary:   types.SalaryRange{Min: 70000, Max: 90000, Currency: "EUR", Period: "year"},
+			deadline: "2025-06-30",
+			contains: "payments platform",
+		},
+		{
+			name: "JSON-LD in a graph",
+			page: `<html><head><script type="application/ld+json">{"@graph": [{"@type": "WebPage"},
+ {"@type": ["JobPosting"], "title": "Data Engineer", "hiringOrganization": "Globex"}]}</script></head>
+<body><main><p>` + postingBody + `</p></main></body></html>`,
+			title:   "Data Engineer",
+			company: "Globex",
+		},
+		{
+			name: "og:title without the board name",
+			page: `<html><head><title>Jobs</title>
+<meta property="og:title" content="Platform Engineer - Initech | LinkedIn"></head>
+<body><main><p>` + postingBody + `</p></main></body></html>`,
+			title:   "Platform Engineer",
+			company: "Initech",
+		},
+		{
+			name: "boilerplate is dropped",
+			page: `<html><body><nav>Home Jobs Sign in</nav><header>Cookie banner text</header>
+<article><h1>Backend Engineer</h1><p>` + postingBody + `</p></article>
+<footer>Copyright footer links</footer></body></html>`,
+			title:       "Backend Engineer",
+			contains:    "mentor other engineers",
+			notContains: "Copyright footer",
+		},
+		{
+			name:     "invalid JSON-LD is ignored",
+			page:     `<html><head><script type="application/ld+json">{not json</script></head><body><main><p>` + postingBody + `</p></main></body></html>`,
+			contains: "payments platform",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := FromHTML(strings.NewReader(tt.page))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.title != "" && p.Job.Title != tt.title {
+				t.Errorf("title = %q, want %q", p.Job.Title, tt.title)
+			}
+			if tt.company != "" && p.Job.Company != tt.company {
+				t.Errorf("company = %q, want %q", p.Job.Company, tt.company)
+			}
+			if tt.location != "" && p.Job.Location != tt.location {
+				t.Errorf("location = %q, want %q", p.Job.Location, tt.location)
+			}
+			if !tt.salary.IsZero() && p.Job.Salary != tt.salary {
+				t.Errorf("salary = %+v, want %+v", p.Job.Salary, tt.salary)
+			}
+			if tt.deadline != "" && p.Job.Deadline.String() != tt.deadline {
+				t.Errorf("deadline = %s, want %s", p.Job.Deadline, tt.deadline)
+			}
+			if tt.contains != "" && !strings.Contains(p.Description, tt.contains) {
+				t.Errorf("description does not contain %q:\n%s", tt.contains, p.Description)
+			}
+			if tt.notContains != "" && strings.Contains(p.Description, tt.notContains) {
+				t.Errorf("description contains %q:\n%s", tt.notContains, p.Description)
+			}
+			if strings.Contains(p.Description, "<") {
+				t.Errorf("description keeps markup:\n%s", p.Description)
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	tests := []struct {
+		name    string
+		path    string
+		wantErr error
+	}{
+		{"unsupported extension", write("job.docx", postingBody), nil},
+		{"missing file", filepath.Join(dir, "missing.txt"), os.ErrNotExist},
+		{"empty text", write("empty.txt", " \n\n "), ErrEmpty},
+		{"empty page", write("empty.html", "<html><body><nav>Home</nav></body></html>"), ErrEmpty},
+	}
+	if _, err := exec.LookPath("pdftotext"); err != nil {
+		tests = append(tests, struct {
+			name    string
+			path    string
+			wantErr error
+		}{"PDF without pdftotext", write("job.pdf", "%PDF-1.4"), pdf.ErrNoPdftotext})
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(context.Background(), tt.path)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "job.md")
+	if err := os.WriteFile(path, []byte("# Backend Engineer\n\n"+postingBody), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := Load(context.Background(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Source != path {
+		t.Errorf("source = %q, want %q", p.Source, path)
+	}
+	if !strings.Contains(p.Description, "payments platform") {
+		t.Errorf("description = %q", p.Description)
+	}
+}
+
+func TestParseDetectsHTML(t *testing.T) {
+	p := Parse("<div><h1>Backend Engineer</h1><p>" + postingBody + "</p></div>")
+	if strings.Contains(p.Description, "<p>") {
+		t.Errorf("copied HTML kept its markup: %q", p.Description)
+	}
+	if got := Parse(postingBody).Description; !strings.Contains(got, "payments platform") {
+		t.Errorf("plain text description = %q", got)
+	}
+}
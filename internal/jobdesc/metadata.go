@@ -0,0 +1,369 @@
+package jobdesc
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// maxSkills bounds the required and nice-to-have lists.
+const maxSkills = 15
+
+// Extract reads structured metadata from a job description using simple
+// rules. The result can be refined by a model with Refine.
+func Extract(text string) types.JobMetadata {
+	text = normalize(text)
+	title, company := detect(text)
+	job := types.JobMetadata{
+		Company:     company,
+		Title:       title,
+		Location:    findLocation(text),
+		Seniority:   findSeniority(title, text),
+		Salary:      findSalary(text),
+		Deadline:    findDeadline(text),
+		ExtractedBy: "rules",
+	}
+	job.Required, job.NiceToHave = findSkills(text)
+	return job
+}
+
+var (
+	locationLabel = regexp.MustCompile(`(?i)^(?:location|locations|job location|based in|office|where)\s*:\s*(.+)$`)
+	workMode      = regexp.MustCompile(`(?i)\b(fully remote|remote|hybrid|on-?site|in-office)\b`)
+)
+
+func findLocation(text string) string {
+	location := ""
+	for _, line := range headLines(text) {
+		if m := locationLabel.FindStringSubmatch(line); m != nil {
+			location = clean(m[1])
+			break
+		}
+	}
+
+	mode := ""
+	switch m := strings.ToLower(workMode.FindString(text)); m {
+	case "":
+	case "remote", "fully remote":
+		mode = "Remote"
+	case "hybrid":
+		mode = "Hybrid"
+	default:
+		mode = "On-site"
+	}
+	switch {
+	case location == "":
+		return mode
+	case mode != "" && !strings.Contains(strings.ToLower(location), strings.ToLower(mode)):
+		return location + " (" + mode + ")"
+	}
+	return location
+}
+
+// seniorityPatterns are checked in order, so the more specific levels win.
+var seniorityPatterns = []struct {
+	level string
+	re    *regexp.Regexp
+}{
+	{"Executive", regexp.MustCompile(`(?i)\b(head of|vp|vice president|chief|cto|cio)\b`)},
+	{"Director", regexp.MustCompile(`(?i)\bdirector\b`)},
+	{"Manager", regexp.MustCompile(`(?i)\b(engineering|development|people|team)\s+manager\b`)},
+	{"Principal", regexp.MustCompile(`(?i)\bprincipal\b`)},
+	{"Staff", regexp.MustCompile(`(?i)\bstaff\b`)},
+	{"Lead", regexp.MustCompile(`(?i)\b(lead|tech lead)\b`)},
+	{"Senior", regexp.MustCompile(`(?i)\b(senior|sr)\b`)},
+	{"Mid", regexp.MustCompile(`(?i)\b(mid[- ]level|intermediate)\b`)},
+	{"Junior", regexp.MustCompile(`(?i)\b(junior|jr|entry[- ]level|graduate)\b`)},
+	{"Intern", regexp.MustCompile(`(?i)\b(intern|internship|trainee|apprentice)\b`)},
+}
+
+var yearsOfExperience = regexp.MustCompile(`(?i)(\d{1,2})\+?\s*(?:-\s*\d{1,2}\s*)?\+?\s*years?(?:\s+of)?(?:\s+\w+){0,3}\s+experience`)
+
+// findSeniority reads the level from the title, falling back to the years of
+// experience asked for.
+func findSeniority(title, text string) string {
+	for _, p := range seniorityPatterns {
+		if p.re.MatchString(title) {
+			return p.level
+		}
+	}
+	m := yearsOfExperience.FindStringSubmatch(text)
+	if m == nil {
+		return ""
+	}
+	years, _ := strconv.Atoi(m[1])
+	switch {
+	case years < 2:
+		return "Junior"
+	case years < 5:
+		return "Mid"
+	}
+	return "Senior"
+}
+
+var (
+	salaryRange  = regexp.MustCompile(`(?i)(US\$|CA\$|A\$|[$€£¥]|\b(?:USD|EUR|GBP|CAD|AUD|CHF|INR|JPY)\b)?\s?(\d{1,3}(?:[,.\s]\d{3})+|\d+(?:\.\d+)?)\s?(k\b)?\s*(?:-|–|—|to)\s*(US\$|CA\$|A\$|[$€£¥]|\b(?:USD|EUR|GBP|CAD|AUD|CHF|INR|JPY)\b)?\s?(\d{1,3}(?:[,.\s]\d{3})+|\d+(?:\.\d+)?)\s?(k\b)?\s*(\b(?:USD|EUR|GBP|CAD|AUD|CHF|INR|JPY)\b)?`)
+	salaryAmount = regexp.MustCompile(`(?i)(US\$|CA\$|A\$|[$€£¥]|\b(?:USD|EUR|GBP|CAD|AUD|CHF|INR|JPY)\b)\s?(\d{1,3}(?:[,.\s]\d{3})+|\d+(?:\.\d+)?)\s?(k\b)?`)
+	salaryWords  = regexp.MustCompile(`(?i)\b(salary|compensation|pay|base|rate|wage|ote)\b`)
+	perHour      = regexp.MustCompile(`(?i)per hour|/\s*h(?:ou)?r\b|hourly|an hour`)
+	perMonth     = regexp.MustCompile(`(?i)per month|/\s*mo(?:nth)?\b|monthly|a month`)
+	grouped      = regexp.MustCompile(`^\d{1,3}(?:[,.\s]\d{3})+$`)
+)
+
+var currencySymbols = map[string]string{
+	"$": "USD", "us$": "USD", "ca$": "CAD", "a$": "AUD", "€": "EUR", "£": "GBP", "¥": "JPY",
+}
+
+// findSalary reads the first pay range, or a single amount on a line that
+// talks about pay.
+func findSalary(text string) types.SalaryRange {
+	for _, line := range strings.Split(text, "\n") {
+		var s types.SalaryRange
+		if m := salaryRange.FindStringSubmatch(line); m != nil {
+			currency := firstNonEmpty(m[1], m[4], m[7])
+			if currency == "" && !salaryWords.MatchString(line) {
+				continue
+			}
+			s = types.SalaryRange{
+				Min:      parseAmount(m[2], m[3] != "" || m[6] != ""),
+				Max:      parseAmount(m[5], m[6] != ""),
+				Currency: currencyCode(currency),
+			}
+		} else if m := salaryAmount.FindStringSubmatch(line); m != nil && salaryWords.MatchString(line) {
+			s = types.SalaryRange{Min: parseAmount(m[2], m[3] != ""), Currency: currencyCode(m[1])}
+		} else {
+			continue
+		}
+		if s.Min > s.Max && s.Max != 0 {
+			s.Min, s.Max = s.Max, s.Min
+		}
+
+		switch {
+		case perHour.MatchString(line):
+			s.Period = "hour"
+		case perMonth.MatchString(line):
+			s.Period = "month"
+		case max(s.Min, s.Max) < 1000:
+			s.Period = "hour"
+		default:
+			s.Period = "year"
+		}
+		// Year ranges and small counts are not salaries.
+		if s.Period == "year" && max(s.Min, s.Max) < 10000 {
+			continue
+		}
+		return s
+	}
+	return types.SalaryRange{}
+}
+
+func currencyCode(c string) string {
+	if code, ok := currencySymbols[strings.ToLower(c)]; ok {
+		return code
+	}
+	return strings.ToUpper(c)
+}
+
+// parseAmount reads amounts such as "120,000", "120.000", "95.5" or "120"
+// with a k suffix.
+func parseAmount(s string, thousands bool) int {
+	if grouped.MatchString(s) {
+		s = strings.NewReplacer(",", "", ".", "", " ", "").Replace(s)
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	if thousands {
+		f *= 1000
+	}
+	return int(f)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+var (
+	deadlineWords = regexp.MustCompile(`(?i)deadline|apply by|apply before|applications? (?:close|due)|closing date|open until|valid through`)
+	isoDate       = regexp.MustCompile(`\b(\d{4})-(\d{1,2})-(\d{1,2})(?:\b|T)`)
+	monthDayYear  = regexp.MustCompile(`(?i)\b([a-z]{3,9})\.?\s+(\d{1,2})(?:st|nd|rd|th)?,?\s+(\d{4})\b`)
+	dayMonthYear  = regexp.MustCompile(`(?i)\b(\d{1,2})(?:st|nd|rd|th)?\s+(?:of\s+)?([a-z]{3,9})\.?,?\s+(\d{4})\b`)
+)
+
+// findDeadline reads a date on, or right after, a line mentioning the
+// application deadline.
+func findDeadline(text string) toml.LocalDate {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if !deadlineWords.MatchString(line) {
+			continue
+		}
+		candidates := line
+		if i+1 < len(lines) {
+			candidates += "\n" + lines[i+1]
+		}
+		if d, ok := ParseDate(candidates); ok {
+			return d
+		}
+	}
+	return toml.LocalDate{}
+}
+
+// ParseDate finds the first date written as 2025-03-01, March 1, 2025 or
+// 1 March 2025 in s.
+func ParseDate(s string) (toml.LocalDate, bool) {
+	if m := isoDate.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		day, _ := strconv.Atoi(m[3])
+		return validDate(year, month, day)
+	}
+	if m := monthDayYear.FindStringSubmatch(s); m != nil {
+		if month := monthNumber(m[1]); month != 0 {
+			day, _ := strconv.Atoi(m[2])
+			year, _ := strconv.Atoi(m[3])
+			return validDate(year, month, day)
+		}
+	}
+	if m := dayMonthYear.FindStringSubmatch(s); m != nil {
+		if month := monthNumber(m[2]); month != 0 {
+			day, _ := strconv.Atoi(m[1])
+			year, _ := strconv.Atoi(m[3])
+			return validDate(year, month, day)
+		}
+	}
+	return toml.LocalDate{}, false
+}
+
+func monthNumber(name string) int {
+	name = strings.ToLower(name)
+	for m := time.January; m <= time.December; m++ {
+		full := strings.ToLower(m.String())
+		if name == full || (len(name) >= 3 && strings.HasPrefix(full, name)) {
+			return int(m)
+		}
+	}
+	return 0
+}
+
+func validDate(year, month, day int) (toml.LocalDate, bool) {
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if t.Year() != year || int(t.Month()) != month || t.Day() != day {
+		return toml.LocalDate{}, false
+	}
+	return toml.LocalDate{Year: year, Month: month, Day: day}, true
+}
+
+var (
+	bullet = regexp.MustCompile(`^\s*(?:[-*•·▪◦‣–]|\d+[.)])\s+(.+)$`)
+	// Section headings listing nice-to-have skills are checked first since
+	// they often also say "qualifications".
+	niceHeading     = regexp.MustCompile(`(?i)nice[- ]to[- ]haves?|preferred|bonus|\bplus(es)?\b|desired|good to have|extra credit|would be great|ideally`)
+	requiredHeading = regexp.MustCompile(`(?i)requirements|required|qualifications|must[- ]haves?|what you('|’)?ll need|what you need|you have|you bring|looking for|who you are|skills|experience`)
+	otherHeading    = regexp.MustCompile(`(?i)responsibilities|what you('|’)?ll do|the role|about|benefits|perks|we offer|overview|description|how to apply|compensation|salary`)
+	listSeparator   = regexp.MustCompile(`[,;]`)
+)
+
+// findSkills collects the items listed under requirement and nice-to-have
+// headings.
+func findSkills(text string) (required, nice []string) {
+	var current *[]string
+	var loose []string // lines of the current section that are not bullets
+	flush := func() {
+		if current != nil && len(*current) == 0 {
+			for _, line := range loose {
+				*current = appendSkill(*current, line)
+			}
+		}
+		loose = nil
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if m := bullet.FindStringSubmatch(line); m != nil {
+			if current != nil {
+				*current = appendSkill(*current, m[1])
+			}
+			continue
+		}
+
+		heading, rest, ok := sectionHeading(line)
+		if !ok {
+			if current != nil {
+				loose = append(loose, line)
+			}
+			continue
+		}
+		flush()
+		switch {
+		case niceHeading.MatchString(heading):
+			current = &nice
+		case requiredHeading.MatchString(heading):
+			current = &required
+		default:
+			current = nil
+		}
+		// Inline lists such as "Nice to have: Rust, gRPC".
+		if current != nil && rest != "" {
+			for _, item := range listSeparator.Split(rest, -1) {
+				*current = appendSkill(*current, item)
+			}
+		}
+	}
+	flush()
+	return required, nice
+}
+
+// sectionHeading reports whether line is a heading such as "## Requirements"
+// or "What you'll need:", returning any text following a colon.
+func sectionHeading(line string) (heading, rest string, ok bool) {
+	trimmed := strings.TrimSpace(line)
+	isMarkdown := strings.HasPrefix(trimmed, "#")
+	trimmed = strings.TrimSpace(markup.ReplaceAllString(trimmed, ""))
+	if before, after, found := strings.Cut(trimmed, ":"); found && len(before) <= 40 {
+		return before, strings.TrimSpace(after), true
+	}
+	if trimmed == "" {
+		return "", "", false
+	}
+	known := niceHeading.MatchString(trimmed) || requiredHeading.MatchString(trimmed) || otherHeading.MatchString(trimmed)
+	if isMarkdown || (known && len(trimmed) <= 50 && !strings.ContainsAny(trimmed[len(trimmed)-1:], ".,;")) {
+		return trimmed, "", true
+	}
+	return "", "", false
+}
+
+func appendSkill(list []string, item string) []string {
+	item = clean(markup.ReplaceAllString(item, ""))
+	if item == "" || len(list) >= maxSkills {
+		return list
+	}
+	if runes := []rune(item); len(runes) > 120 {
+		item = string(runes[:117]) + "..."
+	}
+	return append(list, item)
+}
+
+// headLines returns the first lines of text without Markdown markup.
+func headLines(text string) []string {
+	lines := strings.Split(text, "\n")
+	if len(lines) > detectLines {
+		lines = lines[:detectLines]
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(markup.ReplaceAllString(line, ""))
+	}
+	return lines
+}
@@ -0,0 +1,95 @@
+package jobdesc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/FabricSoul/auto-resume/internal/generator"
+	"github.com/FabricSoul/auto-resume/internal/types"
+)
+
+const refinePrompt = `Extract structured information from the job description below.
+Answer with a single JSON object and nothing else, using these keys:
+  "company": hiring company,
+  "title": role title without company or location,
+  "location": city and country, followed by "(Remote)" or "(Hybrid)" when applicable,
+  "seniority": one of %s,
+  "salary_min", "salary_max": whole numbers, 0 when not stated,
+  "currency": ISO 4217 code,
+  "salary_period": "year", "month" or "hour",
+  "required": short names of required skills,
+  "nice_to_have": short names of optional skills,
+  "deadline": application deadline as YYYY-MM-DD.
+Use "" or [] for anything the description does not state. Do not guess.
+
+Job Description:
+%s`
+
+type refined struct {
+	Company      string   `json:"company"`
+	Title        string   `json:"title"`
+	Location     string   `json:"location"`
+	Seniority    string   `json:"seniority"`
+	SalaryMin    float64  `json:"salary_min"`
+	SalaryMax    float64  `json:"salary_max"`
+	Currency     string   `json:"currency"`
+	SalaryPeriod string   `json:"salary_period"`
+	Required     []string `json:"required"`
+	NiceToHave   []string `json:"nice_to_have"`
+	Deadline     string   `json:"deadline"`
+}
+
+// Refine asks model to extract the metadata of description. Fields the model
+// leaves empty keep their value from job, usually the result of Extract.
+func Refine(ctx context.Context, model types.AIModel, description string, job types.JobMetadata) (types.JobMetadata, error) {
+	prompt := fmt.Sprintf(refinePrompt, strings.Join(types.SeniorityLevels, ", "), description)
+	response, err := generator.Complete(ctx, model, prompt)
+	if err != nil {
+		return job, err
+	}
+
+	// Models like to wrap JSON in code fences or add a sentence around it.
+	start, end := strings.Index(response, "{"), strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return job, fmt.Errorf("%s did not answer with JSON", model.Name)
+	}
+	var r refined
+	if err := json.Unmarshal([]byte(response[start:end+1]), &r); err != nil {
+		return job, fmt.Errorf("failed to read the answer of %s: %w", model.Name, err)
+	}
+
+	found := types.JobMetadata{
+		Company:   clean(r.Company),
+		Title:     clean(r.Title),
+		Location:  clean(r.Location),
+		Seniority: normalizeSeniority(r.Seniority),
+		Salary: types.SalaryRange{
+			Min:      int(r.SalaryMin),
+			Max:      int(r.SalaryMax),
+			Currency: strings.ToUpper(strings.TrimSpace(r.Currency)),
+			Period:   strings.ToLower(strings.TrimSpace(r.SalaryPeriod)),
+		},
+		ExtractedBy: model.Name,
+	}
+	for _, s := range r.Required {
+		found.Required = appendSkill(found.Required, s)
+	}
+	for _, s := range r.NiceToHave {
+		found.NiceToHave = appendSkill(found.NiceToHave, s)
+	}
+	if d, ok := ParseDate(r.Deadline); ok {
+		found.Deadline = d
+	}
+	return job.Merge(found), nil
+}
+
+func normalizeSeniority(s string) string {
+	for _, level := range types.SeniorityLevels {
+		if strings.EqualFold(strings.TrimSpace(s), level) {
+			return level
+		}
+	}
+	return ""
+}
@@ -0,0 +1,736 @@
+package jsonresume
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/FabricSoul/auto-resume/internal/export"
+)
+
+// sectionKind is the part of a JSON Resume a LaTeX section maps to.
+type sectionKind int
+
+const (
+	otherSection sectionKind = iota
+	summarySection
+	workSection
+	volunteerSection
+	educationSection
+	projectsSection
+	skillsSection
+	awardsSection
+	certificatesSection
+	publicationsSection
+	languagesSection
+	interestsSection
+	referencesSection
+)
+
+// sectionKeywords maps words in section titles to their kind, checked in
+// order so "Volunteer Experience" is not read as work.
+var sectionKeywords = []struct {
+	kind  sectionKind
+	words []string
+}{
+	{volunteerSection, []string{"volunteer"}},
+	{summarySection, []string{"summary", "profile", "about", "objective"}},
+	{projectsSection, []string{"project"}},
+	{educationSection, []string{"education", "academic"}},
+	{workSection, []string{"experience", "employment", "work", "career"}},
+	{certificatesSection, []string{"certif", "licen"}},
+	{awardsSection, []string{"award", "honor", "honour", "achievement"}},
+	{publicationsSection, []string{"publication", "paper"}},
+	{skillsSection, []string{"skill", "technolog", "competenc", "tools"}},
+	{languagesSection, []string{"language"}},
+	{interestsSection, []string{"interest", "hobbies"}},
+	{referencesSection, []string{"reference"}},
+}
+
+func kindOf(title string) sectionKind {
+	title = strings.ToLower(title)
+	for _, k := range sectionKeywords {
+		for _, w := range k.words {
+			if strings.Contains(title, w) {
+				if k.kind == languagesSection && strings.Contains(title, "programming") {
+					return skillsSection
+				}
+				return k.kind
+			}
+		}
+	}
+	return otherSection
+}
+
+// FromDocument converts a resume parsed from LaTeX to JSON Resume. Sections
+// are recognised by their titles and entries split into their parts by
+// cells and dates. Details LaTeX does not show, such as profile usernames
+// or company websites, are taken from base when it is not nil. Sections
+// that have no place in the schema are kept under meta.
+func FromDocument(doc export.Document, base *Resume) Resume {
+	r := Resume{Schema: SchemaURL}
+	r.Basics.Name = doc.Name
+	readContact(&r.Basics, doc.Contact)
+
+	others := map[string][]string{}
+	for _, s := range splitSubsections(doc.Sections) {
+		items := entries(s.Blocks)
+		switch kindOf(s.Title) {
+		case summarySection:
+			r.Basics.Summary = strings.Join(sectionText(s.Blocks), "\n")
+		case workSection:
+			for _, e := range items {
+				r.Work = append(r.Work, toWork(e))
+			}
+		case volunteerSection:
+			for _, e := range items {
+				w := toWork(e)
+				r.Volunteer = append(r.Volunteer, Volunteer{
+					Organization: w.Name, Position: w.Position, URL: w.URL,
+					StartDate: w.StartDate, EndDate: w.EndDate,
+					Summary: w.Summary, Highlights: w.Highlights,
+				})
+			}
+		case educationSection:
+			for _, e := range items {
+				r.Education = append(r.Education, toEducation(e))
+			}
+		case projectsSection:
+			for _, e := range items {
+				r.Projects = append(r.Projects, toProject(e))
+			}
+		case skillsSection:
+			for _, l := range labelled(items, s.Title) {
+				r.Skills = append(r.Skills, Skill{Name: l.label, Keywords: l.values})
+			}
+		case interestsSection:
+			for _, l := range labelled(items, s.Title) {
+				r.Interests = append(r.Interests, Interest{Name: l.label, Keywords: l.values})
+			}
+		case languagesSection:
+			r.Languages = append(r.Languages, toLanguages(items)...)
+		case awardsSection:
+			for _, e := range items {
+				t := toTitled(e)
+				r.Awards = append(r.Awards, Award{Title: t.title, Awarder: t.by, Date: t.date, Summary: t.summary})
+			}
+		case certificatesSection:
+			for _, e := range items {
+				t := toTitled(e)
+				r.Certificates = append(r.Certificates, Certificate{Name: t.title, Issuer: t.by, Date: t.date, URL: t.url})
+			}
+		case publicationsSection:
+			for _, e := range items {
+				t := toTitled(e)
+				r.Publications = append(r.Publications, Publication{Name: t.title, Publisher: t.by, ReleaseDate: t.date, URL: t.url, Summary: t.summary})
+			}
+		case referencesSection:
+			for _, e := range items {
+				t := toTitled(e)
+				r.References = append(r.References, Reference{Name: t.title, Reference: t.summary})
+			}
+		default:
+			title := s.Title
+			if title == "" {
+				title = "Other"
+			}
+			others[title] = append(others[title], sectionText(s.Blocks)...)
+		}
+	}
+	if len(others) > 0 {
+		r.Meta = map[string]any{"sections": others}
+	}
+	if base != nil {
+		merge(&r, base)
+	}
+	return r
+}
+
+// splitSubsections makes subsections with a title of their own, such as
+// "Languages" below "Experience", separate sections.
+func splitSubsections(sections []export.Section) []export.Section {
+	var out []export.Section
+	for _, s := range sections {
+		cur := export.Section{Title: s.Title}
+		for _, b := range s.Blocks {
+			if b.Kind == export.Heading {
+				if title := joinLines(b.Lines); kindOf(title) != otherSection && kindOf(title) != kindOf(cur.Title) {
+					out = append(out, cur)
+					cur = export.Section{Title: title}
+					continue
+				}
+			}
+			cur.Blocks = append(cur.Blocks, b)
+		}
+		out = append(out, cur)
+	}
+	return out
+}
+
+// entry is a dated item of a section such as a job: its header lines, any
+// further text and its bullet points.
+type entry struct {
+	lines   []export.Line
+	text    []string
+	bullets []string
+}
+
+// entries splits section content into entries. Templates either put the
+// bullets of an entry in a list nested in its item, or write the header as
+// a paragraph followed by a list; both are read the same.
+func entries(blocks []export.Block) []*entry {
+	var out []*entry
+	var cur *entry
+	for _, b := range blocks {
+		switch b.Kind {
+		case export.Paragraph, export.Heading:
+			if cur != nil && len(cur.bullets) == 0 && !isHeader(b.Lines) {
+				cur.text = append(cur.text, lineStrings(b.Lines)...)
+				continue
+			}
+			cur = &entry{lines: b.Lines}
+			out = append(out, cur)
+		case export.List:
+			for _, it := range b.Items {
+				nested, text := itemContent(it)
+				if len(nested) == 0 && cur != nil {
+					cur.bullets = append(cur.bullets, joinLines(it.Lines))
+					continue
+				}
+				e := &entry{lines: it.Lines, text: text, bullets: nested}
+				out = append(out, e)
+				if len(nested) > 0 {
+					cur = e
+				}
+			}
+		}
+	}
+	return out
+}
+
+// itemContent returns the nested bullets and text of a list item.
+func itemContent(it export.Item) ([]string, []string) {
+	var bullets, text []string
+	for _, b := range it.Blocks {
+		if b.Kind == export.List {
+			for _, n := range b.Items {
+				bullets = append(bullets, joinLines(n.Lines))
+			}
+		} else {
+			text = append(text, lineStrings(b.Lines)...)
+		}
+	}
+	return bullets, text
+}
+
+// isHeader reports whether lines start a new entry rather than continue
+// the previous one: entry headers have cells or dates.
+func isHeader(lines []export.Line) bool {
+	for _, l := range lines {
+		if strings.Contains(l.String(), cellSeparator) {
+			return true
+		}
+		for _, c := range cells(l) {
+			if _, _, ok := parseRange(c); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sectionText returns the lines of a section, with "- " before list items.
+func sectionText(blocks []export.Block) []string {
+	var out []string
+	for _, b := range blocks {
+		if b.Kind != export.List {
+			out = append(out, lineStrings(b.Lines)...)
+			continue
+		}
+		for _, it := range b.Items {
+			out = append(out, "- "+joinLines(it.Lines))
+			for _, s := range sectionText(it.Blocks) {
+				out = append(out, "  "+s)
+			}
+		}
+	}
+	return out
+}
+
+// cellSeparator is put between table cells by the LaTeX parser.
+const cellSeparator = " — "
+
+func cells(l export.Line) []string {
+	var out []string
+	for _, c := range strings.Split(l.String(), cellSeparator) {
+		if c = strings.TrimSpace(c); c != "" {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func lineStrings(lines []export.Line) []string {
+	out := make([]string, 0, len(lines))
+	for _, l := range lines {
+		if s := strings.TrimSpace(l.String()); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func joinLines(lines []export.Line) string {
+	return strings.Join(lineStrings(lines), " ")
+}
+
+// firstURL returns the first web link in lines.
+func firstURL(lines []export.Line) string {
+	for _, l := range lines {
+		for _, s := range l {
+			if strings.HasPrefix(s.URL, "http") {
+				return s.URL
+			}
+		}
+	}
+	return ""
+}
+
+// header holds the cells of an entry header sorted by what they are.
+type header struct {
+	texts      []string
+	start, end string
+	location   string
+	url        string
+}
+
+func readHeader(e *entry) header {
+	h := header{url: firstURL(e.lines)}
+	dated := false
+	for _, l := range e.lines {
+		for _, c := range cells(l) {
+			if !dated {
+				if start, end, ok := parseRange(c); ok {
+					h.start, h.end, dated = start, end, true
+					continue
+				}
+			}
+			h.texts = append(h.texts, c)
+		}
+	}
+	// A place such as "City, ST" or "Remote" follows the title and the
+	// organisation, or the title alone.
+	for i := 1; i < len(h.texts); i++ {
+		if (i >= 2 || len(h.texts) == 2) && isLocation(h.texts[i]) && !isLocation(h.texts[0]) {
+			h.location = h.texts[i]
+			h.texts = append(h.texts[:i], h.texts[i+1:]...)
+			break
+		}
+	}
+	return h
+}
+
+var locationPattern = regexp.MustCompile(`^(?:[\p{L}.'\- ]+, [\p{L}.'\- ]+|Remote|Hybrid)$`)
+
+func isLocation(s string) bool {
+	return len(strings.Fields(s)) <= 5 && locationPattern.MatchString(s)
+}
+
+var positionPattern = regexp.MustCompile(`(?i)\b(engineer|developer|programmer|manager|intern|analyst|designer|lead|director|scientist|consultant|assistant|specialist|architect|officer|administrator|head|researcher|coordinator|technician|associate|founder|president|teacher|tutor|fellow|contractor|freelance)`)
+
+func toWork(e *entry) Work {
+	h := readHeader(e)
+	w := Work{StartDate: h.start, EndDate: h.end, Location: h.location, URL: h.url, Highlights: e.bullets}
+	switch len(h.texts) {
+	case 0:
+	case 1:
+		w.Position = h.texts[0]
+	default:
+		w.Position, w.Name = h.texts[0], h.texts[1]
+		if !positionPattern.MatchString(w.Position) && positionPattern.MatchString(w.Name) {
+			w.Position, w.Name = w.Name, w.Position
+		}
+		e.text = append(append([]string{}, h.texts[2:]...), e.text...)
+	}
+	w.Summary = strings.Join(e.text, "\n")
+	return w
+}
+
+var (
+	institutionPattern = regexp.MustCompile(`(?i)\b(universit|college|school|institut|academy|polytechnic|hochschule)`)
+	degreePattern      = regexp.MustCompile(`(?i)\b(bachelor|master|doctor|ph\.?d|b\.?sc?|m\.?sc?|b\.?a|m\.?a|mba|diploma|associate|degree|certificate|minor|major)\b`)
+	scorePattern       = regexp.MustCompile(`(?i)^(?:gpa|grade|score)\s*:?\s*(.+)$`)
+	coursesPattern     = regexp.MustCompile(`(?i)^(?:relevant\s+)?(?:courses|coursework)\s*:\s*(.+)$`)
+)
+
+func toEducation(e *entry) Education {
+	h := readHeader(e)
+	ed := Education{StartDate: h.start, EndDate: h.end, URL: h.url}
+	var rest []string
+	for _, t := range h.texts {
+		switch {
+		case scorePattern.MatchString(t):
+			ed.Score = scorePattern.FindStringSubmatch(t)[1]
+		case ed.Institution == "" && institutionPattern.MatchString(t) && !degreePattern.MatchString(t):
+			ed.Institution = t
+		case ed.StudyType == "" && ed.Area == "" && degreePattern.MatchString(t):
+			ed.StudyType, ed.Area = splitDegree(t)
+		default:
+			rest = append(rest, t)
+		}
+	}
+	if ed.Institution == "" && len(rest) > 0 {
+		ed.Institution, rest = rest[0], rest[1:]
+	}
+	if ed.StudyType == "" && ed.Area == "" && len(rest) > 0 {
+		ed.StudyType, ed.Area = splitDegree(rest[0])
+	}
+	for _, b := range append(e.text, e.bullets...) {
+		if m := coursesPattern.FindStringSubmatch(b); m != nil {
+			ed.Courses = append(ed.Courses, splitList(m[1])...)
+		} else if m := scorePattern.FindStringSubmatch(b); m != nil && ed.Score == "" {
+			ed.Score = m[1]
+		} else {
+			ed.Courses = append(ed.Courses, b)
+		}
+	}
+	return ed
+}
+
+// splitDegree separates "Bachelor of Science in Computer Science" into the
+// kind and area of study.
+func splitDegree(s string) (string, string) {
+	for _, sep := range []string{" in ", ", "} {
+		if i := strings.Index(s, sep); i > 0 {
+			return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+len(sep):])
+		}
+	}
+	return s, ""
+}
+
+func toProject(e *entry) Project {
+	h := readHeader(e)
+	p := Project{StartDate: h.start, EndDate: h.end, URL: h.url, Highlights: e.bullets}
+	if h.location != "" {
+		h.texts = append(h.texts, h.location)
+	}
+	for i, t := range h.texts {
+		if i == 0 {
+			// Names are often followed by the stack, as in "Name | Go, SQL".
+			name, stack, _ := strings.Cut(t, "|")
+			p.Name = strings.TrimSpace(name)
+			p.Keywords = splitList(stack)
+			continue
+		}
+		if h.url != "" && strings.Contains(h.url, strings.TrimSuffix(t, "/")) {
+			continue
+		}
+		if strings.Contains(t, ",") && len(p.Keywords) == 0 {
+			p.Keywords = splitList(t)
+		} else {
+			e.text = append([]string{t}, e.text...)
+		}
+	}
+	p.Description = strings.Join(e.text, "\n")
+	return p
+}
+
+// labelledLine is a line such as "Languages: Go, Python".
+type labelledLine struct {
+	label  string
+	values []string
+}
+
+// labelled reads every line of items as a label with a list of values.
+// Lines without a label are listed under fallback.
+func labelled(items []*entry, fallback string) []labelledLine {
+	var out []labelledLine
+	var loose []string
+	add := func(line string) {
+		if label, values, ok := strings.Cut(line, ":"); ok && len(strings.Fields(label)) <= 5 {
+			out = append(out, labelledLine{label: strings.TrimSpace(label), values: splitList(values)})
+		} else {
+			loose = append(loose, splitList(line)...)
+		}
+	}
+	for _, e := range items {
+		for _, l := range e.lines {
+			// Description lists give the label in bold without a colon.
+			if text := l.String(); len(l) > 1 && l[0].Bold && !strings.Contains(text, ":") {
+				out = append(out, labelledLine{label: strings.TrimSpace(l[0].Text), values: splitList(text[len(l[0].Text):])})
+				continue
+			}
+			for _, c := range cells(l) {
+				add(c)
+			}
+		}
+		for _, t := range append(e.text, e.bullets...) {
+			add(t)
+		}
+	}
+	if len(loose) > 0 {
+		out = append(out, labelledLine{label: fallback, values: loose})
+	}
+	return out
+}
+
+var fluencyPattern = regexp.MustCompile(`^([^(]+?)\s*\(([^)]+)\)$`)
+
+// toLanguages reads "German: Native", "German (Native)" and lists of those.
+func toLanguages(items []*entry) []Language {
+	var out []Language
+	for _, l := range labelled(items, "") {
+		if l.label != "" {
+			out = append(out, Language{Language: l.label, Fluency: strings.Join(l.values, ", ")})
+			continue
+		}
+		for _, v := range l.values {
+			if m := fluencyPattern.FindStringSubmatch(v); m != nil {
+				out = append(out, Language{Language: m[1], Fluency: m[2]})
+			} else {
+				out = append(out, Language{Language: v})
+			}
+		}
+	}
+	return out
+}
+
+// titled is an award, certificate, publication or reference: a title, who
+// gave it, a date and a description.
+type titled struct {
+	title, by, date, url, summary string
+}
+
+func toTitled(e *entry) titled {
+	t := titled{url: firstURL(e.lines)}
+	var texts []string
+	for _, l := range e.lines {
+		for _, c := range cells(l) {
+			if start, end, ok := parseRange(c); ok && t.date == "" {
+				t.date = start
+				if t.date == "" {
+					t.date = end
+				}
+				continue
+			}
+			texts = append(texts, c)
+		}
+	}
+	// The title is usually in bold, followed by the issuer.
+	if len(e.lines) > 0 && len(e.lines[0]) > 1 && e.lines[0][0].Bold && len(texts) > 0 {
+		bold := strings.TrimSpace(e.lines[0][0].Text)
+		if rest, ok := strings.CutPrefix(texts[0], bold); ok {
+			texts[0] = bold
+			if by := strings.Trim(rest, " ,:–-"); by != "" {
+				texts = append([]string{texts[0], by}, texts[1:]...)
+			}
+		}
+	}
+	if len(texts) > 0 {
+		t.title = texts[0]
+	}
+	if len(texts) > 1 {
+		t.by = texts[1]
+		texts = texts[2:]
+	} else {
+		texts = nil
+	}
+	t.summary = strings.Join(append(append(texts, e.text...), e.bullets...), "\n")
+	return t
+}
+
+// splitList splits a comma separated list.
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+var (
+	emailPattern = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+	phonePattern = regexp.MustCompile(`^\+?[\d\s().\-/]{7,}$`)
+	hostPattern  = regexp.MustCompile(`^(?:www\.)?[\w-]+(?:\.[\w-]+)+(?:/\S*)?$`)
+)
+
+// contactSeparators split the parts of a contact line.
+var contactSeparators = regexp.MustCompile(`\s*(?:\||•|·|⋄|◦|` + cellSeparator + `)\s*`)
+
+// readContact fills basics from the contact lines below the name.
+func readContact(b *Basics, lines []export.Line) {
+	for n, l := range lines {
+		for _, part := range contactParts(l) {
+			text, link := part[0], part[1]
+			switch {
+			case strings.HasPrefix(link, "mailto:"):
+				b.Email = strings.TrimPrefix(link, "mailto:")
+			case strings.HasPrefix(link, "tel:"):
+				b.Phone = text
+			case link != "":
+				addLink(b, link)
+			case emailPattern.MatchString(text):
+				b.Email = text
+			case phonePattern.MatchString(text):
+				b.Phone = text
+			case hostPattern.MatchString(text):
+				addLink(b, "https://"+text)
+			case isLocation(text) && b.Location == nil:
+				city, region, _ := strings.Cut(text, ", ")
+				b.Location = &Location{City: city, Region: region}
+			case n == 0 && b.Label == "" && len(contactParts(l)) == 1:
+				b.Label = text
+			}
+		}
+	}
+}
+
+// contactParts returns the text and link of each part of a contact line.
+func contactParts(l export.Line) [][2]string {
+	var parts [][2]string
+	for i, s := range l {
+		if s.URL != "" {
+			if i > 0 && l[i-1].URL == s.URL {
+				parts[len(parts)-1][0] += s.Text
+			} else {
+				parts = append(parts, [2]string{strings.TrimSpace(s.Text), s.URL})
+			}
+			continue
+		}
+		for _, t := range contactSeparators.Split(s.Text, -1) {
+			if t = strings.TrimSpace(t); t != "" {
+				parts = append(parts, [2]string{t, ""})
+			}
+		}
+	}
+	return parts
+}
+
+// networks names the profiles recognised by their host.
+var networks = map[string]string{
+	"linkedin.com":       "LinkedIn",
+	"github.com":         "GitHub",
+	"gitlab.com":         "GitLab",
+	"twitter.com":        "Twitter",
+	"x.com":              "X",
+	"stackoverflow.com":  "Stack Overflow",
+	"medium.com":         "Medium",
+	"dribbble.com":       "Dribbble",
+	"behance.net":        "Behance",
+	"scholar.google.com": "Google Scholar",
+}
+
+// addLink adds a link as a profile of a known network, or as the website.
+func addLink(b *Basics, link string) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	if network, ok := networks[host]; ok {
+		segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+		b.Profiles = append(b.Profiles, Profile{Network: network, Username: segments[len(segments)-1], URL: link})
+		return
+	}
+	if b.URL == "" {
+		b.URL = link
+		return
+	}
+	b.Profiles = append(b.Profiles, Profile{Network: host, URL: link})
+}
+
+// merge fills details that the LaTeX does not show from base, matching
+// entries by name.
+func merge(r *Resume, base *Resume) {
+	b := &r.Basics
+	fill(&b.Label, base.Basics.Label)
+	fill(&b.Image, base.Basics.Image)
+	fill(&b.Email, base.Basics.Email)
+	fill(&b.Phone, base.Basics.Phone)
+	fill(&b.URL, base.Basics.URL)
+	if b.Location == nil {
+		b.Location = base.Basics.Location
+	}
+	for _, p := range base.Basics.Profiles {
+		found := false
+		for i := range b.Profiles {
+			if strings.EqualFold(b.Profiles[i].Network, p.Network) {
+				b.Profiles[i] = p
+				found = true
+			}
+		}
+		if !found {
+			b.Profiles = append(b.Profiles, p)
+		}
+	}
+
+	for i := range r.Work {
+		w := &r.Work[i]
+		for _, bw := range base.Work {
+			if sameName(w.Name, bw.Name) || (w.Name == "" && sameName(w.Position, bw.Position)) {
+				fill(&w.Name, bw.Name)
+				fill(&w.Position, bw.Position)
+				fill(&w.URL, bw.URL)
+				fill(&w.Location, bw.Location)
+				fill(&w.StartDate, bw.StartDate)
+				fill(&w.EndDate, bw.EndDate)
+				break
+			}
+		}
+	}
+	for i := range r.Education {
+		e := &r.Education[i]
+		for _, be := range base.Education {
+			if sameName(e.Institution, be.Institution) {
+				fill(&e.URL, be.URL)
+				fill(&e.Area, be.Area)
+				fill(&e.StudyType, be.StudyType)
+				fill(&e.Score, be.Score)
+				fill(&e.StartDate, be.StartDate)
+				fill(&e.EndDate, be.EndDate)
+				break
+			}
+		}
+	}
+	for i := range r.Projects {
+		p := &r.Projects[i]
+		for _, bp := range base.Projects {
+			if sameName(p.Name, bp.Name) {
+				fill(&p.URL, bp.URL)
+				fill(&p.Entity, bp.Entity)
+				fill(&p.Type, bp.Type)
+				fill(&p.StartDate, bp.StartDate)
+				fill(&p.EndDate, bp.EndDate)
+				if len(p.Roles) == 0 {
+					p.Roles = bp.Roles
+				}
+				break
+			}
+		}
+	}
+	// References are rarely part of a resume but belong to the candidate.
+	if len(r.References) == 0 {
+		r.References = base.References
+	}
+	for k, v := range base.Meta {
+		if _, ok := r.Meta[k]; ok {
+			continue
+		}
+		if r.Meta == nil {
+			r.Meta = map[string]any{}
+		}
+		r.Meta[k] = v
+	}
+}
+
+func fill(dst *string, src string) {
+	if *dst == "" {
+		*dst = src
+	}
+}
+
+func sameName(a, b string) bool {
+	return a != "" && strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
@@ -0,0 +1,69 @@
+package jsonresume
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	rangeSeparator = regexp.MustCompile(`\s+(?:-{1,3}|to|until)\s+|\s*[–—]\s*`)
+	numericDate    = regexp.MustCompile(`^(\d{1,2})[/.](\d{4})$`)
+	isoDate        = regexp.MustCompile(`^\d{4}(?:-\d{2}(?:-\d{2})?)?$`)
+	monthYear      = regexp.MustCompile(`^(\p{L}+)\.?,?\s+(\d{4})$`)
+)
+
+var months = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+// ongoing words end a range that has not finished.
+var ongoing = map[string]bool{"present": true, "current": true, "now": true, "today": true, "ongoing": true}
+
+// parseRange reads a date or date range such as "Aug. 2018 -- Present" as
+// ISO 8601 dates. The end is empty for ongoing ranges and the start for a
+// single date that is an end, like "Expected May 2025".
+func parseRange(s string) (string, string, bool) {
+	s = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s), "Expected"))
+	parts := rangeSeparator.Split(s, -1)
+	if len(parts) > 2 {
+		return "", "", false
+	}
+	dates := make([]string, len(parts))
+	for i, p := range parts {
+		d, ok := parseDate(p)
+		if !ok || (d == "" && i == 0) {
+			return "", "", false
+		}
+		dates[i] = d
+	}
+	if len(dates) == 1 {
+		return dates[0], dates[0], true
+	}
+	return dates[0], dates[1], true
+}
+
+// parseDate reads a single date, returning "" for words like "Present".
+func parseDate(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	if ongoing[strings.ToLower(s)] {
+		return "", true
+	}
+	if isoDate.MatchString(s) {
+		return s, true
+	}
+	if m := numericDate.FindStringSubmatch(s); m != nil {
+		month, _ := strconv.Atoi(m[1])
+		if month >= 1 && month <= 12 {
+			return fmt.Sprintf("%s-%02d", m[2], month), true
+		}
+	}
+	if m := monthYear.FindStringSubmatch(s); m != nil && len(m[1]) >= 3 {
+		if month, ok := months[strings.ToLower(m[1][:3])]; ok {
+			return fmt.Sprintf("%s-%02d", m[2], month), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,164 @@
+package jsonresume
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/latex"
+	"github.com/FabricSoul/auto-resume/internal/templates"
+)
+
+// DefaultTemplate is used when a project does not name a template.
+const DefaultTemplate = "classic"
+
+// templateFS holds the document bodies of the built-in templates. Each is
+// set in the preamble of the bundled resume template of the same name.
+//
+//go:embed templates/*.tex
+var templateFS embed.FS
+
+// Templates lists the names of the built-in templates.
+func Templates() []string {
+	entries, err := templateFS.ReadDir("templates")
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".tex"))
+	}
+	return names
+}
+
+// LoadTemplate returns the source of a built-in template by name, or reads
+// the template file at name otherwise.
+func LoadTemplate(name string) (string, error) {
+	if name == "" {
+		name = DefaultTemplate
+	}
+	if body, err := templateFS.ReadFile(path.Join("templates", name+".tex")); err == nil {
+		for _, t := range templates.Builtin() {
+			if t.ID == name {
+				return t.Preamble() + string(body), nil
+			}
+		}
+		return "", fmt.Errorf("template %s has no bundled preamble", name)
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return "", fmt.Errorf("unknown template %q, use one of %s or a .tex file: %w",
+			name, strings.Join(Templates(), ", "), err)
+	}
+	return string(data), nil
+}
+
+// Render fills the LaTeX template with r. Templates use [[ and ]] like cover
+// letter templates, and get tex, date, dates, join, degree, link and contact
+// as helpers.
+func Render(r Resume, templateName string) (string, error) {
+	text, err := LoadTemplate(templateName)
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := template.New(templateName).Delims("[[", "]]").Funcs(funcs).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %s: %w", templateName, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, r); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", templateName, err)
+	}
+	return buf.String(), nil
+}
+
+var funcs = template.FuncMap{
+	"tex":     latex.Escape,
+	"date":    formatDate,
+	"dates":   formatDates,
+	"join":    func(list []string) string { return strings.Join(list, ", ") },
+	"degree":  degree,
+	"link":    link,
+	"contact": contact,
+}
+
+// formatDate turns an ISO 8601 date such as "2020-06" into "Jun 2020".
+func formatDate(s string) string {
+	for _, layout := range []string{"2006-01-02", "2006-01"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format("Jan 2006")
+		}
+	}
+	return latex.Escape(s)
+}
+
+// formatDates renders a date range, with "Present" for a missing end.
+func formatDates(start, end string) string {
+	switch {
+	case start == "" && end == "":
+		return ""
+	case start == "":
+		return formatDate(end)
+	case end == "":
+		return formatDate(start) + " -- Present"
+	}
+	return formatDate(start) + " -- " + formatDate(end)
+}
+
+// degree joins the kind and area of study, such as "Bachelor of Science
+// in Computer Science".
+func degree(e Education) string {
+	switch {
+	case e.StudyType == "":
+		return e.Area
+	case e.Area == "":
+		return e.StudyType
+	}
+	return e.StudyType + " in " + e.Area
+}
+
+// link shows a URL without its scheme.
+func link(url string) string {
+	if url == "" {
+		return ""
+	}
+	text := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://"), "/")
+	return `\href{` + escapeURL(url) + `}{` + latex.Escape(text) + `}`
+}
+
+var urlEscaper = strings.NewReplacer(`%`, `\%`, `#`, `\#`, `\`, ``)
+
+func escapeURL(url string) string {
+	return urlEscaper.Replace(url)
+}
+
+// contact renders the phone, email, website, location and profiles
+// separated by bars.
+func contact(b Basics) string {
+	var parts []string
+	if b.Phone != "" {
+		parts = append(parts, latex.Escape(b.Phone))
+	}
+	if b.Email != "" {
+		parts = append(parts, `\href{mailto:`+escapeURL(b.Email)+`}{`+latex.Escape(b.Email)+`}`)
+	}
+	if b.URL != "" {
+		parts = append(parts, link(b.URL))
+	}
+	for _, p := range b.Profiles {
+		if p.URL != "" {
+			parts = append(parts, link(p.URL))
+		} else if p.Username != "" {
+			parts = append(parts, latex.Escape(p.Network+": "+p.Username))
+		}
+	}
+	if loc := b.Location.String(); loc != "" {
+		parts = append(parts, latex.Escape(loc))
+	}
+	return strings.Join(parts, ` $|$ `)
+}
@@ -0,0 +1,99 @@
+package jsonresume
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	r := Resume{
+		Basics: Basics{Name: "Ada Lovelace", Email: "ada@example.com"},
+		Work: []Work{{
+			Name:       "R & D Ltd",
+			Position:   "Engineer",
+			StartDate:  "2020-06",
+			Highlights: []string{"Cut costs by 50%"},
+		}},
+	}
+	for _, name := range Templates() {
+		t.Run(name, func(t *testing.T) {
+			tex, err := Render(r, name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{`\documentclass`, `\begin{document}`, `\end{document}`,
+				"Ada Lovelace", `R \& D Ltd`, `50\%`, "Jun 2020 -- Present"} {
+				if !strings.Contains(tex, want) {
+					t.Errorf("output does not contain %q", want)
+				}
+			}
+			if strings.Index(tex, `\documentclass`) > strings.Index(tex, `\begin{document}`) {
+				t.Error("document body comes before the preamble")
+			}
+		})
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"unknown template", "fancy", "unknown template"},
+		{"missing file", filepath.Join(dir, "missing.tex"), "unknown template"},
+		{"parse error", write("broken.tex", `[[if .Basics.Name]]no end`), "failed to parse"},
+		{"unknown helper", write("helper.tex", `[[shout .Basics.Name]]`), "failed to parse"},
+		{"unknown field", write("field.tex", `[[.Basics.Nickname]]`), "failed to render"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Render(Resume{Basics: Basics{Name: "Ada"}}, tt.template)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTemplateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plain.tex")
+	if err := os.WriteFile(path, []byte(`\name{[[tex .Basics.Name]]} [[dates "2019-01" "2021-03"]]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tex, err := Render(Resume{Basics: Basics{Name: "Tom & Jerry"}}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `\name{Tom \& Jerry} Jan 2019 -- Mar 2021`; tex != want {
+		t.Errorf("Render() = %q, want %q", tex, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"invalid JSON", `{"basics": `},
+		{"wrong type", `{"basics": []}`},
+		{"no content", `{"meta": {}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.data)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
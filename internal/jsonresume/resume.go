@@ -0,0 +1,255 @@
+// Package jsonresume reads and writes resumes in the JSON Resume format
+// (https://jsonresume.org/schema), renders them to LaTeX through a template
+// and converts generated LaTeX back, so resume data can be shared with other
+// tools.
+package jsonresume
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/FabricSoul/auto-resume/internal/export"
+	"github.com/FabricSoul/auto-resume/internal/types"
+)
+
+// File is the name of the JSON Resume kept in a project directory as the
+// format-neutral source of the base resume.
+const File = "resume.json"
+
+// SchemaURL identifies the schema in exported files.
+const SchemaURL = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+
+// Resume is a JSON Resume document.
+type Resume struct {
+	Schema       string         `json:"$schema,omitempty"`
+	Basics       Basics         `json:"basics"`
+	Work         []Work         `json:"work,omitempty"`
+	Volunteer    []Volunteer    `json:"volunteer,omitempty"`
+	Education    []Education    `json:"education,omitempty"`
+	Awards       []Award        `json:"awards,omitempty"`
+	Certificates []Certificate  `json:"certificates,omitempty"`
+	Publications []Publication  `json:"publications,omitempty"`
+	Skills       []Skill        `json:"skills,omitempty"`
+	Languages    []Language     `json:"languages,omitempty"`
+	Interests    []Interest     `json:"interests,omitempty"`
+	References   []Reference    `json:"references,omitempty"`
+	Projects     []Project      `json:"projects,omitempty"`
+	Meta         map[string]any `json:"meta,omitempty"`
+}
+
+type Basics struct {
+	Name     string    `json:"name,omitempty"`
+	Label    string    `json:"label,omitempty"`
+	Image    string    `json:"image,omitempty"`
+	Email    string    `json:"email,omitempty"`
+	Phone    string    `json:"phone,omitempty"`
+	URL      string    `json:"url,omitempty"`
+	Summary  string    `json:"summary,omitempty"`
+	Location *Location `json:"location,omitempty"`
+	Profiles []Profile `json:"profiles,omitempty"`
+}
+
+type Location struct {
+	Address     string `json:"address,omitempty"`
+	PostalCode  string `json:"postalCode,omitempty"`
+	City        string `json:"city,omitempty"`
+	CountryCode string `json:"countryCode,omitempty"`
+	Region      string `json:"region,omitempty"`
+}
+
+// String joins the city, region and country, such as "Berlin, DE".
+func (l *Location) String() string {
+	if l == nil {
+		return ""
+	}
+	var parts []string
+	for _, s := range []string{l.City, l.Region, l.CountryCode} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+type Profile struct {
+	Network  string `json:"network,omitempty"`
+	Username string `json:"username,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+type Work struct {
+	Name       string   `json:"name,omitempty"`
+	Position   string   `json:"position,omitempty"`
+	Location   string   `json:"location,omitempty"`
+	URL        string   `json:"url,omitempty"`
+	StartDate  string   `json:"startDate,omitempty"`
+	EndDate    string   `json:"endDate,omitempty"`
+	Summary    string   `json:"summary,omitempty"`
+	Highlights []string `json:"highlights,omitempty"`
+}
+
+type Volunteer struct {
+	Organization string   `json:"organization,omitempty"`
+	Position     string   `json:"position,omitempty"`
+	URL          string   `json:"url,omitempty"`
+	StartDate    string   `json:"startDate,omitempty"`
+	EndDate      string   `json:"endDate,omitempty"`
+	Summary      string   `json:"summary,omitempty"`
+	Highlights   []string `json:"highlights,omitempty"`
+}
+
+type Education struct {
+	Institution string   `json:"institution,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	Area        string   `json:"area,omitempty"`
+	StudyType   string   `json:"studyType,omitempty"`
+	StartDate   string   `json:"startDate,omitempty"`
+	EndDate     string   `json:"endDate,omitempty"`
+	Score       string   `json:"score,omitempty"`
+	Courses     []string `json:"courses,omitempty"`
+}
+
+type Award struct {
+	Title   string `json:"title,omitempty"`
+	Date    string `json:"date,omitempty"`
+	Awarder string `json:"awarder,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+type Certificate struct {
+	Name   string `json:"name,omitempty"`
+	Date   string `json:"date,omitempty"`
+	Issuer string `json:"issuer,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+type Publication struct {
+	Name        string `json:"name,omitempty"`
+	Publisher   string `json:"publisher,omitempty"`
+	ReleaseDate string `json:"releaseDate,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+}
+
+type Skill struct {
+	Name     string   `json:"name,omitempty"`
+	Level    string   `json:"level,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+type Language struct {
+	Language string `json:"language,omitempty"`
+	Fluency  string `json:"fluency,omitempty"`
+}
+
+type Interest struct {
+	Name     string   `json:"name,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+type Reference struct {
+	Name      string `json:"name,omitempty"`
+	Reference string `json:"reference,omitempty"`
+}
+
+type Project struct {
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Highlights  []string `json:"highlights,omitempty"`
+	Keywords    []string `json:"keywords,omitempty"`
+	StartDate   string   `json:"startDate,omitempty"`
+	EndDate     string   `json:"endDate,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	Entity      string   `json:"entity,omitempty"`
+	Type        string   `json:"type,omitempty"`
+}
+
+// IsJSON reports whether path names a JSON Resume rather than LaTeX.
+func IsJSON(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
+
+// Load reads a JSON Resume file.
+func Load(path string) (Resume, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Resume{}, err
+	}
+	return Parse(data)
+}
+
+// Parse decodes a JSON Resume document.
+func Parse(data []byte) (Resume, error) {
+	var r Resume
+	if err := json.Unmarshal(data, &r); err != nil {
+		return Resume{}, fmt.Errorf("invalid JSON Resume: %w", err)
+	}
+	if r.Basics.Name == "" && len(r.Work) == 0 && len(r.Education) == 0 {
+		return Resume{}, fmt.Errorf("invalid JSON Resume: no basics, work or education")
+	}
+	return r, nil
+}
+
+// Marshal encodes r as indented JSON.
+func Marshal(r Resume) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Save writes r to path.
+func Save(path string, r Resume) error {
+	data, err := Marshal(r)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ProjectPath returns where the JSON Resume of a project is kept.
+func ProjectPath(projectDir string) string {
+	return filepath.Join(projectDir, File)
+}
+
+// OutputPath returns where the JSON Resume export of an output is written.
+func OutputPath(projectDir string, out types.Output) string {
+	return filepath.Join(types.OutputDir(projectDir, out.Slug), File)
+}
+
+// ExportOutput converts the generated resume of out to JSON Resume and
+// writes it next to its PDF, returning the path. Details the LaTeX does not
+// show are taken from the project's resume.json when there is one.
+func ExportOutput(projectDir string, out types.Output) (string, error) {
+	if out.GeneratedOutput == "" {
+		return "", fmt.Errorf("output '%s' has not been generated yet", out.Name)
+	}
+	if out.Slug == "" {
+		return "", fmt.Errorf("output '%s' has not been saved yet", out.Name)
+	}
+	doc := export.Parse(out.GeneratedOutput)
+	if doc.IsEmpty() {
+		return "", export.ErrEmpty
+	}
+	var base *Resume
+	if r, err := Load(ProjectPath(projectDir)); err == nil {
+		base = &r
+	}
+	path := OutputPath(projectDir, out)
+	if err := Save(path, FromDocument(doc, base)); err != nil {
+		return "", fmt.Errorf("failed to write JSON Resume export: %w", err)
+	}
+	return path, nil
+}
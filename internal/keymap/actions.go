@@ -0,0 +1,222 @@
+package keymap
+
+// Actions of the TUI. Screens share an action when it means the same thing
+// everywhere, such as moving the selection or going back.
+const (
+	Quit    Action = "quit"
+	Palette Action = "palette"
+	Close   Action = "close"
+	Back    Action = "back"
+	Help    Action = "help"
+
+	Up          Action = "up"
+	Down        Action = "down"
+	Left        Action = "left"
+	Right       Action = "right"
+	Select      Action = "select"
+	NextSection Action = "next_section"
+	PrevSection Action = "prev_section"
+	PageDown    Action = "page_down"
+	PageUp      Action = "page_up"
+	First       Action = "first"
+	Last        Action = "last"
+	Filter      Action = "filter"
+	Sort        Action = "sort"
+
+	NewProject      Action = "new_project"
+	ManageModels    Action = "manage_models"
+	ExportProject   Action = "export_project"
+	ImportProject   Action = "import_project"
+	ExportReminders Action = "export_reminders"
+	Board           Action = "board"
+	Settings        Action = "settings"
+
+	Input    Action = "input"
+	Editor   Action = "editor"
+	Save     Action = "save"
+	Add      Action = "add"
+	Edit     Action = "edit"
+	Import   Action = "import"
+	PasteJob Action = "paste_job"
+	Reimport Action = "reimport"
+
+	RegenerateStale Action = "regenerate_stale"
+	CycleFilter     Action = "cycle_filter"
+	Preview         Action = "preview"
+	Compare         Action = "compare"
+	Batch           Action = "batch"
+	History         Action = "history"
+	EnableHistory   Action = "enable_history"
+	SwitchTab       Action = "switch_tab"
+	Extract         Action = "extract"
+	Refine          Action = "refine"
+
+	SetStatus Action = "set_status"
+	AddNote   Action = "add_note"
+	MoveLeft  Action = "move_left"
+	MoveRight Action = "move_right"
+
+	CancelBatch Action = "cancel_batch"
+	StartBatch  Action = "start_batch"
+	RetryFailed Action = "retry_failed"
+	ClearBatch  Action = "clear_batch"
+
+	Toggle       Action = "toggle"
+	ToggleAll    Action = "toggle_all"
+	ToggleDiff   Action = "toggle_diff"
+	ChooseModels Action = "choose_models"
+	Promote      Action = "promote"
+
+	NextPage   Action = "next_page"
+	PrevPage   Action = "prev_page"
+	ToggleText Action = "toggle_text"
+)
+
+// Categories group actions in the command palette and the help.
+const (
+	General      = "General"
+	Navigation   = "Navigation"
+	Projects     = "Projects"
+	Editing      = "Editing"
+	Outputs      = "Outputs"
+	Applications = "Applications"
+	Batches      = "Batches"
+	Comparison   = "Comparison"
+	PDFPreview   = "Preview"
+)
+
+// Categories lists the categories in the order the help shows them.
+var Categories = []string{General, Navigation, Projects, Editing, Outputs, Applications, Batches, Comparison, PDFPreview}
+
+// global are the actions offered on every screen.
+var global = []Action{Quit, Palette, Help}
+
+// screens group the actions offered together on a screen. A key may trigger
+// actions of different screens but only one per screen, which Load checks
+// for the keys of the user config.
+var screens = map[string][]Action{
+	"projects": {Down, Up, Select, Close, Back, NewProject, ManageModels, Board, ExportReminders,
+		ExportProject, ImportProject, Filter, Sort, Settings},
+	"new project": {Input, Editor, Down, Up, Select, Back},
+	"project": {NextSection, PrevSection, Down, Up, Right, Select, Input, Editor, Import, Reimport,
+		Add, Save, Batch, History, EnableHistory, Compare, SetStatus, AddNote, Preview, Sort,
+		CycleFilter, Board, SwitchTab, Extract, Refine, PasteJob, RegenerateStale, Close, Back},
+	"models":      {Down, Up, Add, Edit, Input, Select, Close, Back},
+	"board":       {Left, Right, Down, Up, MoveLeft, MoveRight, AddNote, Select, Close, Back},
+	"application": {Down, Up, Select, SetStatus, Back},
+	"batch":       {Down, Up, StartBatch, RetryFailed, CancelBatch, ClearBatch, Close, Back},
+	"compare":     {Down, Up, Toggle, ToggleAll, Select, Close, Back},
+	"comparison":  {Down, Up, ToggleDiff, PageDown, PageUp, ChooseModels, Promote, Close, Back},
+	"preview":     {NextPage, PrevPage, First, Last, ToggleText, Close, Back},
+	"settings":    {Down, Up, Select, Close, Back},
+	"help":        {Down, Up, PageDown, PageUp, Close, Back},
+}
+
+// definition holds the default keys and descriptions of an action.
+type definition struct {
+	keys     []string
+	help     string
+	title    string
+	category string
+}
+
+var definitions = map[Action]definition{
+	Quit:    {[]string{"ctrl+c"}, "quit", "Quit auto-resume", General},
+	Palette: {[]string{":", "ctrl+p"}, "commands", "Open the command palette", General},
+	Close:   {[]string{"q"}, "close", "Close the screen", General},
+	Back:    {[]string{"esc"}, "back", "Go back or cancel", General},
+	Help:    {[]string{"?"}, "help", "Show the keys of this screen", General},
+
+	Up:          {[]string{"k", "up"}, "navigate", "Move up", Navigation},
+	Down:        {[]string{"j", "down"}, "navigate", "Move down", Navigation},
+	Left:        {[]string{"h", "left"}, "column", "Move to the column on the left", Navigation},
+	Right:       {[]string{"l", "right"}, "column", "Move to the column on the right", Navigation},
+	Select:      {[]string{"enter"}, "select", "Select or confirm", Navigation},
+	NextSection: {[]string{"tab"}, "switch section", "Focus the next section", Navigation},
+	PrevSection: {[]string{"shift+tab"}, "switch section", "Focus the previous section", Navigation},
+	PageDown:    {[]string{"ctrl+d", "pgdown"}, "scroll", "Scroll down", Navigation},
+	PageUp:      {[]string{"ctrl+u", "pgup"}, "scroll", "Scroll up", Navigation},
+	First:       {[]string{"g", "home"}, "first/last", "Go to the first item", Navigation},
+	Last:        {[]string{"G", "end"}, "first/last", "Go to the last item", Navigation},
+	Filter:      {[]string{"/"}, "filter", "Filter the list", Navigation},
+	Sort:        {[]string{"s"}, "sort", "Change the sort order", Navigation},
+
+	NewProject:      {[]string{"n"}, "new project", "Create a project", Projects},
+	ManageModels:    {[]string{"M"}, "models", "Manage models", Projects},
+	ExportProject:   {[]string{"x"}, "export", "Export the project to an archive", Projects},
+	ImportProject:   {[]string{"I"}, "import", "Import a project archive", Projects},
+	ExportReminders: {[]string{"E"}, "export reminders", "Export reminders to a calendar", Projects},
+	Board:           {[]string{"T"}, "board", "Open the application board", Projects},
+	Settings:        {[]string{","}, "settings", "Open the settings", Projects},
+
+	Input:    {[]string{"i"}, "input", "Edit the focused field", Editing},
+	Editor:   {[]string{"e", "E"}, "$EDITOR", "Edit the focused field in $EDITOR", Editing},
+	Save:     {[]string{"ctrl+s"}, "save", "Save the project", Editing},
+	Add:      {[]string{"a"}, "add", "Add an item", Editing},
+	Edit:     {[]string{"e"}, "edit", "Edit the selected item", Editing},
+	Import:   {[]string{"I"}, "import", "Import the resume or job description from a file", Editing},
+	PasteJob: {[]string{"v"}, "paste job", "Import the job description from the clipboard", Editing},
+	Reimport: {[]string{"r"}, "re-import resume", "Import the resume again from its source", Editing},
+
+	RegenerateStale: {[]string{"R"}, "regenerate stale", "Regenerate outputs of an older resume", Outputs},
+	CycleFilter:     {[]string{"f"}, "filter", "Filter outputs by application status", Outputs},
+	Preview:         {[]string{"P"}, "preview PDF", "Compile and preview the output", Outputs},
+	Compare:         {[]string{"C"}, "compare models", "Compare models on the output", Outputs},
+	Batch:           {[]string{"B"}, "batch", "Generate outputs for many job descriptions", Outputs},
+	History:         {[]string{"H"}, "history", "Show the project history", Outputs},
+	EnableHistory:   {[]string{"G"}, "enable history", "Keep the project history in git", Outputs},
+	SwitchTab:       {[]string{"t"}, "resume/cover letter", "Switch between resume and cover letter", Outputs},
+	Extract:         {[]string{"x"}, "extract job details", "Extract job details with rules", Outputs},
+	Refine:          {[]string{"X"}, "extract with LLM", "Extract job details with the model", Outputs},
+
+	SetStatus: {[]string{"S"}, "status", "Set the application status", Applications},
+	AddNote:   {[]string{"N", "n"}, "note", "Add a note to the application", Applications},
+	MoveLeft:  {[]string{"H", "<"}, "move card", "Move the card to the previous status", Applications},
+	MoveRight: {[]string{"L", ">"}, "move card", "Move the card to the next status", Applications},
+
+	CancelBatch: {[]string{"c"}, "cancel", "Cancel the batch", Batches},
+	StartBatch:  {[]string{"s"}, "resume", "Resume the batch", Batches},
+	RetryFailed: {[]string{"r"}, "retry failed", "Retry failed jobs", Batches},
+	ClearBatch:  {[]string{"x"}, "clear batch", "Remove the finished batch", Batches},
+
+	Toggle:       {[]string{" ", "x"}, "toggle", "Toggle the selected model", Comparison},
+	ToggleAll:    {[]string{"a"}, "all", "Toggle all models", Comparison},
+	ToggleDiff:   {[]string{"tab"}, "diff/output", "Switch between diff and output", Comparison},
+	ChooseModels: {[]string{"r"}, "choose models again", "Choose the models again", Comparison},
+	Promote:      {[]string{"p"}, "promote to output", "Save the selected result as an output", Comparison},
+
+	NextPage:   {[]string{"l", "right", "j", "down", "n", "pgdown"}, "page", "Show the next page", PDFPreview},
+	PrevPage:   {[]string{"h", "left", "k", "up", "p", "pgup"}, "page", "Show the previous page", PDFPreview},
+	ToggleText: {[]string{"t"}, "text/image", "Switch between page image and text", PDFPreview},
+}
+
+// vimKeys are the changes of the vim preset: navigation without arrow keys
+// and vim's scrolling.
+var vimKeys = map[Action][]string{
+	Palette:  {":"},
+	Up:       {"k"},
+	Down:     {"j"},
+	Left:     {"h"},
+	Right:    {"l"},
+	PageDown: {"ctrl+d", "ctrl+f"},
+	PageUp:   {"ctrl+u", "ctrl+b"},
+	NextPage: {"l", "j", "ctrl+f"},
+	PrevPage: {"h", "k", "ctrl+b"},
+}
+
+// emacsKeys are the changes of the emacs preset: control keys for movement
+// and M-x for commands.
+var emacsKeys = map[Action][]string{
+	Palette:  {"alt+x"},
+	Back:     {"ctrl+g", "esc"},
+	Up:       {"ctrl+p", "up"},
+	Down:     {"ctrl+n", "down"},
+	Left:     {"ctrl+b", "left"},
+	Right:    {"ctrl+f", "right"},
+	PageDown: {"ctrl+v", "pgdown"},
+	PageUp:   {"alt+v", "pgup"},
+	First:    {"alt+<", "home"},
+	Last:     {"alt+>", "end"},
+	NextPage: {"ctrl+n", "ctrl+v", "right", "down", "pgdown"},
+	PrevPage: {"ctrl+p", "alt+v", "left", "up", "pgup"},
+}
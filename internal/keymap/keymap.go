@@ -0,0 +1,279 @@
+// Package keymap defines the key bindings of the TUI in one place, so they
+// can be remapped from the user config and listed consistently in help bars
+// and the command palette.
+//
+// Screens ask whether a key press triggers an action with Matches and build
+// their help from the bindings of the actions they offer.
+package keymap
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Action names something the user can do, such as "add_output". Actions are
+// the keys of the [keys] table of the user config.
+type Action string
+
+// Binding is an action with the keys triggering it.
+type Binding struct {
+	Action Action
+	Keys   []string
+	// Help is the short description in help bars. Consecutive bindings
+	// with the same Help are shown together, as in "j/k: navigate".
+	Help string
+	// Title describes the action in the command palette.
+	Title    string
+	Category string
+}
+
+// Keymap maps actions to their keys.
+type Keymap map[Action][]string
+
+// active is the keymap screens match keys against.
+var active = defaultKeymap()
+
+// Set replaces the keymap used by Matches and Get.
+func Set(km Keymap) {
+	active = km
+}
+
+// Presets lists the bundled keymaps, the first is used when the user config
+// does not pick one.
+var Presets = []string{"default", "vim", "emacs"}
+
+// Preset returns a bundled keymap by name.
+func Preset(name string) (Keymap, error) {
+	km := defaultKeymap()
+	switch name {
+	case "", "default":
+	case "vim":
+		km.apply(vimKeys)
+	case "emacs":
+		km.apply(emacsKeys)
+	default:
+		return nil, fmt.Errorf("unknown keymap %q, use one of %s", name, strings.Join(Presets, ", "))
+	}
+	return km, nil
+}
+
+// Load returns the preset with the keys of overrides, a map from action to
+// keys as read from the [keys] table of the user config. Unknown actions are
+// reported and the other overrides kept. Overrides that leave quit without a
+// key or bind a key to two actions of a screen are rejected, the preset is
+// returned with the error.
+func Load(preset string, overrides map[string][]string) (Keymap, error) {
+	km, err := Preset(preset)
+	if err != nil {
+		return nil, err
+	}
+	base := maps.Clone(km)
+	var unknown []string
+	for name, keys := range overrides {
+		action := Action(name)
+		if _, ok := definitions[action]; !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		km[action] = normalize(keys)
+	}
+	if err := km.check(); err != nil {
+		return base, err
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return km, fmt.Errorf("unknown actions in [keys]: %s", strings.Join(unknown, ", "))
+	}
+	return km, nil
+}
+
+// check reports keys that trigger two actions of a screen, and quit left
+// without a key.
+func (km Keymap) check() error {
+	if len(km[Quit]) == 0 {
+		return fmt.Errorf("%s needs a key", Quit)
+	}
+	names := slices.Sorted(maps.Keys(screens))
+	var conflicts []string
+	for _, screen := range names {
+		bound := make(map[string]Action)
+		for _, action := range append(slices.Clone(global), screens[screen]...) {
+			for _, key := range km[action] {
+				if other, ok := bound[key]; ok && other != action {
+					conflicts = append(conflicts, fmt.Sprintf("%q is bound to %s and %s on the %s screen",
+						Display(key), other, action, screen))
+				}
+				bound[key] = action
+			}
+		}
+	}
+	if len(conflicts) > 0 {
+		return errors.New(strings.Join(conflicts, ", "))
+	}
+	return nil
+}
+
+func defaultKeymap() Keymap {
+	km := make(Keymap, len(definitions))
+	for action, d := range definitions {
+		km[action] = d.keys
+	}
+	return km
+}
+
+func (km Keymap) apply(keys map[Action][]string) {
+	for action, k := range keys {
+		km[action] = k
+	}
+}
+
+// normalize turns the key names users write into those of tea.KeyMsg.
+func normalize(keys []string) []string {
+	out := make([]string, 0, len(keys))
+	for _, k := range keys {
+		k = strings.TrimSpace(k)
+		if strings.EqualFold(k, "space") {
+			k = " "
+		}
+		if k != "" {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// Matches reports whether msg is one of the keys of action.
+func Matches(msg tea.KeyMsg, action Action) bool {
+	s := msg.String()
+	for _, k := range active[action] {
+		if k == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Keys returns the keys of action.
+func Keys(action Action) []string {
+	return active[action]
+}
+
+// Bindings are the actions offered by a screen, in the order they are shown.
+type Bindings []Binding
+
+// Get returns the bindings of actions in order. Actions without keys are
+// left out.
+func Get(actions ...Action) Bindings {
+	bindings := make(Bindings, 0, len(actions))
+	for _, action := range actions {
+		keys := active[action]
+		if len(keys) == 0 {
+			continue
+		}
+		d := definitions[action]
+		bindings = append(bindings, Binding{
+			Action:   action,
+			Keys:     keys,
+			Help:     d.help,
+			Title:    d.title,
+			Category: d.category,
+		})
+	}
+	return bindings
+}
+
+// Describe sets the help of action to text fitting the screen, such as
+// "export" for Select in the export menu.
+func (bs Bindings) Describe(action Action, help string) Bindings {
+	for i := range bs {
+		if bs[i].Action == action {
+			bs[i].Help = help
+		}
+	}
+	return bs
+}
+
+// Summary describes the binding in lists: its title, or its help when the
+// screen described what the action does there, such as "Choose model".
+func (b Binding) Summary() string {
+	if d, ok := definitions[b.Action]; ok && b.Help != d.help && b.Help != "" {
+		return strings.ToUpper(b.Help[:1]) + b.Help[1:]
+	}
+	return b.Title
+}
+
+// Display returns how a key is shown to the user.
+func Display(key string) string {
+	switch key {
+	case " ":
+		return "space"
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	}
+	return key
+}
+
+// DisplayKeys returns all keys of a binding as shown to the user, such as
+// "j/↓".
+func DisplayKeys(b Binding) string {
+	keys := make([]string, len(b.Keys))
+	for i, k := range b.Keys {
+		keys[i] = Display(k)
+	}
+	return strings.Join(keys, "/")
+}
+
+// HelpLine renders bindings as a help bar, such as
+// "j/k: navigate • enter: select".
+func HelpLine(bindings Bindings) string {
+	var parts []string
+	for i := 0; i < len(bindings); {
+		keys := []string{Display(bindings[i].Keys[0])}
+		j := i + 1
+		for ; j < len(bindings) && bindings[j].Help == bindings[i].Help; j++ {
+			keys = append(keys, Display(bindings[j].Keys[0]))
+		}
+		parts = append(parts, strings.Join(keys, "/")+": "+bindings[i].Help)
+		i = j
+	}
+	return strings.Join(parts, " • ")
+}
+
+// keyTypes maps the names of special keys to their type.
+var keyTypes = func() map[string]tea.KeyType {
+	types := make(map[string]tea.KeyType)
+	for t := tea.KeyType(-256); t < 256; t++ {
+		if name := t.String(); name != "" {
+			types[name] = t
+		}
+	}
+	return types
+}()
+
+// KeyMsg returns the key press for a key name, used to trigger an action
+// picked from the command palette.
+func KeyMsg(key string) tea.KeyMsg {
+	if t, ok := keyTypes[key]; ok {
+		return tea.KeyMsg{Type: t}
+	}
+	alt := false
+	if rest, ok := strings.CutPrefix(key, "alt+"); ok && rest != "" {
+		alt, key = true, rest
+		if t, ok := keyTypes[key]; ok {
+			return tea.KeyMsg{Type: t, Alt: true}
+		}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key), Alt: alt}
+}
@@ -0,0 +1,121 @@
+package keymap
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPresetsHaveNoConflicts(t *testing.T) {
+	for _, name := range Presets {
+		km, err := Preset(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := km.check(); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name      string
+		preset    string
+		overrides map[string][]string
+		wantErr   string
+		// want are the keys expected for actions after loading.
+		want map[Action][]string
+	}{
+		{
+			name:      "override",
+			overrides: map[string][]string{"add": {"a", "+"}},
+			want:      map[Action][]string{Add: {"a", "+"}, Quit: {"ctrl+c"}},
+		},
+		{
+			name:      "space and blank keys",
+			overrides: map[string][]string{"toggle": {"Space", " "}},
+			want:      map[Action][]string{Toggle: {" "}},
+		},
+		{
+			name:      "unknown action keeps the other overrides",
+			overrides: map[string][]string{"add": {"+"}, "launch": {"x"}},
+			wantErr:   "unknown actions in [keys]: launch",
+			want:      map[Action][]string{Add: {"+"}},
+		},
+		{
+			name:      "same key on different screens",
+			overrides: map[string][]string{"toggle_text": {"a"}},
+			want:      map[Action][]string{ToggleText: {"a"}, Add: {"a"}},
+		},
+		{
+			name:      "quit without a key",
+			overrides: map[string][]string{"quit": {}},
+			wantErr:   "quit needs a key",
+			want:      map[Action][]string{Quit: {"ctrl+c"}},
+		},
+		{
+			name:      "key of two actions on a screen",
+			overrides: map[string][]string{"add": {"n"}},
+			wantErr:   `"n" is bound to add and add_note on the project screen`,
+			want:      map[Action][]string{Add: {"a"}},
+		},
+		{
+			name:      "key of a global action",
+			preset:    "vim",
+			overrides: map[string][]string{"preview": {"?"}},
+			wantErr:   "help and preview",
+			want:      map[Action][]string{Help: {"?"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			km, err := Load(tt.preset, tt.overrides)
+			if tt.wantErr == "" && err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+			for action, keys := range tt.want {
+				if !slices.Equal(km[action], keys) {
+					t.Errorf("%s = %q, want %q", action, km[action], keys)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadUnknownPreset(t *testing.T) {
+	if km, err := Load("nano", nil); err == nil || km != nil {
+		t.Errorf("Load() = %v, %v, want an error", km, err)
+	}
+}
+
+func TestKeyMsg(t *testing.T) {
+	tests := []struct {
+		key  string
+		want tea.KeyMsg
+	}{
+		{"a", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")}},
+		{"enter", tea.KeyMsg{Type: tea.KeyEnter}},
+		{"ctrl+c", tea.KeyMsg{Type: tea.KeyCtrlC}},
+		{"alt+x", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x"), Alt: true}},
+		{"alt+enter", tea.KeyMsg{Type: tea.KeyEnter, Alt: true}},
+		{" ", tea.KeyMsg{Type: tea.KeySpace}},
+		{"?", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got := KeyMsg(tt.key)
+			if got.Type != tt.want.Type || got.Alt != tt.want.Alt || string(got.Runes) != string(tt.want.Runes) {
+				t.Errorf("KeyMsg(%q) = %#v, want %#v", tt.key, got, tt.want)
+			}
+			if got.String() != tt.key {
+				t.Errorf("KeyMsg(%q).String() = %q", tt.key, got.String())
+			}
+		})
+	}
+}
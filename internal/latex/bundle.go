@@ -0,0 +1,395 @@
+// Package latex contains helpers for working with LaTeX resume sources.
+package latex
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxIncludeDepth bounds nested \input/\include resolution.
+const maxIncludeDepth = 16
+
+var (
+	includePattern     = regexp.MustCompile(`\\(input|include)\s*\{([^}]+)\}`)
+	classPattern       = regexp.MustCompile(`\\(?:documentclass|LoadClass)\s*(?:\[[^\]]*\])?\s*\{([^}]+)\}`)
+	packagePattern     = regexp.MustCompile(`\\(?:usepackage|RequirePackage)\s*(?:\[[^\]]*\])?\s*\{([^}]+)\}`)
+	graphicsPattern    = regexp.MustCompile(`\\includegraphics\s*(?:\[[^\]]*\])?\s*\{([^}]+)\}`)
+	graphicsPathRegexp = regexp.MustCompile(`\\graphicspath\s*\{((?:\{[^}]*\})+)\}`)
+	bibPattern         = regexp.MustCompile(`\\(?:bibliography|addbibresource)\s*(?:\[[^\]]*\])?\s*\{([^}]+)\}`)
+	bibStylePattern    = regexp.MustCompile(`\\bibliographystyle\s*\{([^}]+)\}`)
+	fontPathPattern    = regexp.MustCompile(`Path\s*=\s*([^,\]\}]+)`)
+)
+
+// graphicExtensions are tried in order when \includegraphics omits one.
+var graphicExtensions = []string{".pdf", ".png", ".jpg", ".jpeg", ".eps"}
+
+// ErrNoMainFile is returned when a directory contains no .tex file with a
+// \documentclass.
+var ErrNoMainFile = errors.New("no .tex file with \\documentclass found")
+
+// Bundle is a LaTeX document flattened into a single source, together with
+// the local files it needs to compile.
+type Bundle struct {
+	// Root is the directory of the main file. Relative paths in the source
+	// are resolved against it, as latex does.
+	Root string
+	// Main is the path of the main .tex file.
+	Main string
+	// Source is the main file with every resolvable \input and \include
+	// replaced by the content of the referenced file.
+	Source string
+	// Assets lists class, style, image, font and bibliography files the
+	// document depends on, relative to Root.
+	Assets []string
+	// Files lists the main file and every file merged into Source.
+	Files []string
+}
+
+// Load reads a resume from a .tex file, or from the main .tex file of a
+// directory, and resolves its includes and local dependencies.
+func Load(path string) (*Bundle, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		if path, err = FindMain(path); err != nil {
+			return nil, err
+		}
+	}
+
+	b := &Bundle{
+		Root: filepath.Dir(path),
+		Main: path,
+	}
+	source, err := b.flatten(path, map[string]bool{}, 0)
+	if err != nil {
+		return nil, err
+	}
+	b.Source = source
+	b.Assets = b.findAssets(source)
+	return b, nil
+}
+
+// FindMain returns the main .tex file in dir: the file containing
+// \documentclass, preferring conventional names when there are several.
+func FindMain(dir string) (string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.tex"))
+	if err != nil {
+		return "", err
+	}
+
+	var candidates []string
+	for _, m := range matches {
+		data, err := os.ReadFile(m)
+		if err != nil {
+			continue
+		}
+		if classPattern.Match(stripComments(data)) {
+			candidates = append(candidates, m)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("%s: %w", dir, ErrNoMainFile)
+	}
+
+	for _, preferred := range []string{"resume.tex", "cv.tex", "main.tex"} {
+		for _, c := range candidates {
+			if strings.EqualFold(filepath.Base(c), preferred) {
+				return c, nil
+			}
+		}
+	}
+	sort.Strings(candidates)
+	return candidates[0], nil
+}
+
+// flatten returns the content of path with \input and \include expanded.
+// Files that cannot be found are left as references.
+func (b *Bundle) flatten(path string, active map[string]bool, depth int) (string, error) {
+	if depth > maxIncludeDepth {
+		return "", fmt.Errorf("includes nested deeper than %d levels at %s", maxIncludeDepth, path)
+	}
+	if active[path] {
+		return "", fmt.Errorf("circular include of %s", path)
+	}
+	active[path] = true
+	defer delete(active, path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if !contains(b.Files, path) {
+		b.Files = append(b.Files, path)
+	}
+
+	lines := strings.SplitAfter(string(data), "\n")
+	for i, line := range lines {
+		code, comment := splitComment(line)
+		var expandErr error
+		code = includePattern.ReplaceAllStringFunc(code, func(match string) string {
+			parts := includePattern.FindStringSubmatch(match)
+			target := b.resolve(parts[2], ".tex")
+			if target == "" {
+				return match
+			}
+			content, err := b.flatten(target, active, depth+1)
+			if err != nil {
+				expandErr = err
+				return match
+			}
+			rel, _ := filepath.Rel(b.Root, target)
+			content = fmt.Sprintf("%% begin %s\n%s\n%% end %s\n", rel, strings.TrimRight(content, "\n"), rel)
+			if parts[1] == "include" {
+				content = "\\clearpage\n" + content + "\\clearpage\n"
+			}
+			return content
+		})
+		if expandErr != nil {
+			return "", expandErr
+		}
+		lines[i] = code + comment
+	}
+	return strings.Join(lines, ""), nil
+}
+
+// resolve finds name relative to the bundle root, trying ext when the name
+// has no extension. It returns "" when no such file exists.
+func (b *Bundle) resolve(name, ext string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ""
+	}
+	candidates := []string{name}
+	if filepath.Ext(name) == "" {
+		candidates = []string{name + ext, name}
+	}
+	for _, c := range candidates {
+		p := c
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(b.Root, p)
+		}
+		if info, err := os.Stat(p); err == nil && !info.IsDir() {
+			return p
+		}
+	}
+	return ""
+}
+
+// findAssets lists the local files source depends on. Local classes and
+// packages are scanned too, since they may pull in further local files.
+func (b *Bundle) findAssets(source string) []string {
+	seen := make(map[string]bool)
+	var assets []string
+
+	add := func(p string) bool {
+		if p == "" {
+			return false
+		}
+		rel, err := filepath.Rel(b.Root, p)
+		if err != nil || strings.HasPrefix(rel, "..") || seen[rel] {
+			return false
+		}
+		seen[rel] = true
+		assets = append(assets, rel)
+		return true
+	}
+
+	var scan func(text string)
+	scan = func(text string) {
+		code := string(stripComments([]byte(text)))
+
+		for _, m := range classPattern.FindAllStringSubmatch(code, -1) {
+			b.addStyle(add, scan, m[1], ".cls")
+		}
+		for _, m := range packagePattern.FindAllStringSubmatch(code, -1) {
+			for _, name := range strings.Split(m[1], ",") {
+				b.addStyle(add, scan, name, ".sty")
+			}
+		}
+
+		graphicsDirs := []string{""}
+		for _, m := range graphicsPathRegexp.FindAllStringSubmatch(code, -1) {
+			for _, dir := range strings.Split(strings.Trim(m[1], "{}"), "}{") {
+				graphicsDirs = append(graphicsDirs, dir)
+			}
+		}
+		for _, m := range graphicsPattern.FindAllStringSubmatch(code, -1) {
+			for _, dir := range graphicsDirs {
+				if b.addGraphic(add, filepath.Join(dir, strings.TrimSpace(m[1]))) {
+					break
+				}
+			}
+		}
+
+		for _, m := range bibPattern.FindAllStringSubmatch(code, -1) {
+			for _, name := range strings.Split(m[1], ",") {
+				add(b.resolve(name, ".bib"))
+			}
+		}
+		for _, m := range bibStylePattern.FindAllStringSubmatch(code, -1) {
+			add(b.resolve(m[1], ".bst"))
+		}
+
+		for _, m := range fontPathPattern.FindAllStringSubmatch(code, -1) {
+			b.addFontDir(add, strings.TrimSpace(m[1]))
+		}
+	}
+	scan(source)
+
+	sort.Strings(assets)
+	return assets
+}
+
+func (b *Bundle) addStyle(add func(string) bool, scan func(string), name, ext string) {
+	p := b.resolve(name, ext)
+	if p == "" || filepath.Ext(p) != ext {
+		return
+	}
+	if !add(p) {
+		return
+	}
+	if data, err := os.ReadFile(p); err == nil {
+		scan(string(data))
+	}
+}
+
+func (b *Bundle) addGraphic(add func(string) bool, name string) bool {
+	if filepath.Ext(name) != "" {
+		if p := b.resolve(name, ""); p != "" {
+			add(p)
+			return true
+		}
+		return false
+	}
+	for _, ext := range graphicExtensions {
+		if p := b.resolve(name+ext, ""); p != "" {
+			add(p)
+			return true
+		}
+	}
+	return false
+}
+
+// addFontDir adds every font file from a fontspec Path= directory.
+func (b *Bundle) addFontDir(add func(string) bool, dir string) {
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(b.Root, dir)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".ttf", ".otf":
+			add(filepath.Join(dir, e.Name()))
+		}
+	}
+}
+
+// ModTime returns the latest modification time of the files making up the
+// bundle, which changes whenever a re-import would produce a different
+// result.
+func (b *Bundle) ModTime() time.Time {
+	var latest time.Time
+	paths := append([]string{}, b.Files...)
+	for _, rel := range b.Assets {
+		paths = append(paths, filepath.Join(b.Root, rel))
+	}
+	for _, p := range paths {
+		if info, err := os.Stat(p); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
+
+// CopyAssets copies the bundle's assets into dest, keeping their paths
+// relative to the bundle root. Files listed in skip are not overwritten.
+func (b *Bundle) CopyAssets(dest string, skip ...string) error {
+	for _, rel := range b.Assets {
+		if contains(skip, filepath.ToSlash(rel)) {
+			continue
+		}
+		target := filepath.Join(dest, rel)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := copyFile(filepath.Join(b.Root, rel), target); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", rel, err)
+		}
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// splitComment splits a line into code and a trailing % comment. Escaped
+// percent signs (\%) are not treated as comments.
+func splitComment(line string) (string, string) {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '%':
+			return line[:i], line[i:]
+		}
+	}
+	return line, ""
+}
+
+// StripComments removes % comments from every line of source.
+func StripComments(source string) string {
+	return string(stripComments([]byte(source)))
+}
+
+// stripComments removes % comments from every line of data.
+func stripComments(data []byte) []byte {
+	lines := strings.SplitAfter(string(data), "\n")
+	for i, line := range lines {
+		code, comment := splitComment(line)
+		if strings.HasSuffix(comment, "\n") {
+			code += "\n"
+		}
+		lines[i] = code
+	}
+	return []byte(strings.Join(lines, ""))
+}
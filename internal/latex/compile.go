@@ -0,0 +1,148 @@
+package latex
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Engines lists the supported LaTeX engines in order of preference when a
+// project does not configure one.
+var Engines = []string{"latexmk", "tectonic", "pdflatex", "xelatex", "lualatex"}
+
+// Timeout bounds a single run of the engine, so a document waiting for input
+// or looping cannot hang the caller.
+const Timeout = 5 * time.Minute
+
+var ErrNoEngine = errors.New("no LaTeX engine found, install latexmk, tectonic or a TeX distribution")
+
+// CompileOptions controls how a document is compiled.
+type CompileOptions struct {
+	// Engine is one of Engines, or empty to pick the first installed one.
+	Engine string
+	// SearchPaths are extra directories searched for classes, packages and
+	// images, such as the project directory holding imported assets.
+	SearchPaths []string
+}
+
+// CompileError is returned when the engine ran but failed. It carries the
+// errors reported in the log.
+type CompileError struct {
+	Engine string
+	Errors []string
+	Output string
+}
+
+func (e *CompileError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("%s failed", e.Engine)
+	}
+	return fmt.Sprintf("%s failed: %s", e.Engine, strings.Join(e.Errors, "; "))
+}
+
+// FindEngine returns engine if it is installed, or the first installed engine
+// from Engines when engine is empty.
+func FindEngine(engine string) (string, error) {
+	if engine != "" {
+		if _, err := exec.LookPath(engine); err != nil {
+			return "", fmt.Errorf("LaTeX engine %q not found", engine)
+		}
+		return engine, nil
+	}
+	for _, e := range Engines {
+		if _, err := exec.LookPath(e); err == nil {
+			return e, nil
+		}
+	}
+	return "", ErrNoEngine
+}
+
+// Compile turns texPath into a PDF next to it and returns the PDF path. The
+// engine is stopped after Timeout.
+func Compile(ctx context.Context, texPath string, opts CompileOptions) (string, error) {
+	engine, err := FindEngine(opts.Engine)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	dir := filepath.Dir(texPath)
+	file := filepath.Base(texPath)
+
+	var args []string
+	switch engine {
+	case "latexmk":
+		args = []string{"-pdf", "-interaction=nonstopmode", "-halt-on-error", file}
+	case "tectonic":
+		args = []string{"--keep-logs", file}
+	default:
+		args = []string{"-interaction=nonstopmode", "-halt-on-error", file}
+	}
+
+	cmd := exec.CommandContext(ctx, engine, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), searchPathEnv(opts.SearchPaths)...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("%s did not finish within %s: %w", engine, Timeout, ctx.Err())
+		}
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", &CompileError{
+			Engine: engine,
+			Errors: logErrors(out.String()),
+			Output: out.String(),
+		}
+	}
+
+	pdf := strings.TrimSuffix(texPath, filepath.Ext(texPath)) + ".pdf"
+	if _, err := os.Stat(pdf); err != nil {
+		return "", fmt.Errorf("%s did not produce %s", engine, filepath.Base(pdf))
+	}
+	return pdf, nil
+}
+
+// searchPathEnv builds TEXINPUTS and friends so the engine also looks in
+// paths. The trailing separator keeps the default search path, the double
+// slash searches subdirectories.
+func searchPathEnv(paths []string) []string {
+	if len(paths) == 0 {
+		return nil
+	}
+	var parts []string
+	for _, p := range paths {
+		parts = append(parts, p+string(os.PathSeparator)+string(os.PathSeparator))
+	}
+	value := strings.Join(parts, string(os.PathListSeparator)) + string(os.PathListSeparator)
+	return []string{
+		"TEXINPUTS=" + value,
+		"BIBINPUTS=" + value,
+		"BSTINPUTS=" + value,
+	}
+}
+
+// logErrors extracts the "! ..." error lines from engine output.
+func logErrors(output string) []string {
+	var errs []string
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "! ") || strings.HasPrefix(line, "error: ") {
+			errs = append(errs, strings.TrimPrefix(strings.TrimPrefix(line, "! "), "error: "))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,21 @@
+package latex
+
+import "strings"
+
+var escaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+)
+
+// Escape makes plain text safe to include in a LaTeX document.
+func Escape(s string) string {
+	return escaper.Replace(s)
+}
@@ -0,0 +1,45 @@
+package latex
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// DocumentClass returns the class of a document, or "" when it has none.
+func DocumentClass(source string) string {
+	if m := classPattern.FindStringSubmatch(StripComments(source)); m != nil {
+		return strings.TrimSpace(m[1])
+	}
+	return ""
+}
+
+// Packages lists the packages a document loads, in order.
+func Packages(source string) []string {
+	var pkgs []string
+	for _, m := range packagePattern.FindAllStringSubmatch(StripComments(source), -1) {
+		for _, p := range strings.Split(m[1], ",") {
+			if p = strings.TrimSpace(p); p != "" && !contains(pkgs, p) {
+				pkgs = append(pkgs, p)
+			}
+		}
+	}
+	return pkgs
+}
+
+// MissingFiles returns which of files, such as "moderncv.cls" or
+// "fontspec.sty", the TeX installation cannot find. The second result is
+// false when that cannot be told because kpsewhich is not installed, as
+// with tectonic, which downloads packages on demand.
+func MissingFiles(files ...string) ([]string, bool) {
+	path, err := exec.LookPath("kpsewhich")
+	if err != nil {
+		return nil, false
+	}
+	var missing []string
+	for _, f := range files {
+		if out, err := exec.Command(path, f).Output(); err != nil || strings.TrimSpace(string(out)) == "" {
+			missing = append(missing, f)
+		}
+	}
+	return missing, true
+}
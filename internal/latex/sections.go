@@ -0,0 +1,114 @@
+package latex
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PreambleSection names everything before the first section: the document
+// setup and usually the resume header with contact details.
+const PreambleSection = "Preamble"
+
+// sectionPattern matches \section{...} as well as the section macros common
+// resume classes define, such as \cvsection{...} or \resumeSection{...}.
+// Subsections are not split out.
+var sectionPattern = regexp.MustCompile(`(?m)\\([a-zA-Z]*[sS]ection)\*?\s*(?:\[[^\]]*\])?\s*\{([^}]*)\}`)
+
+// Section is a top-level part of a resume.
+type Section struct {
+	Title string
+	Body  string
+}
+
+// Sections splits source into its preamble and top-level sections.
+func Sections(source string) []Section {
+	var locs [][]int
+	for _, loc := range sectionPattern.FindAllStringSubmatchIndex(source, -1) {
+		cmd := source[loc[2]:loc[3]]
+		if strings.Contains(strings.ToLower(cmd), "subsection") {
+			continue
+		}
+		locs = append(locs, loc)
+	}
+
+	sections := []Section{{Title: PreambleSection}}
+	if len(locs) == 0 {
+		sections[0].Body = source
+		return sections
+	}
+	sections[0].Body = source[:locs[0][0]]
+
+	for i, loc := range locs {
+		end := len(source)
+		if i+1 < len(locs) {
+			end = locs[i+1][0]
+		}
+		sections = append(sections, Section{
+			Title: strings.TrimSpace(source[loc[4]:loc[5]]),
+			Body:  source[loc[0]:end],
+		})
+	}
+	return sections
+}
+
+// Fingerprint identifies a version of a resume, both as a whole and per
+// section, so later versions can be compared without keeping a copy.
+type Fingerprint struct {
+	Hash     string            `toml:"hash"`
+	Sections map[string]string `toml:"sections"`
+}
+
+// NewFingerprint computes the fingerprint of source.
+func NewFingerprint(source string) Fingerprint {
+	fp := Fingerprint{
+		Hash:     hash(normalizeSpace(source)),
+		Sections: make(map[string]string),
+	}
+	for _, s := range Sections(source) {
+		title := s.Title
+		// Keep repeated titles apart so each one is compared on its own.
+		for n := 2; fp.Sections[title] != ""; n++ {
+			title = s.Title + " #" + strconv.Itoa(n)
+		}
+		fp.Sections[title] = hash(normalizeSpace(s.Body))
+	}
+	return fp
+}
+
+// IsZero reports whether the fingerprint was never computed.
+func (f Fingerprint) IsZero() bool {
+	return f.Hash == ""
+}
+
+// ChangedSections lists the titles of sections that were added, removed or
+// modified between f and other, in sorted order.
+func (f Fingerprint) ChangedSections(other Fingerprint) []string {
+	var changed []string
+	for title, h := range f.Sections {
+		if other.Sections[title] != h {
+			changed = append(changed, title)
+		}
+	}
+	for title := range other.Sections {
+		if _, ok := f.Sections[title]; !ok {
+			changed = append(changed, title)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:8])
+}
+
+// normalizeSpace collapses whitespace so reflowing a paragraph does not count
+// as a change.
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,160 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/keymap"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/FabricSoul/auto-resume/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Filters of the outputs list besides the application statuses.
+const (
+	filterAll    = ""
+	filterActive = "active"
+)
+
+// outputFilters lists the filters cycled with f.
+func outputFilters() []string {
+	filters := []string{filterAll, filterActive}
+	for _, s := range types.ApplicationStatuses {
+		filters = append(filters, string(s))
+	}
+	return filters
+}
+
+// matchesFilter reports whether out is listed under the current filter.
+func (m *ProjectDetailModel) matchesFilter(out types.Output) bool {
+	status := out.Application.CurrentStatus()
+	switch m.outputFilter {
+	case filterAll:
+		return true
+	case filterActive:
+		return !status.Closed()
+	}
+	return string(status) == m.outputFilter
+}
+
+// cycleOutputFilter switches to the next filter and selects the first
+// output it shows.
+func (m *ProjectDetailModel) cycleOutputFilter() {
+	filters := outputFilters()
+	next := (slices.Index(filters, m.outputFilter) + 1) % len(filters)
+	m.outputFilter = filters[next]
+	if order := m.outputOrder(); len(order) > 0 {
+		m.selectedOutputIndex = order[0]
+	}
+}
+
+// openBoard shows the application board of this project.
+func (m *ProjectDetailModel) openBoard() tea.Cmd {
+	project := types.Project{Name: m.overviewProjectName, Path: m.projectDir}
+	if p, err := m.projects.GetProject(m.overviewProjectName); err == nil {
+		project = p
+	}
+	params := boardParams{
+		projects: []types.Project{project},
+		back:     types.TransitionMsg{To: types.StateProjectOverview, Params: project},
+	}
+	return func() tea.Msg {
+		return types.TransitionMsg{To: types.StateBoard, Params: params}
+	}
+}
+
+// openStatusSelector shows the application statuses for the selected output.
+func (m *ProjectDetailModel) openStatusSelector() {
+	if len(m.outputs) == 0 {
+		return
+	}
+	types.AssignSlugs(m.outputs)
+	out := m.outputs[m.selectedOutputIndex]
+	m.statusSlug = out.Slug
+	m.statusCursor = max(slices.Index(types.ApplicationStatuses, out.Application.CurrentStatus()), 0)
+	m.showStatusSelector = true
+}
+
+// updateStatusSelector handles keys while the status selector is shown.
+func (m *ProjectDetailModel) updateStatusSelector(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case keymap.Matches(msg, keymap.Back), keymap.Matches(msg, keymap.SetStatus):
+		m.showStatusSelector = false
+	case keymap.Matches(msg, keymap.Down):
+		if m.statusCursor < len(types.ApplicationStatuses)-1 {
+			m.statusCursor++
+		}
+	case keymap.Matches(msg, keymap.Up):
+		if m.statusCursor > 0 {
+			m.statusCursor--
+		}
+	case keymap.Matches(msg, keymap.Select):
+		m.showStatusSelector = false
+		i := m.outputIndex(m.statusSlug)
+		if i < 0 {
+			return nil
+		}
+		current := &m.outputs[i]
+		status := types.ApplicationStatuses[m.statusCursor]
+		if !current.Application.SetStatus(status, "", time.Now()) {
+			return nil
+		}
+		message := fmt.Sprintf("Mark %s as %s", current.Name, status)
+		return m.saveWithMessage(message)
+	}
+	return nil
+}
+
+// promptApplicationNote asks for a note on the application of the selected
+// output.
+func (m *ProjectDetailModel) promptApplicationNote() tea.Cmd {
+	if len(m.outputs) == 0 {
+		return nil
+	}
+	types.AssignSlugs(m.outputs)
+	out := m.outputs[m.selectedOutputIndex]
+	return func() tea.Msg {
+		return types.ShowFloatInputMsg{
+			Prompt: "Note for " + out.Name,
+			Submit: func(value string) tea.Cmd {
+				// Look the output up again, the outputs may have been
+				// replaced while the note was written.
+				i := m.outputIndex(out.Slug)
+				if i < 0 {
+					return nil
+				}
+				current := &m.outputs[i]
+				current.Application.AddNote(value, time.Now())
+				message := fmt.Sprintf("Add note to %s", current.Name)
+				return m.saveWithMessage(message)
+			},
+		}
+	}
+}
+
+func (m *ProjectDetailModel) renderStatusSelector() string {
+	i := m.outputIndex(m.statusSlug)
+	if i < 0 {
+		content := ui.Title.Render("Application Status") + "\n\n" + "The output was removed.\n\n" +
+			ui.Help.Render(keymap.HelpLine(menuBindings("set status")))
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, ui.FloatBox.Render(content))
+	}
+	out := m.outputs[i]
+	content := ui.Title.Render("Application Status: "+out.Name) + "\n\n"
+	for i, status := range types.ApplicationStatuses {
+		item := applicationStyle(status).Render(status.Title())
+		if status == out.Application.CurrentStatus() {
+			item += " (current)"
+		}
+		if i == m.statusCursor {
+			item = ui.SelectedItem.Render("► ") + item
+		} else {
+			item = "  " + item
+		}
+		content += item + "\n"
+	}
+	content += ui.Help.Render(keymap.HelpLine(menuBindings("set status")))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, ui.FloatBox.Render(content))
+}
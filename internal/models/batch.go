@@ -0,0 +1,313 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/batch"
+	"github.com/FabricSoul/auto-resume/internal/keymap"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/FabricSoul/auto-resume/internal/ui"
+	"github.com/FabricSoul/auto-resume/pkg/config"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// batchParams are passed with the transition to StateBatch.
+type batchParams struct {
+	project types.Project
+	queue   *batch.Queue
+}
+
+// batchEventMsg carries one progress event of a running batch. ok is false
+// once the runner has closed its event channel.
+type batchEventMsg struct {
+	projectDir string
+	event      batch.Event
+	ok         bool
+	events     <-chan batch.Event
+}
+
+func waitForBatchEvent(projectDir string, events <-chan batch.Event) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-events
+		return batchEventMsg{projectDir: projectDir, event: event, ok: ok, events: events}
+	}
+}
+
+// BatchModel shows the progress of a batch generation. The batch keeps
+// running while other screens are shown.
+type BatchModel struct {
+	width, height int
+
+	pm      *types.ProjectManager
+	project types.Project
+	model   types.AIModel
+	limit   int
+
+	// queue is owned by the runner while running is set, the table is drawn
+	// from entries which are updated from its events.
+	queue    *batch.Queue
+	entries  []batch.Entry
+	running  bool
+	cancel   context.CancelFunc
+	events   <-chan batch.Event
+	selected int
+}
+
+// NewBatchModel creates the progress screen for queue. The model named by the
+// queue must still be configured.
+func NewBatchModel(pm *types.ProjectManager, project types.Project, queue *batch.Queue) (*BatchModel, error) {
+	m := &BatchModel{pm: pm, project: project, queue: queue}
+	for _, model := range pm.GetModels() {
+		if model.Name == queue.Model {
+			m.model = model
+		}
+	}
+	if m.model.Name == "" {
+		return nil, fmt.Errorf("%w: '%s'", types.ErrModelNotFound, queue.Model)
+	}
+	m.limit = pm.ProviderConcurrency(m.model.Provider)
+	m.entries = append([]batch.Entry(nil), queue.Entries...)
+	return m, nil
+}
+
+func (m *BatchModel) Init() tea.Cmd {
+	return m.start()
+}
+
+// start runs the queued jobs in the background.
+func (m *BatchModel) start() tea.Cmd {
+	if m.running || !m.queue.Pending() {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.running = true
+
+	// Every job reports at most twice plus the final event, so the runner
+	// never blocks on a screen that is not being updated.
+	events := make(chan batch.Event, 2*len(m.entries)+1)
+	runner := &batch.Runner{
+		ProjectDir: m.project.Path,
+		Model:      m.model,
+		Limit:      m.limit,
+		Queue:      m.queue,
+	}
+	m.events = events
+	go runner.Run(ctx, events)
+	return waitForBatchEvent(m.project.Path, events)
+}
+
+// handleEvent applies a progress event and waits for the next one.
+func (m *BatchModel) handleEvent(msg batchEventMsg) tea.Cmd {
+	// Events of an earlier run end their chain here.
+	if !msg.ok || msg.events != m.events {
+		return nil
+	}
+	next := waitForBatchEvent(msg.projectDir, msg.events)
+	if msg.event.Finished {
+		m.running = false
+		m.cancel()
+		if msg.event.Err != nil {
+			return tea.Batch(next, func() tea.Msg {
+				return types.ErrorMsg{Error: fmt.Errorf("batch generation failed: %w", msg.event.Err)}
+			})
+		}
+		return next
+	}
+	if msg.event.Index < len(m.entries) {
+		m.entries[msg.event.Index] = msg.event.Entry
+	}
+	return next
+}
+
+func (m *BatchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case batchEventMsg:
+		return m, m.handleEvent(msg)
+
+	case tea.KeyMsg:
+		switch {
+		case keymap.Matches(msg, keymap.Back), keymap.Matches(msg, keymap.Close):
+			project := m.project
+			return m, func() tea.Msg {
+				return types.TransitionMsg{To: types.StateProjectOverview, Params: project}
+			}
+		case keymap.Matches(msg, keymap.Up):
+			if m.selected > 0 {
+				m.selected--
+			}
+		case keymap.Matches(msg, keymap.Down):
+			if m.selected < len(m.entries)-1 {
+				m.selected++
+			}
+		case keymap.Matches(msg, keymap.CancelBatch):
+			if m.running {
+				m.cancel()
+			}
+		case keymap.Matches(msg, keymap.StartBatch):
+			return m, m.start()
+		case keymap.Matches(msg, keymap.RetryFailed):
+			if !m.running && m.queue.RetryFailed() > 0 {
+				m.entries = append(m.entries[:0], m.queue.Entries...)
+				return m, m.start()
+			}
+		case keymap.Matches(msg, keymap.ClearBatch):
+			// Clearing a finished batch removes the queue file; the generated
+			// outputs stay in the project.
+			if !m.running {
+				if err := batch.RemoveQueue(m.project.Path); err != nil {
+					return m, func() tea.Msg {
+						return types.ErrorMsg{Error: fmt.Errorf("failed to remove batch: %w", err)}
+					}
+				}
+				project := m.project
+				return m, func() tea.Msg {
+					return types.TransitionMsg{To: types.StateProjectOverview, Params: project}
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+func statusStyle(status batch.Status) lipgloss.Style {
+	switch status {
+	case batch.StatusRunning:
+		return ui.StatusRunning
+	case batch.StatusDone:
+		return ui.StatusDone
+	case batch.StatusFailed:
+		return ui.StatusFailed
+	default:
+		return ui.StatusQueued
+	}
+}
+
+func truncate(s string, width int) string {
+	r := []rune(s)
+	if width <= 1 || len(r) <= width {
+		return s
+	}
+	return string(r[:width-1]) + "…"
+}
+
+func (m *BatchModel) View() string {
+	content := ui.Title.Render(fmt.Sprintf("Batch generation: %s", m.project.Name)) + "\n"
+	content += fmt.Sprintf("Model: %s (%s/%s) • %d at a time\n", m.model.Name, m.model.Provider, m.model.Model, m.limit)
+
+	counts := make(map[batch.Status]int)
+	for _, e := range m.entries {
+		counts[e.Status]++
+	}
+	content += fmt.Sprintf("%d queued • %d running • %d done • %d failed\n\n",
+		counts[batch.StatusQueued], counts[batch.StatusRunning], counts[batch.StatusDone], counts[batch.StatusFailed])
+
+	nameWidth := max(m.width-60, 20)
+	header := fmt.Sprintf("  %-4s %-*s %-8s %-8s %s", "#", nameWidth, "Job", "Status", "Time", "Output")
+	content += ui.Help.UnsetMarginTop().Render(header) + "\n"
+
+	// Keep the selected row visible when there are more jobs than lines.
+	rows := max(m.height-16, 5)
+	first := 0
+	if m.selected >= rows {
+		first = m.selected - rows + 1
+	}
+	for i := first; i < len(m.entries) && i < first+rows; i++ {
+		e := m.entries[i]
+		elapsed := ""
+		if d := e.Duration(); d > 0 {
+			elapsed = d.Round(time.Second).String()
+		}
+		detail := e.OutputSlug
+		if e.Status == batch.StatusFailed {
+			detail = strings.SplitN(e.Error, "\n", 2)[0]
+		}
+		row := fmt.Sprintf("%-4d %-*s %s %-8s %s",
+			i+1, nameWidth, truncate(e.Job.Name, nameWidth),
+			statusStyle(e.Status).Render(fmt.Sprintf("%-8s", e.Status)),
+			elapsed, truncate(detail, 40))
+		if i == m.selected {
+			row = ui.SelectedItem.Render("► ") + row
+		} else {
+			row = "  " + row
+		}
+		content += row + "\n"
+	}
+
+	if m.selected < len(m.entries) {
+		e := m.entries[m.selected]
+		content += "\nSource: " + e.Job.Source + "\n"
+		if e.Error != "" {
+			content += ui.ErrorTitle.Render("Error: "+e.Error) + "\n"
+		}
+	}
+
+	content += ui.Help.Render(keymap.HelpLine(m.Bindings()))
+	return ui.JoinedContainer.Render(content)
+}
+
+// Bindings returns the actions of the batch, which depend on whether it is
+// running.
+func (m *BatchModel) Bindings() keymap.Bindings {
+	if m.running {
+		return keymap.Get(keymap.Down, keymap.Up, keymap.Back, keymap.CancelBatch, keymap.Palette, keymap.Help).
+			Describe(keymap.Back, "back (keeps running)")
+	}
+	return keymap.Get(keymap.Down, keymap.Up, keymap.Back, keymap.StartBatch, keymap.RetryFailed,
+		keymap.ClearBatch, keymap.Palette, keymap.Help)
+}
+
+// promptBatch asks for a directory, CSV or JSONL file of job descriptions and
+// opens the batch screen for them. An unfinished batch is resumed instead.
+func (m *ProjectDetailModel) promptBatch() tea.Cmd {
+	project := types.Project{Name: m.overviewProjectName, Path: m.projectDir}
+	if p, err := m.projects.GetProject(m.overviewProjectName); err == nil {
+		project = p
+	}
+
+	queue, err := batch.LoadQueue(m.projectDir)
+	if err != nil {
+		return func() tea.Msg { return types.ErrorMsg{Error: err} }
+	}
+	if queue != nil {
+		return func() tea.Msg {
+			return types.TransitionMsg{To: types.StateBatch, Params: batchParams{project: project, queue: queue}}
+		}
+	}
+
+	if len(m.llmOptions) == 0 {
+		return func() tea.Msg {
+			return types.ErrorMsg{Error: fmt.Errorf("no LLM model selected")}
+		}
+	}
+	model := m.llmOptions[m.selectedLLMIndex]
+	return func() tea.Msg {
+		return types.ShowFloatInputMsg{
+			Prompt: "Job descriptions (directory, .csv or .jsonl)",
+			Submit: func(value string) tea.Cmd {
+				source := config.ExpandHome(strings.TrimSpace(value))
+				jobs, err := batch.LoadJobs(source)
+				if err != nil {
+					return func() tea.Msg {
+						return types.ErrorMsg{Error: fmt.Errorf("failed to load jobs: %w", err)}
+					}
+				}
+				queue := batch.NewQueue(model.Name, source, jobs)
+				if err := queue.Save(project.Path); err != nil {
+					return func() tea.Msg { return types.ErrorMsg{Error: err} }
+				}
+				return func() tea.Msg {
+					return types.TransitionMsg{To: types.StateBatch, Params: batchParams{project: project, queue: queue}}
+				}
+			},
+		}
+	}
+}
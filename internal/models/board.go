@@ -0,0 +1,334 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/keymap"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/FabricSoul/auto-resume/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// boardParams are passed with the transition to StateBoard.
+type boardParams struct {
+	projects []types.Project
+	// back is the transition taken when leaving the board.
+	back types.TransitionMsg
+}
+
+// boardCard is one output on the board.
+type boardCard struct {
+	project types.Project
+	output  types.Output
+}
+
+// BoardModel shows the outputs of one or more projects as cards in a column
+// per application status.
+type BoardModel struct {
+	width, height int
+
+	params  boardParams
+	cards   []boardCard
+	skipped int // projects that could not be loaded
+
+	column int
+	row    int
+}
+
+func NewBoardModel(params boardParams) *BoardModel {
+	m := &BoardModel{params: params}
+	for _, p := range params.projects {
+		config, err := types.LoadProjectConfig(p.Path)
+		if err != nil {
+			m.skipped++
+			continue
+		}
+		for _, out := range config.Outputs {
+			m.cards = append(m.cards, boardCard{project: p, output: out})
+		}
+	}
+	return m
+}
+
+func (m *BoardModel) Init() tea.Cmd {
+	return nil
+}
+
+// columnCards returns the indices of the cards in column, most recently
+// moved first.
+func (m *BoardModel) columnCards(column int) []int {
+	status := types.ApplicationStatuses[column]
+	var cards []int
+	for i, c := range m.cards {
+		if c.output.Application.CurrentStatus() == status {
+			cards = append(cards, i)
+		}
+	}
+	sort.SliceStable(cards, func(i, j int) bool {
+		a, b := m.cards[cards[i]].output, m.cards[cards[j]].output
+		return a.Application.Since().After(b.Application.Since())
+	})
+	return cards
+}
+
+// selected returns the index of the selected card, or -1.
+func (m *BoardModel) selected() int {
+	cards := m.columnCards(m.column)
+	if len(cards) == 0 {
+		return -1
+	}
+	m.row = min(m.row, len(cards)-1)
+	return cards[m.row]
+}
+
+// setStatus moves the selected card to status and keeps it selected.
+func (m *BoardModel) setStatus(status types.ApplicationStatus) tea.Cmd {
+	i := m.selected()
+	if i < 0 {
+		return nil
+	}
+	card := &m.cards[i]
+	if !card.output.Application.SetStatus(status, "", time.Now()) {
+		return nil
+	}
+	m.column = slices.Index(types.ApplicationStatuses, status)
+	m.row = slices.Index(m.columnCards(m.column), i)
+	return m.save(*card, fmt.Sprintf("Mark %s as %s", card.output.Name, status))
+}
+
+// promptNote asks for a note to add to the selected card.
+func (m *BoardModel) promptNote() tea.Cmd {
+	i := m.selected()
+	if i < 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		return types.ShowFloatInputMsg{
+			Prompt: "Note for " + m.cards[i].output.Name,
+			Submit: func(value string) tea.Cmd {
+				card := &m.cards[i]
+				card.output.Application.AddNote(value, time.Now())
+				return m.save(*card, fmt.Sprintf("Add note to %s", card.output.Name))
+			},
+		}
+	}
+}
+
+// save writes the application of card to its project. It goes through the
+// generation results so an open project screen picks the change up.
+func (m *BoardModel) save(card boardCard, message string) tea.Cmd {
+	slug := card.output.Slug
+	application := card.output.Application
+	application.Events = slices.Clone(application.Events)
+	done := generationDoneMsg{
+		projectDir: card.project.Path,
+		label:      card.project.Name + ": " + card.output.Name,
+		apply: func(outputs []types.Output) ([]types.Output, string) {
+			for i := range outputs {
+				if outputs[i].Slug == slug {
+					outputs[i].Application = application
+				}
+			}
+			return outputs, message
+		},
+	}
+	return func() tea.Msg { return done }
+}
+
+func (m *BoardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		switch key := msg.String(); {
+		case keymap.Matches(msg, keymap.Back), keymap.Matches(msg, keymap.Close):
+			back := m.params.back
+			return m, func() tea.Msg { return back }
+		case keymap.Matches(msg, keymap.Left):
+			if m.column > 0 {
+				m.column--
+				m.row = 0
+			}
+		case keymap.Matches(msg, keymap.Right):
+			if m.column < len(types.ApplicationStatuses)-1 {
+				m.column++
+				m.row = 0
+			}
+		case keymap.Matches(msg, keymap.Up):
+			if m.row > 0 {
+				m.row--
+			}
+		case keymap.Matches(msg, keymap.Down):
+			if m.row < len(m.columnCards(m.column))-1 {
+				m.row++
+			}
+		case keymap.Matches(msg, keymap.MoveLeft):
+			if m.column > 0 {
+				return m, m.setStatus(types.ApplicationStatuses[m.column-1])
+			}
+		case keymap.Matches(msg, keymap.MoveRight):
+			if m.column < len(types.ApplicationStatuses)-1 {
+				return m, m.setStatus(types.ApplicationStatuses[m.column+1])
+			}
+		case len(key) == 1 && key >= "1" && key <= "7":
+			// Columns are picked by position, these keys are not remapped.
+			return m, m.setStatus(types.ApplicationStatuses[key[0]-'1'])
+		case keymap.Matches(msg, keymap.AddNote):
+			return m, m.promptNote()
+		case keymap.Matches(msg, keymap.Select):
+			if i := m.selected(); i >= 0 {
+				project := m.cards[i].project
+				return m, func() tea.Msg {
+					return types.TransitionMsg{To: types.StateProjectOverview, Params: project}
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+// Bindings returns the actions of the board. Statuses are also set with the
+// number of their column.
+func (m *BoardModel) Bindings() keymap.Bindings {
+	return keymap.Get(keymap.Left, keymap.Right, keymap.Down, keymap.Up, keymap.MoveLeft, keymap.MoveRight,
+		keymap.AddNote, keymap.Select, keymap.Back, keymap.Palette, keymap.Help).
+		Describe(keymap.Up, "card").
+		Describe(keymap.Down, "card").
+		Describe(keymap.AddNote, "add note").
+		Describe(keymap.Select, "open project")
+}
+
+func (m *BoardModel) View() string {
+	columns := len(types.ApplicationStatuses)
+	columnWidth := max((m.width-2)/columns-2, 12)
+	detailsHeight := 9
+	columnHeight := max(m.height-detailsHeight-6, 6)
+	// Each card takes two lines.
+	visible := max((columnHeight-2)/2, 1)
+	multiProject := len(m.params.projects) > 1
+
+	var rendered []string
+	for c, status := range types.ApplicationStatuses {
+		cards := m.columnCards(c)
+		header := applicationStyle(status).Bold(true).Render(fmt.Sprintf("%s (%d)", status.Title(), len(cards)))
+		lines := []string{header}
+
+		start := 0
+		if c == m.column && m.row >= visible {
+			start = m.row - visible + 1
+		}
+		for pos := start; pos < len(cards) && pos < start+visible; pos++ {
+			card := m.cards[cards[pos]]
+			name := truncate(card.output.Name, columnWidth-4)
+			sub := card.output.Job.Company
+			if multiProject {
+				sub = card.project.Name
+			}
+			if c == m.column && pos == m.row {
+				name = ui.SelectedItem.Render("► " + name)
+			} else {
+				name = "  " + name
+			}
+			lines = append(lines, name, ui.StatusQueued.Render("  "+truncate(sub, columnWidth-4)))
+		}
+		if len(cards) > start+visible {
+			lines = append(lines, ui.StatusQueued.Render(fmt.Sprintf("  +%d more", len(cards)-start-visible)))
+		}
+
+		style := ui.BoardColumn
+		if c == m.column {
+			style = ui.BoardColumnActive
+		}
+		rendered = append(rendered, style.Width(columnWidth).Height(columnHeight).Render(strings.Join(lines, "\n")))
+	}
+	board := lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+
+	title := "Application Board"
+	if len(m.params.projects) == 1 {
+		title += ": " + m.params.projects[0].Name
+	}
+	details := ui.BaseDetails.Width(max(m.width-4, 40)).Height(detailsHeight).Render(m.renderDetails())
+	help := keymap.HelpLine(m.Bindings()) + " • 1-7: set status"
+	if m.skipped > 0 {
+		help = fmt.Sprintf("%d project(s) could not be loaded • ", m.skipped) + help
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, ui.Title.Render(title), board, details, ui.Help.Render(help))
+}
+
+// renderDetails shows the selected card with its latest events.
+func (m *BoardModel) renderDetails() string {
+	i := m.selected()
+	if i < 0 {
+		if len(m.cards) == 0 {
+			return "No outputs yet. Add outputs in a project to track their applications."
+		}
+		return "No applications " + string(types.ApplicationStatuses[m.column]) + "."
+	}
+	card := m.cards[i]
+	out := card.output
+	content := ui.SelectedItem.Render(out.Name) + ui.StatusQueued.Render("  "+card.project.Name) + "\n"
+	if role := jobSummary(out.Job); role != "" {
+		content += role + "\n"
+	}
+	content += renderApplication(out.Application, 5)
+	return content
+}
+
+// jobSummary describes the role of a job in one line, such as
+// "Backend Engineer at Acme, Berlin".
+func jobSummary(job types.JobMetadata) string {
+	summary := job.Title
+	if job.Company != "" {
+		if summary != "" {
+			summary += " at "
+		}
+		summary += job.Company
+	}
+	if job.Location != "" && summary != "" {
+		summary += ", " + job.Location
+	}
+	return summary
+}
+
+// renderApplication shows the status of an application and its latest
+// events, newest first.
+func renderApplication(a types.Application, events int) string {
+	status := a.CurrentStatus()
+	line := "Status: " + applicationStyle(status).Render(status.Title())
+	if since := a.Since(); !since.IsZero() {
+		line += " since " + since.Format("2006-01-02") + " (" + relativeDays(since) + ")"
+	}
+	lines := []string{line}
+	for i := len(a.Events) - 1; i >= 0 && len(lines) <= events; i-- {
+		e := a.Events[i]
+		text := e.At.Format("2006-01-02")
+		if e.Status != "" {
+			text += " → " + string(e.Status)
+		}
+		if e.Note != "" {
+			text += ": " + e.Note
+		}
+		lines = append(lines, ui.StatusQueued.Render("  "+text))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// applicationStyle colours a status by how the application is going.
+func applicationStyle(status types.ApplicationStatus) lipgloss.Style {
+	switch status {
+	case types.StatusOffer:
+		return ui.StatusDone
+	case types.StatusRejected, types.StatusWithdrawn:
+		return ui.StatusFailed
+	case types.StatusDrafted:
+		return ui.StatusQueued
+	}
+	return ui.StatusRunning
+}
@@ -0,0 +1,398 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/analysis"
+	"github.com/FabricSoul/auto-resume/internal/generator"
+	"github.com/FabricSoul/auto-resume/internal/keymap"
+	"github.com/FabricSoul/auto-resume/internal/latex"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/FabricSoul/auto-resume/internal/ui"
+	"github.com/FabricSoul/auto-resume/pkg/diff"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// compareParams are passed with the transition to StateCompare.
+type compareParams struct {
+	project types.Project
+	output  types.Output
+	resume  string
+}
+
+// compareResultMsg delivers the response of one model to the comparison
+// that asked for it.
+type compareResultMsg struct {
+	compare  *CompareModel
+	run      int
+	index    int
+	response string
+	latency  time.Duration
+	err      error
+}
+
+type compareResult struct {
+	model    types.AIModel
+	running  bool
+	response string
+	err      error
+	latency  time.Duration
+	score    analysis.Score
+	diff     []diff.Line
+	inserted int
+	deleted  int
+}
+
+// CompareModel sends the same resume and job description to several models
+// and shows their results side by side.
+type CompareModel struct {
+	width, height int
+
+	project  types.Project
+	output   types.Output
+	resume   string
+	keywords []string
+	baseline analysis.Score
+
+	models  []types.AIModel
+	chosen  []bool
+	cursor  int
+	started bool
+	// run numbers the comparison runs, so results of an abandoned run are
+	// ignored.
+	run int
+
+	results  []compareResult
+	selected int
+	showDiff bool
+	scroll   int
+}
+
+func NewCompareModel(pm *types.ProjectManager, params compareParams) *CompareModel {
+	keywords := analysis.Keywords(params.output.JobDescription)
+	models := pm.GetModels()
+	return &CompareModel{
+		project:  params.project,
+		output:   params.output,
+		resume:   params.resume,
+		keywords: keywords,
+		baseline: analysis.KeywordScore(params.resume, keywords),
+		models:   models,
+		chosen:   make([]bool, len(models)),
+		showDiff: true,
+	}
+}
+
+func (m *CompareModel) Init() tea.Cmd {
+	return nil
+}
+
+// start sends the job to every chosen model in parallel.
+func (m *CompareModel) start() tea.Cmd {
+	m.run++
+	m.results = nil
+	m.selected, m.scroll = 0, 0
+
+	var cmds []tea.Cmd
+	for i, model := range m.models {
+		if !m.chosen[i] {
+			continue
+		}
+		index := len(m.results)
+		m.results = append(m.results, compareResult{model: model, running: true})
+
+		run, resume, out := m.run, m.resume, m.output
+		cmds = append(cmds, func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), generator.Timeout)
+			defer cancel()
+			start := time.Now()
+			response, err := generator.GenerateResume(ctx, model, resume, out.JobDescription, out.Job)
+			return compareResultMsg{compare: m, run: run, index: index, response: response, latency: time.Since(start), err: err}
+		})
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+	m.started = true
+	return tea.Batch(cmds...)
+}
+
+// handleResult stores the response of one model with its metrics.
+func (m *CompareModel) handleResult(msg compareResultMsg) {
+	if msg.compare != m || msg.run != m.run || msg.index >= len(m.results) {
+		return
+	}
+	r := &m.results[msg.index]
+	r.running = false
+	r.latency = msg.latency
+	r.err = msg.err
+	if msg.err != nil {
+		return
+	}
+	r.response = msg.response
+	r.score = analysis.KeywordScore(msg.response, m.keywords)
+	r.diff = diff.Lines(m.resume, msg.response)
+	r.inserted, r.deleted = diff.Stats(r.diff)
+}
+
+func (m *CompareModel) back() tea.Cmd {
+	project := m.project
+	return func() tea.Msg {
+		return types.TransitionMsg{To: types.StateProjectOverview, Params: project}
+	}
+}
+
+// promote makes the selected result the generated resume of the compared
+// output, or adds it as a new output when the output was never saved.
+func (m *CompareModel) promote() tea.Cmd {
+	if m.selected >= len(m.results) {
+		return nil
+	}
+	r := m.results[m.selected]
+	if r.running || r.err != nil {
+		return nil
+	}
+
+	source := m.output
+	response := r.response
+	fingerprint := latex.NewFingerprint(m.resume)
+	done := generationDoneMsg{
+		projectDir: m.project.Path,
+		label:      fmt.Sprintf("%s: %s", m.project.Name, source.Name),
+		apply: func(outputs []types.Output) ([]types.Output, string) {
+			message := fmt.Sprintf("Promote %s output for %s from comparison", r.model.Name, source.Name)
+			for i := range outputs {
+				if source.Slug != "" && outputs[i].Slug == source.Slug {
+					outputs[i].GeneratedOutput = response
+					outputs[i].GeneratedAt = time.Now()
+					outputs[i].ResumeFingerprint = fingerprint
+					return outputs, message
+				}
+			}
+			promoted := source
+			promoted.Slug = ""
+			promoted.GeneratedOutput = response
+			promoted.GeneratedAt = time.Now()
+			promoted.ResumeFingerprint = fingerprint
+			return append(outputs, promoted), message
+		},
+	}
+	return tea.Sequence(func() tea.Msg { return done }, m.back())
+}
+
+func (m *CompareModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case compareResultMsg:
+		m.handleResult(msg)
+
+	case tea.KeyMsg:
+		if !m.started {
+			return m, m.updateSelection(msg)
+		}
+		switch {
+		case keymap.Matches(msg, keymap.Back), keymap.Matches(msg, keymap.Close):
+			return m, m.back()
+		case keymap.Matches(msg, keymap.Up):
+			if m.selected > 0 {
+				m.selected--
+				m.scroll = 0
+			}
+		case keymap.Matches(msg, keymap.Down):
+			if m.selected < len(m.results)-1 {
+				m.selected++
+				m.scroll = 0
+			}
+		case keymap.Matches(msg, keymap.ToggleDiff):
+			m.showDiff = !m.showDiff
+			m.scroll = 0
+		case keymap.Matches(msg, keymap.PageDown):
+			m.scroll += max(m.height/2, 1)
+		case keymap.Matches(msg, keymap.PageUp):
+			m.scroll = max(m.scroll-max(m.height/2, 1), 0)
+		case keymap.Matches(msg, keymap.ChooseModels):
+			m.started = false
+		case keymap.Matches(msg, keymap.Promote):
+			return m, m.promote()
+		}
+	}
+	return m, nil
+}
+
+// updateSelection handles keys while models are being chosen.
+func (m *CompareModel) updateSelection(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case keymap.Matches(msg, keymap.Back), keymap.Matches(msg, keymap.Close):
+		return m.back()
+	case keymap.Matches(msg, keymap.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case keymap.Matches(msg, keymap.Down):
+		if m.cursor < len(m.models)-1 {
+			m.cursor++
+		}
+	case keymap.Matches(msg, keymap.Toggle):
+		if m.cursor < len(m.chosen) {
+			m.chosen[m.cursor] = !m.chosen[m.cursor]
+		}
+	case keymap.Matches(msg, keymap.ToggleAll):
+		all := true
+		for _, c := range m.chosen {
+			all = all && c
+		}
+		for i := range m.chosen {
+			m.chosen[i] = !all
+		}
+	case keymap.Matches(msg, keymap.Select):
+		return m.start()
+	}
+	return nil
+}
+
+// Bindings returns the actions of choosing models or of the results.
+func (m *CompareModel) Bindings() keymap.Bindings {
+	if !m.started {
+		return keymap.Get(keymap.Down, keymap.Up, keymap.Toggle, keymap.ToggleAll, keymap.Select, keymap.Back, keymap.Palette, keymap.Help).
+			Describe(keymap.Select, "compare")
+	}
+	return keymap.Get(keymap.Down, keymap.Up, keymap.ToggleDiff, keymap.PageDown, keymap.PageUp,
+		keymap.Promote, keymap.ChooseModels, keymap.Back, keymap.Palette, keymap.Help).
+		Describe(keymap.Up, "select").
+		Describe(keymap.Down, "select")
+}
+
+func (m *CompareModel) View() string {
+	if !m.started {
+		return m.renderSelection()
+	}
+
+	listWidth := max(m.width*2/5, 40)
+	detailsWidth := max(m.width-listWidth-8, 30)
+	height := max(m.height-6, 10)
+
+	list := ui.Title.Render("Compare: "+m.output.Name) + "\n"
+	list += fmt.Sprintf("Keywords in base resume: %d%%\n\n", m.baseline.Percent())
+	list += fmt.Sprintf("  %-16s %8s %8s %6s %9s\n", "Model", "Time", "Est. tok", "Score", "+/-")
+	for i, r := range m.results {
+		var row string
+		switch {
+		case r.running:
+			row = fmt.Sprintf("%-16s %s", truncate(r.model.Name, 16), ui.StatusRunning.Render("running"))
+		case r.err != nil:
+			row = fmt.Sprintf("%-16s %s", truncate(r.model.Name, 16), ui.StatusFailed.Render("failed"))
+		default:
+			row = fmt.Sprintf("%-16s %8s %8d %5d%% %9s",
+				truncate(r.model.Name, 16), r.latency.Round(100*time.Millisecond),
+				analysis.EstimateTokens(r.response), r.score.Percent(),
+				fmt.Sprintf("+%d/-%d", r.inserted, r.deleted))
+		}
+		if i == m.selected {
+			row = ui.SelectedItem.Render("► " + row)
+		} else {
+			row = "  " + row
+		}
+		list += row + "\n"
+	}
+	list += "\n" + ui.Help.Render("Token counts are estimates from the text length, not the provider's.")
+
+	left := ui.BaseList.Width(listWidth).Height(height).Render(list)
+	right := ui.BaseDetails.Width(detailsWidth).Height(height).Render(m.renderDetails(detailsWidth-4, height-4))
+	help := ui.Help.Render(keymap.HelpLine(m.Bindings()))
+	return lipgloss.JoinVertical(lipgloss.Left, lipgloss.JoinHorizontal(lipgloss.Top, left, right), help)
+}
+
+func (m *CompareModel) renderDetails(width, height int) string {
+	if m.selected >= len(m.results) {
+		return ""
+	}
+	r := m.results[m.selected]
+	header := ui.Title.Render(fmt.Sprintf("%s (%s/%s)", r.model.Name, r.model.Provider, r.model.Model)) + "\n"
+	switch {
+	case r.running:
+		return header + "Waiting for response..."
+	case r.err != nil:
+		return header + ui.ErrorTitle.Render(r.err.Error())
+	}
+
+	tokensIn := analysis.EstimateTokens(m.resume) + analysis.EstimateTokens(m.output.JobDescription)
+	header += fmt.Sprintf("Latency %s • estimated %d tokens in, %d out\n", r.latency.Round(time.Millisecond), tokensIn, analysis.EstimateTokens(r.response))
+	if len(r.score.Missing) > 0 {
+		header += truncate("Missing: "+strings.Join(r.score.Missing, ", "), width) + "\n"
+	}
+	header += "\n"
+
+	var lines []string
+	if m.showDiff {
+		for _, l := range r.diff {
+			text := truncate(l.String(), width)
+			switch l.Op {
+			case diff.Insert:
+				text = ui.StatusDone.Render(text)
+			case diff.Delete:
+				text = ui.StatusFailed.Render(text)
+			}
+			lines = append(lines, text)
+		}
+	} else {
+		for _, l := range strings.Split(r.response, "\n") {
+			lines = append(lines, truncate(l, width))
+		}
+	}
+
+	visible := max(height-strings.Count(header, "\n")-1, 1)
+	m.scroll = min(m.scroll, max(len(lines)-visible, 0))
+	end := min(m.scroll+visible, len(lines))
+	return header + strings.Join(lines[m.scroll:end], "\n")
+}
+
+func (m *CompareModel) renderSelection() string {
+	content := ui.Title.Render("Generate with several models") + "\n"
+	content += "Output: " + m.output.Name + "\n\n"
+	if len(m.models) == 0 {
+		content += "No models configured, add them with M on the start screen.\n"
+	}
+	for i, model := range m.models {
+		box := "[ ]"
+		if m.chosen[i] {
+			box = "[x]"
+		}
+		item := fmt.Sprintf("%s %s (%s/%s)", box, model.Name, model.Provider, model.Model)
+		if i == m.cursor {
+			item = ui.SelectedItem.Render("► " + item)
+		} else {
+			item = "  " + item
+		}
+		content += item + "\n"
+	}
+	content += ui.Help.Render(keymap.HelpLine(m.Bindings()))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, ui.FloatBox.Width(70).Render(content))
+}
+
+// compareModels opens the comparison screen for the selected output.
+func (m *ProjectDetailModel) compareModels() tea.Cmd {
+	if len(m.outputs) == 0 {
+		return nil
+	}
+	output := m.outputs[m.selectedOutputIndex]
+	if strings.TrimSpace(output.JobDescription) == "" {
+		return func() tea.Msg {
+			return types.ErrorMsg{Error: fmt.Errorf("output '%s' has no job description", output.Name)}
+		}
+	}
+	project := types.Project{Name: m.overviewProjectName, Path: m.projectDir}
+	if p, err := m.projects.GetProject(m.overviewProjectName); err == nil {
+		project = p
+	}
+	params := compareParams{project: project, output: output, resume: m.resumeInput}
+	return func() tea.Msg {
+		return types.TransitionMsg{To: types.StateCompare, Params: params}
+	}
+}
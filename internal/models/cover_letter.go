@@ -0,0 +1,81 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/FabricSoul/auto-resume/internal/coverletter"
+	"github.com/FabricSoul/auto-resume/internal/generator"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// coverLetterAction handles enter on the cover letter tab of the job section.
+func (m *ProjectDetailModel) coverLetterAction() tea.Cmd {
+	current := m.outputs[m.selectedOutputIndex]
+	switch m.jobField {
+	case JobFieldOutput:
+		m.outputViewer.SetValue(current.CoverLetter)
+		m.outputViewerTitle = "Cover Letter"
+		m.showOutputViewer = true
+	case JobFieldGenerate:
+		return m.generateCoverLetter(current)
+	case JobFieldExport:
+		return m.compileCoverLetter(current)
+	}
+	return nil
+}
+
+// generateCoverLetter queues a background job writing the cover letter of
+// out from its tailored resume and job description.
+func (m *ProjectDetailModel) generateCoverLetter(out types.Output) tea.Cmd {
+	if len(m.llmOptions) == 0 || m.selectedLLMIndex >= len(m.llmOptions) {
+		return func() tea.Msg {
+			return types.ErrorMsg{Error: errors.New("no LLM model selected")}
+		}
+	}
+	if out.Slug == "" {
+		return func() tea.Msg {
+			return types.ErrorMsg{Error: fmt.Errorf("save output '%s' before writing its cover letter", out.Name)}
+		}
+	}
+	prompt, err := coverletter.Prompt(m.projectDir, out)
+	if err != nil {
+		return func() tea.Msg { return types.ErrorMsg{Error: err} }
+	}
+	model := m.llmOptions[m.selectedLLMIndex]
+
+	return startGeneration(generationJob{
+		projectDir: m.projectDir,
+		label:      fmt.Sprintf("%s: cover letter for %s", m.overviewProjectName, out.Name),
+		run: func(ctx context.Context) (func([]types.Output) ([]types.Output, string), error) {
+			letter, err := generator.Complete(ctx, model, prompt)
+			if err != nil {
+				return nil, err
+			}
+			return func(outputs []types.Output) ([]types.Output, string) {
+				for i := range outputs {
+					if outputs[i].Slug == out.Slug {
+						outputs[i].CoverLetter = letter
+					}
+				}
+				return outputs, fmt.Sprintf("Generate cover letter for %s with %s", out.Name, model.Name)
+			}, nil
+		},
+	})
+}
+
+// compileCoverLetter renders the cover letter of out with the LaTeX letter
+// template and compiles it next to the resume PDF.
+func (m *ProjectDetailModel) compileCoverLetter(out types.Output) tea.Cmd {
+	projectDir := m.projectDir
+	cfg := types.ProjectConfig{Engine: m.engine}
+	return func() tea.Msg {
+		path, err := coverletter.Compile(context.Background(), projectDir, cfg, out)
+		if err != nil {
+			return types.ErrorMsg{Error: fmt.Errorf("failed to save cover letter PDF: %w", err)}
+		}
+		return exportDoneMsg{path: path, projectDir: projectDir, slug: out.Slug, name: out.Name}
+	}
+}
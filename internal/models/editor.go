@@ -0,0 +1,73 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/FabricSoul/auto-resume/internal/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// editorCommand returns the user's preferred editor from $VISUAL or $EDITOR,
+// split into program and arguments, falling back to vi.
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"vi"}
+}
+
+// openInEditor suspends the program and opens content in the user's editor
+// through a temporary file with the given extension, so the editor picks the
+// right syntax. When the editor exits the edited text is handed to callback
+// through an EditorFinishedMsg.
+func openInEditor(content, ext string, callback func(string)) tea.Cmd {
+	f, err := os.CreateTemp("", "auto-resume-*"+ext)
+	if err != nil {
+		return func() tea.Msg {
+			return types.ErrorMsg{Error: fmt.Errorf("failed to create temp file: %w", err)}
+		}
+	}
+	path := f.Name()
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		os.Remove(path)
+		return func() tea.Msg {
+			return types.ErrorMsg{Error: fmt.Errorf("failed to write temp file: %w", err)}
+		}
+	}
+	f.Close()
+
+	editor := editorCommand()
+	if _, err := exec.LookPath(editor[0]); err != nil {
+		os.Remove(path)
+		return func() tea.Msg {
+			return types.ErrorMsg{Error: fmt.Errorf("editor %q not found, set $VISUAL or $EDITOR", editor[0])}
+		}
+	}
+
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				return types.ErrorMsg{Error: fmt.Errorf("editor exited with status %d, changes discarded", exitErr.ExitCode())}
+			}
+			return types.ErrorMsg{Error: fmt.Errorf("failed to run editor: %w", err)}
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return types.ErrorMsg{Error: fmt.Errorf("failed to read edited file: %w", err)}
+		}
+		return types.EditorFinishedMsg{
+			Value:    string(data),
+			Callback: callback,
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package models
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/FabricSoul/auto-resume/internal/export"
+	"github.com/FabricSoul/auto-resume/internal/generator"
+	"github.com/FabricSoul/auto-resume/internal/jsonresume"
+	"github.com/FabricSoul/auto-resume/internal/keymap"
+	"github.com/FabricSoul/auto-resume/internal/latex"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/FabricSoul/auto-resume/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// exportChoice is an entry of the export menu.
+type exportChoice struct {
+	label      string
+	format     export.Format // empty for PDF and JSON Resume
+	pandoc     bool
+	jsonResume bool
+}
+
+// exportDoneMsg reports where an export was written.
+type exportDoneMsg struct {
+	path string
+	// projectDir, slug and name identify the output that was exported.
+	projectDir string
+	slug       string
+	name       string
+	// preview opens the compiled PDF in the preview screen, pages is its
+	// page count.
+	preview bool
+	pages   int
+	// fitted is set when the output was shortened to the page limit
+	// already, so it is not shortened again.
+	fitted bool
+}
+
+// exportChoices lists PDF, the text formats and JSON Resume. The text
+// formats are offered converted with pandoc as well when it is installed.
+func exportChoices() []exportChoice {
+	choices := []exportChoice{{label: "PDF"}}
+	for _, f := range export.Formats {
+		choices = append(choices, exportChoice{label: f.Title(), format: f})
+	}
+	choices = append(choices, exportChoice{label: "JSON Resume", jsonResume: true})
+	if export.HasPandoc() {
+		for _, f := range export.Formats {
+			choices = append(choices, exportChoice{label: f.Title() + " (pandoc)", format: f, pandoc: true})
+		}
+	}
+	return choices
+}
+
+// openExportMenu shows the export formats for the selected output.
+func (m *ProjectDetailModel) openExportMenu() {
+	if len(m.outputs) == 0 {
+		return
+	}
+	m.exportChoices = exportChoices()
+	m.exportCursor = 0
+	m.showExportMenu = true
+}
+
+// updateExportMenu handles keys while the export menu is shown.
+func (m *ProjectDetailModel) updateExportMenu(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case keymap.Matches(msg, keymap.Back):
+		m.showExportMenu = false
+	case keymap.Matches(msg, keymap.Down):
+		if m.exportCursor < len(m.exportChoices)-1 {
+			m.exportCursor++
+		}
+	case keymap.Matches(msg, keymap.Up):
+		if m.exportCursor > 0 {
+			m.exportCursor--
+		}
+	case keymap.Matches(msg, keymap.Select):
+		m.showExportMenu = false
+		return m.exportCurrentOutput(m.exportChoices[m.exportCursor])
+	}
+	return nil
+}
+
+// exportCurrentOutput writes the selected output in the chosen format.
+func (m *ProjectDetailModel) exportCurrentOutput(choice exportChoice) tea.Cmd {
+	current := m.outputs[m.selectedOutputIndex]
+	projectDir := m.projectDir
+	cfg := types.ProjectConfig{Engine: m.engine}
+	if choice.format == "" && !choice.jsonResume {
+		return previewOutput(projectDir, cfg, current, false)
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), latex.Timeout)
+		defer cancel()
+		var path string
+		var err error
+		if choice.jsonResume {
+			path, err = jsonresume.ExportOutput(projectDir, current)
+		} else {
+			path, err = export.ExportOutput(ctx, projectDir, current, choice.format, choice.pandoc)
+		}
+		if err != nil {
+			return types.ErrorMsg{Error: fmt.Errorf("failed to export %s: %w", choice.label, err)}
+		}
+		return exportDoneMsg{path: path, projectDir: projectDir, slug: current.Slug, name: current.Name}
+	}
+}
+
+// previewOutput compiles out to its PDF for the preview screen. fitted is
+// set for outputs just shortened to the page limit.
+func previewOutput(projectDir string, cfg types.ProjectConfig, out types.Output, fitted bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), latex.Timeout)
+		defer cancel()
+		path, pages, err := generator.CompiledPages(ctx, projectDir, cfg, out)
+		if err != nil {
+			return types.ErrorMsg{Error: fmt.Errorf("failed to compile %s: %w", out.Name, err)}
+		}
+		return exportDoneMsg{path: path, projectDir: projectDir, slug: out.Slug, name: out.Name,
+			preview: true, pages: pages, fitted: fitted}
+	}
+}
+
+// openPreview shows the pages of the PDF compiled from the output with slug.
+func (m *ProjectDetailModel) openPreview(path, slug string) tea.Cmd {
+	project := types.Project{Name: m.overviewProjectName, Path: m.projectDir}
+	if p, err := m.projects.GetProject(m.overviewProjectName); err == nil {
+		project = p
+	}
+	params := previewParams{
+		pdfPath:   path,
+		pageLimit: m.pageLimit,
+		back:      types.TransitionMsg{To: types.StateProjectOverview, Params: project},
+	}
+	if i := m.outputIndex(slug); i >= 0 {
+		current := m.outputs[i]
+		params.title = current.Name
+		params.source = current.GeneratedOutput
+	}
+	return func() tea.Msg {
+		return types.TransitionMsg{To: types.StatePreview, Params: params}
+	}
+}
+
+func (m *ProjectDetailModel) renderExportMenu() string {
+	content := ui.Title.Render("Export: "+m.outputs[m.selectedOutputIndex].Name) + "\n\n"
+	for i, choice := range m.exportChoices {
+		if i == m.exportCursor {
+			content += ui.SelectedItem.Render("► "+choice.label) + "\n"
+		} else {
+			content += "  " + choice.label + "\n"
+		}
+	}
+	content += ui.Help.Render(keymap.HelpLine(menuBindings("export")))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, ui.FloatBox.Render(content))
+}
@@ -1,25 +1,43 @@
 package models
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/FabricSoul/auto-resume/internal/types"
 	"github.com/FabricSoul/auto-resume/internal/ui"
+	"github.com/FabricSoul/auto-resume/pkg/textedit"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// Visible size of the editing area in multi-line mode.
+const (
+	floatEditorMinWidth = 40
+	floatEditorLines    = 16
+)
+
+// FloatInputModel is the floating text input shown for ShowFloatInputMsg.
+// In single-line mode enter confirms; in multi-line mode enter inserts a
+// newline and ctrl+s confirms.
 type FloatInputModel struct {
-	value    string
+	buffer   *textedit.Buffer
 	prompt   string
 	width    int
 	height   int
 	callback func(string)
+	submit   func(string) tea.Cmd
+
+	// First visible line and column, kept so the cursor stays in view.
+	top, left int
 }
 
-func NewFloatInputModel(prompt string, initialValue string, callback func(string)) *FloatInputModel {
+func NewFloatInputModel(prompt string, initialValue string, multiline bool, callback func(string), submit func(string) tea.Cmd) *FloatInputModel {
 	return &FloatInputModel{
-		value:    initialValue,
+		buffer:   textedit.New(initialValue, multiline),
 		prompt:   prompt,
 		callback: callback,
+		submit:   submit,
 	}
 }
 
@@ -34,40 +52,192 @@ func (m *FloatInputModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 
 	case tea.KeyMsg:
+		b := m.buffer
 		switch msg.Type {
 		case tea.KeyEsc:
 			return m, func() tea.Msg {
 				return types.FloatDismissMsg{}
 			}
+		case tea.KeyCtrlS:
+			return m, m.confirm()
 		case tea.KeyEnter:
-			m.callback(m.value)
-			return m, func() tea.Msg {
-				return types.FloatDismissMsg{}
+			if !b.Multiline() || msg.Alt {
+				return m, m.confirm()
 			}
+			b.Insert("\n")
 		case tea.KeyBackspace:
-			if len(m.value) > 0 {
-				m.value = m.value[:len(m.value)-1]
+			if msg.Alt {
+				b.DeleteWordBackward()
+			} else {
+				b.Backspace()
+			}
+		case tea.KeyDelete, tea.KeyCtrlD:
+			b.Delete()
+		case tea.KeyCtrlW:
+			b.DeleteWordBackward()
+		case tea.KeyCtrlU:
+			b.DeleteToLineStart()
+		case tea.KeyCtrlK:
+			b.DeleteToLineEnd()
+		case tea.KeyCtrlZ:
+			b.Undo()
+		case tea.KeyCtrlY:
+			b.Redo()
+		case tea.KeyLeft, tea.KeyCtrlB:
+			if msg.Alt {
+				b.WordLeft()
+			} else {
+				b.Left()
+			}
+		case tea.KeyRight, tea.KeyCtrlF:
+			if msg.Alt {
+				b.WordRight()
+			} else {
+				b.Right()
+			}
+		case tea.KeyCtrlLeft:
+			b.WordLeft()
+		case tea.KeyCtrlRight:
+			b.WordRight()
+		case tea.KeyUp:
+			b.Up()
+		case tea.KeyDown:
+			b.Down()
+		case tea.KeyHome, tea.KeyCtrlA:
+			b.LineStart()
+		case tea.KeyEnd, tea.KeyCtrlE:
+			b.LineEnd()
+		case tea.KeyCtrlHome:
+			b.Top()
+		case tea.KeyCtrlEnd:
+			b.Bottom()
+		case tea.KeyTab:
+			if b.Multiline() {
+				b.Insert("\t")
 			}
-		default:
-			if msg.Type == tea.KeyRunes {
-				m.value += string(msg.Runes)
+		case tea.KeySpace:
+			b.Insert(" ")
+		case tea.KeyRunes:
+			// Alt+b/f/d follow the readline word bindings. Pasted text
+			// arrives as a single message and is inserted verbatim.
+			if msg.Alt && !msg.Paste && len(msg.Runes) == 1 {
+				switch msg.Runes[0] {
+				case 'b':
+					b.WordLeft()
+				case 'f':
+					b.WordRight()
+				case 'd':
+					b.DeleteWordForward()
+				}
+				break
 			}
+			b.Insert(string(msg.Runes))
 		}
 	}
 	return m, nil
 }
 
+// confirm hands the value to the caller and closes the input.
+func (m *FloatInputModel) confirm() tea.Cmd {
+	value := m.buffer.Value()
+	if m.callback != nil {
+		m.callback(value)
+	}
+	dismiss := func() tea.Msg {
+		return types.FloatDismissMsg{}
+	}
+	if m.submit != nil {
+		return tea.Batch(dismiss, m.submit(value))
+	}
+	return dismiss
+}
+
+// editorSize returns the number of columns and lines of text shown.
+func (m *FloatInputModel) editorSize() (int, int) {
+	if !m.buffer.Multiline() {
+		return ui.FloatBox.GetWidth() - 10, 1
+	}
+	width := m.width*3/4 - 10
+	if width < floatEditorMinWidth {
+		width = floatEditorMinWidth
+	}
+	lines := floatEditorLines
+	if m.height > 0 && m.height-16 < lines {
+		lines = max(m.height-16, 3)
+	}
+	return width, lines
+}
+
+// scroll adjusts the visible window so the cursor is inside it.
+func (m *FloatInputModel) scroll(width, lines int) {
+	row, col := m.buffer.Cursor()
+	if row < m.top {
+		m.top = row
+	}
+	if row >= m.top+lines {
+		m.top = row - lines + 1
+	}
+	if col < m.left {
+		m.left = col
+	}
+	if col >= m.left+width {
+		m.left = col - width + 1
+	}
+}
+
+func (m *FloatInputModel) renderText(width, lines int) string {
+	m.scroll(width, lines)
+	row, col := m.buffer.Cursor()
+	all := m.buffer.Lines()
+
+	var out []string
+	for i := m.top; i < m.top+lines && i < len(all); i++ {
+		line := all[i]
+		start := min(m.left, len(line))
+		end := min(m.left+width, len(line))
+		visible := string(line[start:end])
+		if i == row {
+			c := col - start
+			r := []rune(visible)
+			if c >= len(r) {
+				visible = string(r) + "█"
+			} else {
+				visible = string(r[:c]) + lipgloss.NewStyle().Reverse(true).Render(string(r[c])) + string(r[c+1:])
+			}
+		}
+		out = append(out, strings.ReplaceAll(visible, "\t", "    "))
+	}
+	for len(out) < lines {
+		out = append(out, "")
+	}
+	return strings.Join(out, "\n")
+}
+
 func (m *FloatInputModel) View() string {
+	width, lines := m.editorSize()
+
 	content := ui.Title.Render(m.prompt) + "\n\n"
-	content += ui.Input.Render(m.value + "█")
-	content += "\n\n" + ui.Help.Render("enter: confirm • esc: cancel")
+	content += ui.Input.Width(width + 4).Render(m.renderText(width, lines))
+
+	row, col := m.buffer.Cursor()
+	counter := fmt.Sprintf("%d chars", m.buffer.RuneCount())
+	help := "enter: confirm • esc: cancel • ctrl+z/y: undo/redo"
+	if m.buffer.Multiline() {
+		counter = fmt.Sprintf("%d chars • %d lines • Ln %d, Col %d", m.buffer.RuneCount(), m.buffer.LineCount(), row+1, col+1)
+		help = "ctrl+s: confirm • esc: cancel • ctrl+z/y: undo/redo • ctrl+w: delete word"
+	}
+	content += "\n" + ui.Help.Render(counter)
+	content += "\n" + ui.Help.Render(help)
 
-	box := ui.FloatBox.Render(content)
+	box := ui.FloatBox
+	if m.buffer.Multiline() {
+		box = box.Width(width + 10)
+	}
 	return lipgloss.Place(
 		m.width,
 		m.height,
 		lipgloss.Center,
 		lipgloss.Center,
-		box,
+		box.Render(content),
 	)
 }
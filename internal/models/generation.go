@@ -0,0 +1,192 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/generator"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/FabricSoul/auto-resume/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// generationTickInterval refreshes the elapsed time in the status bar.
+const generationTickInterval = time.Second
+
+// generationJob is a unit of work for the generation manager. run talks to
+// the LLM in the background and must not touch any model. The apply function
+// it returns adds the result to the project's outputs on the update loop and
+// returns the commit message describing the change.
+type generationJob struct {
+	projectDir string
+	label      string
+	run        func(ctx context.Context) (apply func([]types.Output) ([]types.Output, string), err error)
+	// after, when set, runs once the applied outputs are saved, such as to
+	// compile a changed output again.
+	after func([]types.Output) tea.Cmd
+}
+
+// startGenerationMsg asks MainModel to run a job in the background.
+type startGenerationMsg struct {
+	job generationJob
+}
+
+func startGeneration(job generationJob) tea.Cmd {
+	return func() tea.Msg {
+		return startGenerationMsg{job: job}
+	}
+}
+
+// generationDoneMsg delivers the result of a job.
+type generationDoneMsg struct {
+	id         int
+	projectDir string
+	label      string
+	apply      func([]types.Output) ([]types.Output, string)
+	after      func([]types.Output) tea.Cmd
+	err        error
+}
+
+type generationTickMsg struct{}
+
+type runningGeneration struct {
+	id         int
+	projectDir string
+	label      string
+	started    time.Time
+}
+
+// generationManager runs generation jobs independently of the screen that
+// started them.
+type generationManager struct {
+	nextID  int
+	running map[int]runningGeneration
+	ticking bool
+}
+
+func newGenerationManager() *generationManager {
+	return &generationManager{running: make(map[int]runningGeneration)}
+}
+
+// start runs job in the background and keeps the status indicator ticking.
+func (g *generationManager) start(job generationJob) tea.Cmd {
+	g.nextID++
+	id := g.nextID
+	g.running[id] = runningGeneration{id: id, projectDir: job.projectDir, label: job.label, started: time.Now()}
+
+	run := func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), generator.Timeout)
+		defer cancel()
+		apply, err := job.run(ctx)
+		return generationDoneMsg{id: id, projectDir: job.projectDir, label: job.label, apply: apply, after: job.after, err: err}
+	}
+	if !g.ticking {
+		g.ticking = true
+		return tea.Batch(run, g.tick())
+	}
+	return run
+}
+
+func (g *generationManager) tick() tea.Cmd {
+	return tea.Tick(generationTickInterval, func(time.Time) tea.Msg {
+		return generationTickMsg{}
+	})
+}
+
+// nextTick continues the tick chain while jobs are running.
+func (g *generationManager) nextTick() tea.Cmd {
+	if len(g.running) == 0 {
+		g.ticking = false
+		return nil
+	}
+	return g.tick()
+}
+
+// finish forgets the job of msg.
+func (g *generationManager) finish(msg generationDoneMsg) {
+	delete(g.running, msg.id)
+}
+
+// count returns the number of running jobs for projectDir.
+func (g *generationManager) count(projectDir string) int {
+	n := 0
+	for _, r := range g.running {
+		if r.projectDir == projectDir {
+			n++
+		}
+	}
+	return n
+}
+
+// status renders the global indicator of running jobs, or "" when idle.
+func (g *generationManager) status() string {
+	if len(g.running) == 0 {
+		return ""
+	}
+	jobs := make([]runningGeneration, 0, len(g.running))
+	for _, r := range g.running {
+		jobs = append(jobs, r)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].id < jobs[j].id })
+
+	parts := make([]string, 0, len(jobs))
+	for _, r := range jobs {
+		parts = append(parts, fmt.Sprintf("%s (%s)", r.label, time.Since(r.started).Round(time.Second)))
+	}
+	return ui.StatusRunning.Render(fmt.Sprintf("⟳ Generating %d: ", len(jobs))) + strings.Join(parts, " • ")
+}
+
+// applyGeneration stores the result of a job. When the project is open its
+// screen takes the result, so unsaved edits are kept; otherwise the project
+// is updated on disk.
+func (m *MainModel) applyGeneration(msg generationDoneMsg) tea.Cmd {
+	if m.projectModel != nil && m.projectModel.projectDir == msg.projectDir {
+		m.projectModel.pendingGenerations = m.generations.count(msg.projectDir)
+		return m.projectModel.applyGeneration(msg)
+	}
+	if msg.err != nil {
+		return func() tea.Msg {
+			return types.ErrorMsg{Error: fmt.Errorf("%s: %w", msg.label, msg.err)}
+		}
+	}
+
+	config, err := types.LoadProjectConfig(msg.projectDir)
+	if err != nil {
+		return func() tea.Msg { return types.ErrorMsg{Error: err} }
+	}
+	var message string
+	config.Outputs, message = msg.apply(config.Outputs)
+	if err := types.SaveProjectConfig(msg.projectDir, config); err != nil {
+		return func() tea.Msg {
+			return types.ErrorMsg{Error: fmt.Errorf("failed to save project config: %w", err)}
+		}
+	}
+	var cmds []tea.Cmd
+	if result := commitProject(msg.projectDir, message); result != nil {
+		cmds = append(cmds, func() tea.Msg { return result })
+	}
+	if msg.after != nil {
+		cmds = append(cmds, msg.after(config.Outputs))
+	}
+	return tea.Batch(cmds...)
+}
+
+// applyGeneration adds the result of a job to the outputs and saves them.
+func (m *ProjectDetailModel) applyGeneration(msg generationDoneMsg) tea.Cmd {
+	if msg.err != nil {
+		return func() tea.Msg {
+			return types.ErrorMsg{Error: fmt.Errorf("%s: %w", msg.label, msg.err)}
+		}
+	}
+	var message string
+	m.outputs, message = msg.apply(m.outputs)
+	m.resumeNotice = message
+	if msg.after != nil {
+		return tea.Sequence(m.saveWithMessage(message), msg.after(slices.Clone(m.outputs)))
+	}
+	return m.saveWithMessage(message)
+}
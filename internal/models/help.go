@@ -0,0 +1,95 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/FabricSoul/auto-resume/internal/keymap"
+	"github.com/FabricSoul/auto-resume/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// HelpModel lists every key of the active screen, grouped by category. It is
+// built from the bindings the screen offers, so it follows the focused
+// section and the user's keymap.
+type HelpModel struct {
+	lines  []string
+	offset int
+	width  int
+	height int
+}
+
+// NewHelpModel lays out bindings with the global actions added.
+func NewHelpModel(bindings keymap.Bindings, width, height int) *HelpModel {
+	all := append(bindings, keymap.Get(keymap.Quit, keymap.Palette, keymap.Help)...)
+
+	groups := make(map[string]keymap.Bindings)
+	seen := make(map[keymap.Action]bool)
+	keysWidth := 0
+	for _, b := range all {
+		if seen[b.Action] {
+			continue
+		}
+		seen[b.Action] = true
+		groups[b.Category] = append(groups[b.Category], b)
+		keysWidth = max(keysWidth, ansi.StringWidth(keymap.DisplayKeys(b)))
+	}
+
+	m := &HelpModel{width: width, height: height}
+	for _, category := range keymap.Categories {
+		if len(groups[category]) == 0 {
+			continue
+		}
+		if len(m.lines) > 0 {
+			m.lines = append(m.lines, "")
+		}
+		m.lines = append(m.lines, ui.SelectedItem.Render(category))
+		for _, b := range groups[category] {
+			keys := keymap.DisplayKeys(b)
+			pad := strings.Repeat(" ", keysWidth-ansi.StringWidth(keys))
+			m.lines = append(m.lines, "  "+keys+pad+"  "+b.Summary())
+		}
+	}
+	return m
+}
+
+// rows returns the number of lines shown at once.
+func (m *HelpModel) rows() int {
+	return max(m.height-14, 5)
+}
+
+// update handles a key press and reports whether the help is closed.
+func (m *HelpModel) update(msg tea.KeyMsg) bool {
+	switch {
+	case keymap.Matches(msg, keymap.Back), keymap.Matches(msg, keymap.Help), keymap.Matches(msg, keymap.Close):
+		return true
+	case keymap.Matches(msg, keymap.Down):
+		if m.offset < len(m.lines)-m.rows() {
+			m.offset++
+		}
+	case keymap.Matches(msg, keymap.Up):
+		if m.offset > 0 {
+			m.offset--
+		}
+	case keymap.Matches(msg, keymap.PageDown):
+		m.offset = max(min(m.offset+m.rows(), len(m.lines)-m.rows()), 0)
+	case keymap.Matches(msg, keymap.PageUp):
+		m.offset = max(m.offset-m.rows(), 0)
+	}
+	return false
+}
+
+func (m *HelpModel) View() string {
+	content := ui.Title.Render("Keys") + "\n"
+	end := min(m.offset+m.rows(), len(m.lines))
+	content += strings.Join(m.lines[m.offset:end], "\n") + "\n"
+
+	bindings := keymap.Get(keymap.Help).Describe(keymap.Help, "close")
+	if len(m.lines) > m.rows() {
+		bindings = append(keymap.Get(keymap.Down, keymap.Up).Describe(keymap.Down, "scroll").Describe(keymap.Up, "scroll"), bindings...)
+	}
+	content += ui.Help.Render(keymap.HelpLine(bindings))
+	box := ui.FloatBox.Width(min(max(m.width-4, 60), 90))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box.Render(content))
+}
@@ -0,0 +1,236 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/jobdesc"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/FabricSoul/auto-resume/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// outputSort selects the order in which outputs are listed. The order of
+// the outputs in project.toml is left alone.
+type outputSort int
+
+const (
+	OutputSortAdded outputSort = iota
+	OutputSortName
+	OutputSortCompany
+	OutputSortDeadline
+	OutputSortSalary
+	OutputSortSeniority
+	outputSortCount
+)
+
+func (s outputSort) String() string {
+	switch s {
+	case OutputSortName:
+		return "name"
+	case OutputSortCompany:
+		return "company"
+	case OutputSortDeadline:
+		return "deadline"
+	case OutputSortSalary:
+		return "salary"
+	case OutputSortSeniority:
+		return "seniority"
+	default:
+		return "added"
+	}
+}
+
+// outputOrder returns the indices of the outputs matching the status filter
+// in the current sort order. Outputs without the sorted field come last.
+func (m *ProjectDetailModel) outputOrder() []int {
+	var order []int
+	for i, out := range m.outputs {
+		if m.matchesFilter(out) {
+			order = append(order, i)
+		}
+	}
+	less := func(a, b types.Output) bool {
+		switch m.outputSort {
+		case OutputSortName:
+			return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+		case OutputSortCompany:
+			return lessMissingLast(strings.ToLower(a.Job.Company), strings.ToLower(b.Job.Company))
+		case OutputSortDeadline:
+			da, _ := a.Job.DeadlineTime()
+			db, _ := b.Job.DeadlineTime()
+			if da.IsZero() || db.IsZero() {
+				return !da.IsZero() && db.IsZero()
+			}
+			return da.Before(db)
+		case OutputSortSalary:
+			return a.Job.Salary.Annual() > b.Job.Salary.Annual()
+		case OutputSortSeniority:
+			return a.Job.SeniorityRank() > b.Job.SeniorityRank()
+		}
+		return false
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return less(m.outputs[order[i]], m.outputs[order[j]])
+	})
+	return order
+}
+
+// lessMissingLast orders strings alphabetically with empty ones last.
+func lessMissingLast(a, b string) bool {
+	if a == "" || b == "" {
+		return a != "" && b == ""
+	}
+	return a < b
+}
+
+// moveOutputSelection selects the output delta places away in the listed
+// order.
+func (m *ProjectDetailModel) moveOutputSelection(delta int) {
+	order := m.outputOrder()
+	for pos, i := range order {
+		if i == m.selectedOutputIndex {
+			if next := pos + delta; next >= 0 && next < len(order) {
+				m.selectedOutputIndex = order[next]
+			}
+			return
+		}
+	}
+	if len(order) > 0 {
+		m.selectedOutputIndex = order[0]
+	}
+}
+
+// setJobDescription replaces the job description of out and extracts its
+// metadata again, as details refined earlier no longer apply.
+func setJobDescription(out *types.Output, description string) {
+	if description == out.JobDescription && !out.Job.IsZero() {
+		return
+	}
+	out.JobDescription = description
+	out.Job = types.JobMetadata{}
+	if strings.TrimSpace(description) != "" {
+		out.Job = jobdesc.Extract(description)
+	}
+}
+
+// extractJobDetails reads the metadata of the selected output again with the
+// built-in rules.
+func (m *ProjectDetailModel) extractJobDetails() tea.Cmd {
+	current := &m.outputs[m.selectedOutputIndex]
+	if strings.TrimSpace(current.JobDescription) == "" {
+		return func() tea.Msg {
+			return types.ErrorMsg{Error: fmt.Errorf("output '%s' has no job description", current.Name)}
+		}
+	}
+	current.Job = jobdesc.Extract(current.JobDescription)
+	message := fmt.Sprintf("Extract job details for %s", current.Name)
+	return m.saveWithMessage(message)
+}
+
+// refineJobDetails asks the selected model to extract the metadata of the
+// selected output in the background.
+func (m *ProjectDetailModel) refineJobDetails() tea.Cmd {
+	if len(m.llmOptions) == 0 || m.selectedLLMIndex >= len(m.llmOptions) {
+		return func() tea.Msg {
+			return types.ErrorMsg{Error: errors.New("no LLM model selected")}
+		}
+	}
+	out := m.outputs[m.selectedOutputIndex]
+	if out.Slug == "" {
+		return func() tea.Msg {
+			return types.ErrorMsg{Error: fmt.Errorf("save output '%s' before extracting its details", out.Name)}
+		}
+	}
+	if strings.TrimSpace(out.JobDescription) == "" {
+		return func() tea.Msg {
+			return types.ErrorMsg{Error: fmt.Errorf("output '%s' has no job description", out.Name)}
+		}
+	}
+	model := m.llmOptions[m.selectedLLMIndex]
+
+	return startGeneration(generationJob{
+		projectDir: m.projectDir,
+		label:      fmt.Sprintf("%s: job details for %s", m.overviewProjectName, out.Name),
+		run: func(ctx context.Context) (func([]types.Output) ([]types.Output, string), error) {
+			job, err := jobdesc.Refine(ctx, model, out.JobDescription, jobdesc.Extract(out.JobDescription))
+			if err != nil {
+				return nil, err
+			}
+			return func(outputs []types.Output) ([]types.Output, string) {
+				for i := range outputs {
+					if outputs[i].Slug == out.Slug {
+						outputs[i].Job = job
+					}
+				}
+				return outputs, fmt.Sprintf("Extract job details for %s with %s", out.Name, model.Name)
+			}, nil
+		},
+	})
+}
+
+// renderJobDetails lists the extracted metadata of out, skipping unknown
+// fields.
+func renderJobDetails(out types.Output, width int) string {
+	job := out.Job
+	if job.IsZero() {
+		if strings.TrimSpace(out.JobDescription) == "" {
+			return ""
+		}
+		return ui.Help.Render("No job details found, x: extract • X: extract with LLM")
+	}
+
+	var lines []string
+	field := func(label, value string) {
+		if value != "" {
+			lines = append(lines, truncate(label+": "+value, width))
+		}
+	}
+	field("Company", job.Company)
+	field("Role", job.Title)
+	field("Location", job.Location)
+	field("Seniority", job.Seniority)
+	field("Salary", job.Salary.String())
+	if deadline, ok := job.DeadlineTime(); ok {
+		field("Deadline", job.Deadline.String()+" ("+relativeDays(deadline)+")")
+	}
+	field("Required", summarizeList(job.Required, 3))
+	field("Nice to have", summarizeList(job.NiceToHave, 3))
+
+	source := "extracted by rules"
+	if job.ExtractedBy != "" && job.ExtractedBy != "rules" {
+		source = "extracted by " + job.ExtractedBy
+	}
+	return ui.Title.Render("Job Details") + " " + ui.Help.Render(source) + "\n" + strings.Join(lines, "\n")
+}
+
+// summarizeList shows the first n items and how many more there are.
+func summarizeList(items []string, n int) string {
+	if len(items) <= n {
+		return strings.Join(items, "; ")
+	}
+	return fmt.Sprintf("%s (+%d more)", strings.Join(items[:n], "; "), len(items)-n)
+}
+
+// relativeDays describes t relative to today, such as "in 3 days".
+func relativeDays(t time.Time) string {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	switch days := int(day.Sub(today).Hours() / 24); {
+	case days == 0:
+		return "today"
+	case days == 1:
+		return "tomorrow"
+	case days == -1:
+		return "yesterday"
+	case days < 0:
+		return fmt.Sprintf("%d days ago", -days)
+	default:
+		return fmt.Sprintf("in %d days", days)
+	}
+}
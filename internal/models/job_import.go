@@ -0,0 +1,112 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/jobdesc"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/FabricSoul/auto-resume/pkg/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultOutputName is the name of outputs added with 'a'. Importing a job
+// description replaces it with the detected title and company.
+const defaultOutputName = "New Output"
+
+// canImportJob reports whether I and v import a job description in the
+// focused area: the outputs list adds a new output, the description field of
+// the resume tab replaces the current one.
+func (m *ProjectDetailModel) canImportJob() bool {
+	switch m.focusArea {
+	case FocusOutputs:
+		return true
+	case FocusJob:
+		return len(m.outputs) > 0 && m.jobTab == JobTabResume && m.jobField == JobFieldDescription
+	}
+	return false
+}
+
+// jobImportTimeout bounds reading a job posting, such as extracting the
+// text of a PDF.
+const jobImportTimeout = time.Minute
+
+// jobImportedMsg carries a posting read in the background. slug names the
+// output to store it in, empty for a new one.
+type jobImportedMsg struct {
+	posting jobdesc.Posting
+	slug    string
+	from    string
+	err     error
+}
+
+// importTarget returns the slug of the output an import replaces the job
+// description of, empty when it adds a new output.
+func (m *ProjectDetailModel) importTarget() string {
+	if m.focusArea == FocusOutputs || len(m.outputs) == 0 {
+		return ""
+	}
+	return m.outputs[m.selectedOutputIndex].Slug
+}
+
+// promptJobImport asks for a job posting file to import.
+func (m *ProjectDetailModel) promptJobImport() tea.Cmd {
+	slug := m.importTarget()
+	return func() tea.Msg {
+		return types.ShowFloatInputMsg{
+			Prompt: "Import job description from .txt, .md, .pdf or .html file",
+			Submit: func(value string) tea.Cmd {
+				path := config.ExpandHome(strings.TrimSpace(value))
+				return func() tea.Msg {
+					ctx, cancel := context.WithTimeout(context.Background(), jobImportTimeout)
+					defer cancel()
+					posting, err := jobdesc.Load(ctx, path)
+					if err != nil {
+						err = fmt.Errorf("failed to import job description: %w", err)
+					}
+					return jobImportedMsg{posting: posting, slug: slug, from: filepath.Base(path), err: err}
+				}
+			},
+		}
+	}
+}
+
+// pasteJob imports the job posting on the system clipboard.
+func (m *ProjectDetailModel) pasteJob() tea.Cmd {
+	slug := m.importTarget()
+	return func() tea.Msg {
+		posting, err := jobdesc.FromClipboard()
+		return jobImportedMsg{posting: posting, slug: slug, from: "clipboard", err: err}
+	}
+}
+
+// applyPosting stores the posting as the job description of the selected
+// output, or of a new one, naming untitled outputs after the detected title
+// and company.
+func (m *ProjectDetailModel) applyPosting(msg jobImportedMsg) tea.Cmd {
+	if msg.err != nil {
+		return func() tea.Msg { return types.ErrorMsg{Error: msg.err} }
+	}
+	posting := msg.posting
+	i := -1
+	if msg.slug != "" {
+		i = m.outputIndex(msg.slug)
+	}
+	if i < 0 {
+		m.outputs = append(m.outputs, types.Output{Name: defaultOutputName})
+		i = len(m.outputs) - 1
+	}
+	m.selectedOutputIndex = i
+	current := &m.outputs[i]
+	current.JobDescription = posting.Description
+	current.Job = posting.Job
+	if name := posting.SuggestName(); name != "" && (current.Name == "" || current.Name == defaultOutputName) {
+		current.Name = name
+	}
+
+	message := fmt.Sprintf("Import job description for %s from %s", current.Name, msg.from)
+	return m.saveWithMessage(message)
+}
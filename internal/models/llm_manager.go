@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/FabricSoul/auto-resume/internal/keymap"
 	"github.com/FabricSoul/auto-resume/internal/types"
 	"github.com/FabricSoul/auto-resume/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
@@ -46,11 +47,11 @@ func (m *LLMManagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		if m.editing {
-			switch msg.String() {
-			case "esc":
+			switch {
+			case keymap.Matches(msg, keymap.Back):
 				m.editing = false
 				return m, nil
-			case "i":
+			case keymap.Matches(msg, keymap.Input):
 				if m.editFieldIndex < 4 { // Don't show float input for submit button
 					var prompt, initialValue string
 					var callback func(string)
@@ -90,15 +91,15 @@ func (m *LLMManagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						}
 					}
 				}
-			case "j", "down":
+			case keymap.Matches(msg, keymap.Down):
 				if m.editFieldIndex < 4 {
 					m.editFieldIndex++
 				}
-			case "k", "up":
+			case keymap.Matches(msg, keymap.Up):
 				if m.editFieldIndex > 0 {
 					m.editFieldIndex--
 				}
-			case "enter":
+			case keymap.Matches(msg, keymap.Select):
 				if m.editFieldIndex == 4 { // Submit button
 					if m.tempModel.Name == "" {
 						return m, func() tea.Msg {
@@ -131,27 +132,27 @@ func (m *LLMManagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		} else {
 			// Non-editing mode key handling.
-			switch msg.String() {
-			case "q", "ctrl+c":
+			switch {
+			case keymap.Matches(msg, keymap.Close):
 				// Return to parent model (splash screen) instead of quitting.
 				return m, func() tea.Msg {
 					return types.TransitionMsg{To: types.StateSplash}
 				}
-			case "j", "down":
+			case keymap.Matches(msg, keymap.Down):
 				if m.selectedIndex < len(m.models)-1 {
 					m.selectedIndex++
 				}
-			case "k", "up":
+			case keymap.Matches(msg, keymap.Up):
 				if m.selectedIndex > 0 {
 					m.selectedIndex--
 				}
-			case "a":
+			case keymap.Matches(msg, keymap.Add):
 				// Enter creation mode.
 				m.editing = true
 				m.isNew = true
 				m.editFieldIndex = 0
 				m.tempModel = types.AIModel{}
-			case "e":
+			case keymap.Matches(msg, keymap.Edit):
 				// Enter edit mode for the selected model.
 				if len(m.models) > 0 {
 					m.editing = true
@@ -165,6 +166,17 @@ func (m *LLMManagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// Bindings returns the actions of the model list or the model form.
+func (m *LLMManagerModel) Bindings() keymap.Bindings {
+	if m.editing {
+		return keymap.Get(keymap.Down, keymap.Up, keymap.Input, keymap.Select, keymap.Back).
+			Describe(keymap.Select, "submit").
+			Describe(keymap.Back, "cancel")
+	}
+	return keymap.Get(keymap.Down, keymap.Up, keymap.Add, keymap.Edit, keymap.Close, keymap.Palette, keymap.Help).
+		Describe(keymap.Close, "back")
+}
+
 func (m *LLMManagerModel) renderModelsList() string {
 	if len(m.models) == 0 {
 		return "No models available"
@@ -216,7 +228,7 @@ func (m *LLMManagerModel) renderDetailsSection() string {
 		content += fmt.Sprintf("%s%s: %s\n", prefix, field.label, field.value)
 	}
 
-	return content + "\nPress enter to move between fields or submit, esc to cancel"
+	return content + "\nMove between fields and submit the last one, " + keymap.HelpLine(keymap.Get(keymap.Back)) + " cancels"
 }
 
 func (m *LLMManagerModel) View() string {
@@ -230,6 +242,6 @@ func (m *LLMManagerModel) View() string {
 	rightSection := ui.BaseDetails.Width(detailsWidth).Height(m.height - 4).Render(detailsContent)
 
 	content := lipgloss.JoinHorizontal(lipgloss.Top, leftSection, rightSection)
-	help := ui.Help.Render("a: add • e: edit • j/k: navigate • i: input • enter: submit • esc: cancel • q: back")
+	help := ui.Help.Render(keymap.HelpLine(m.Bindings()))
 	return ui.JoinedContainer.Render(lipgloss.JoinVertical(lipgloss.Left, content, help))
 }
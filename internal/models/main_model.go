@@ -2,9 +2,15 @@
 package models
 
 import (
+	"errors"
+	"fmt"
+
+	"github.com/FabricSoul/auto-resume/internal/keymap"
 	"github.com/FabricSoul/auto-resume/internal/types"
 	"github.com/FabricSoul/auto-resume/internal/ui"
+	"github.com/FabricSoul/auto-resume/pkg/config"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 type MainModel struct {
@@ -19,25 +25,64 @@ type MainModel struct {
 	errorModel        *ErrorModel
 	llmManagerModel   *LLMManagerModel
 	projectModel      *ProjectDetailModel
+	batchModels       map[string]*BatchModel // by project directory
+	generations       *generationManager
+	compareModel      *CompareModel
 	floatModel        tea.Model
 	showFloat         bool
-	isEditing         bool // Global editing state
+	palette           *PaletteModel
+	showPalette       bool
+	help              *HelpModel
+	showHelp          bool
+	startupErr        error // from reading the user config
+	isEditing         bool  // Global editing state
 	width             int
 	height            int
 }
 
 func NewMainModel(pm *types.ProjectManager) *MainModel {
 	return &MainModel{
-		projects:   pm,
-		errorModel: NewErrorModel(),
+		projects:    pm,
+		errorModel:  NewErrorModel(),
+		batchModels: make(map[string]*BatchModel),
+		generations: newGenerationManager(),
+		startupErr:  applyUserConfig(),
 	}
 }
 
+// applyUserConfig sets up the keymap and the theme from the user config.
+// The defaults stay in use for settings that cannot be applied.
+func applyUserConfig() error {
+	u, err := config.LoadUser()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	km, err := keymap.Load(u.Keymap, u.Keys)
+	if km != nil {
+		keymap.Set(km)
+	}
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid keymap in %s: %w", config.UserFile, err))
+	}
+	theme, err := ui.FindTheme(themesDir(), u.Theme)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid theme in %s: %w", config.UserFile, err))
+	} else {
+		ui.Apply(theme)
+	}
+	return errors.Join(errs...)
+}
+
 func (m *MainModel) Init() tea.Cmd {
 	if m.activeModel == nil {
 		m.activeModel = NewSplashModel(m.projects)
 		m.State = types.StateSplash
 	}
+	if err := m.startupErr; err != nil {
+		m.startupErr = nil
+		return tea.Batch(m.activeModel.Init(), func() tea.Msg { return types.ErrorMsg{Error: err} })
+	}
 	return m.activeModel.Init()
 }
 
@@ -45,13 +90,66 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Handle error messages first
 	if errMsg, ok := msg.(types.ErrorMsg); ok {
 		m.errorModel.SetError(errMsg.Error)
-		// Reset generating state in project model if it exists
-		if m.projectModel != nil {
-			m.projectModel.isGenerating = false
+		return m, nil
+	}
+
+	// Resume polling must keep running behind overlays and other screens.
+	if watchMsg, ok := msg.(resumeWatchMsg); ok {
+		if m.projectModel == nil {
+			return m, nil
+		}
+		return m, m.projectModel.checkResumeSource(watchMsg)
+	}
+
+	// Generations run in the background and finish whichever screen is
+	// shown, so they are handled before any overlay.
+	switch msg := msg.(type) {
+	case startGenerationMsg:
+		cmd := m.generations.start(msg.job)
+		if m.projectModel != nil && m.projectModel.projectDir == msg.job.projectDir {
+			m.projectModel.pendingGenerations = m.generations.count(msg.job.projectDir)
+		}
+		return m, cmd
+	case generationDoneMsg:
+		m.generations.finish(msg)
+		return m, m.applyGeneration(msg)
+	case generationTickMsg:
+		return m, m.generations.nextTick()
+	}
+
+	// Imported projects join the list whichever screen is shown, since the
+	// list is changed only on the update loop.
+	if extracted, ok := msg.(archiveExtractedMsg); ok {
+		if _, err := m.projects.AddExtracted(extracted.archive); err != nil {
+			return m, func() tea.Msg {
+				return types.ErrorMsg{Error: fmt.Errorf("failed to import project: %w", err)}
+			}
+		}
+		return m, nil
+	}
+
+	// Comparison results arrive even after leaving the screen.
+	if resultMsg, ok := msg.(compareResultMsg); ok {
+		if m.compareModel != nil {
+			m.compareModel.handleResult(resultMsg)
 		}
 		return m, nil
 	}
 
+	// Batches run in the background, their progress is delivered to the
+	// batch screen of the project whichever screen is shown.
+	if eventMsg, ok := msg.(batchEventMsg); ok {
+		bm := m.batchModels[eventMsg.projectDir]
+		if bm == nil {
+			return m, nil
+		}
+		cmd := bm.handleEvent(eventMsg)
+		if m.projectModel != nil && m.projectModel.projectDir == eventMsg.projectDir {
+			m.projectModel.reloadOutputs()
+		}
+		return m, cmd
+	}
+
 	// Handle window size messages
 	if msg, ok := msg.(tea.WindowSizeMsg); ok {
 		m.width = msg.Width
@@ -60,6 +158,19 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.errorModel.width = msg.Width
 			m.errorModel.height = msg.Height
 		}
+		if m.palette != nil {
+			m.palette.width = msg.Width
+			m.palette.height = msg.Height
+		}
+		if m.help != nil {
+			m.help.width = msg.Width
+			m.help.height = msg.Height
+		}
+	}
+
+	// Quitting works from every screen and overlay.
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keymap.Matches(keyMsg, keymap.Quit) {
+		return m, tea.Quit
 	}
 
 	// If error is visible, let it handle messages first
@@ -72,20 +183,19 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case types.ShowFloatInputMsg:
-		m.floatModel = NewFloatInputModel(msg.Prompt, msg.InitialValue, msg.Callback)
+		m.floatModel = NewFloatInputModel(msg.Prompt, msg.InitialValue, msg.Multiline, msg.Callback, msg.Submit)
 		m.showFloat = true
 		m.isEditing = true
 		return m, nil
+	case types.EditorFinishedMsg:
+		if msg.Callback != nil {
+			msg.Callback(msg.Value)
+		}
+		return m, nil
 	case types.FloatDismissMsg:
 		m.showFloat = false
 		m.isEditing = false
 		return m, nil
-	case types.GenerationCompleteMsg:
-		// Ensure generating state is reset when generation completes
-		if m.projectModel != nil {
-			m.projectModel.isGenerating = false
-		}
-		return m, nil
 	}
 
 	if m.showFloat {
@@ -94,6 +204,30 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		if m.showPalette {
+			closed, cmd := m.palette.update(keyMsg)
+			m.showPalette = !closed
+			return m, cmd
+		}
+		if m.showHelp {
+			m.showHelp = !m.help.update(keyMsg)
+			return m, nil
+		}
+		if p, ok := m.activeModel.(bindingsProvider); ok && !m.activeTyping() {
+			switch {
+			case keymap.Matches(keyMsg, keymap.Palette):
+				m.palette = NewPaletteModel(p.Bindings(), m.width, m.height)
+				m.showPalette = true
+				return m, nil
+			case keymap.Matches(keyMsg, keymap.Help):
+				m.help = NewHelpModel(p.Bindings(), m.width, m.height)
+				m.showHelp = true
+				return m, nil
+			}
+		}
+	}
+
 	// Handle state transitions
 	switch msg := msg.(type) {
 	case types.TransitionMsg:
@@ -116,12 +250,64 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.llmManagerModel = NewLLMManagerModel(m.projects)
 			}
 			m.activeModel = m.llmManagerModel
+		case types.StateCompare:
+			m.compareModel = NewCompareModel(m.projects, msg.Params.(compareParams))
+			m.activeModel = m.compareModel
+			var cmd tea.Cmd
+			m.activeModel, cmd = m.activeModel.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+			return m, cmd
+		case types.StateBoard:
+			m.activeModel = NewBoardModel(msg.Params.(boardParams))
+			var cmd tea.Cmd
+			m.activeModel, cmd = m.activeModel.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+			return m, cmd
+		case types.StateSettings:
+			m.activeModel = NewSettingsModel()
+			var cmd tea.Cmd
+			m.activeModel, cmd = m.activeModel.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+			return m, tea.Batch(m.activeModel.Init(), cmd)
+		case types.StatePreview:
+			m.activeModel = NewPreviewModel(msg.Params.(previewParams))
+			var cmd tea.Cmd
+			m.activeModel, cmd = m.activeModel.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+			return m, tea.Batch(m.activeModel.Init(), cmd)
+		case types.StateBatch:
+			params := msg.Params.(batchParams)
+			var initCmd tea.Cmd
+			bm := m.batchModels[params.project.Path]
+			if bm == nil || !bm.running {
+				var err error
+				if bm, err = NewBatchModel(m.projects, params.project, params.queue); err != nil {
+					return m, func() tea.Msg { return types.ErrorMsg{Error: err} }
+				}
+				m.batchModels[params.project.Path] = bm
+				initCmd = bm.Init()
+			}
+			m.activeModel = bm
+			var cmd tea.Cmd
+			m.activeModel, cmd = m.activeModel.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+			return m, tea.Batch(cmd, initCmd)
 		case types.StateProjectOverview:
-			if m.projectModel == nil {
-				project := msg.Params.(types.Project)
+			project := msg.Params.(types.Project)
+			var initCmd tea.Cmd
+			if m.projectModel == nil || m.projectModel.projectDir != project.Path {
 				m.projectModel = NewProjectDetailModel(project.Path, m.projects)
+				m.projectModel.pendingGenerations = m.generations.count(project.Path)
+				initCmd = m.projectModel.Init()
 			}
 			m.activeModel = m.projectModel
+
+			var cmd tea.Cmd
+			m.activeModel, cmd = m.activeModel.Update(msg)
+			cmds := []tea.Cmd{cmd, initCmd}
+
+			// Opening a project counts as a visit for the recent ordering.
+			if err := m.projects.TouchProject(project.Name); err != nil {
+				cmds = append(cmds, func() tea.Msg {
+					return types.ErrorMsg{Error: fmt.Errorf("failed to update project: %w", err)}
+				})
+			}
+			return m, tea.Batch(cmds...)
 		}
 	}
 
@@ -131,15 +317,28 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// activeTyping reports whether the active screen takes typed text.
+func (m *MainModel) activeTyping() bool {
+	t, ok := m.activeModel.(typist)
+	return ok && t.typing()
+}
+
 func (m *MainModel) View() string {
 	var content string
 	if m.showFloat {
 		content = m.floatModel.View()
 	} else if m.errorModel.visible {
 		content = m.errorModel.View()
+	} else if m.showPalette {
+		content = m.palette.View()
+	} else if m.showHelp {
+		content = m.help.View()
 	} else {
 		content = m.activeModel.View()
 	}
+	if status := m.generations.status(); status != "" {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, status)
+	}
 	// Wrap the view with the centralized AppBackground style.
 	return ui.AppBackground.Render(content)
 }
@@ -1,6 +1,13 @@
 package models
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/FabricSoul/auto-resume/internal/keymap"
+	"github.com/FabricSoul/auto-resume/internal/latex"
+	"github.com/FabricSoul/auto-resume/internal/templates"
 	"github.com/FabricSoul/auto-resume/internal/types"
 	"github.com/FabricSoul/auto-resume/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
@@ -12,6 +19,25 @@ type NewProjectModel struct {
 	width       int
 	height      int
 	projects    *types.ProjectManager
+
+	// Template gallery; the cursor is 0 for an empty resume and i+1 for
+	// templates[i].
+	templates      []templates.Template
+	templateCursor int
+	templateErr    error
+	// missing lists the files each template needs that are not installed,
+	// by template ID. Unknown without kpsewhich.
+	missing      map[string][]string
+	missingKnown bool
+}
+
+// templatesLoadedMsg carries the template gallery, loaded in the background
+// because checking installed packages runs kpsewhich for each.
+type templatesLoadedMsg struct {
+	templates    []templates.Template
+	missing      map[string][]string
+	missingKnown bool
+	err          error
 }
 
 func NewProjectScreen(pm *types.ProjectManager) *NewProjectModel {
@@ -21,7 +47,21 @@ func NewProjectScreen(pm *types.ProjectManager) *NewProjectModel {
 }
 
 func (m *NewProjectModel) Init() tea.Cmd {
-	return nil
+	return m.loadTemplates()
+}
+
+func (m *NewProjectModel) loadTemplates() tea.Cmd {
+	dir := templates.Dir()
+	return func() tea.Msg {
+		list, err := templates.List(dir)
+		msg := templatesLoadedMsg{templates: list, missing: map[string][]string{}, err: err}
+		for _, t := range list {
+			missing, known := latex.MissingFiles(t.Requirements()...)
+			msg.missing[t.ID] = missing
+			msg.missingKnown = known
+		}
+		return msg
+	}
 }
 
 func (m *NewProjectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -29,9 +69,20 @@ func (m *NewProjectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+	case types.TransitionMsg:
+		// User templates may have been added since the screen was last shown.
+		return m, m.loadTemplates()
+	case templatesLoadedMsg:
+		m.templates = msg.templates
+		m.templateErr = msg.err
+		m.missing = msg.missing
+		m.missingKnown = msg.missingKnown
+		if m.templateCursor > len(m.templates) {
+			m.templateCursor = 0
+		}
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "i":
+		switch {
+		case keymap.Matches(msg, keymap.Input):
 			return m, func() tea.Msg {
 				return types.ShowFloatInputMsg{
 					Prompt:       "Enter Project Name",
@@ -41,11 +92,23 @@ func (m *NewProjectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					},
 				}
 			}
-		case "esc":
+		case keymap.Matches(msg, keymap.Editor):
+			return m, openInEditor(m.projectName, ".txt", func(value string) {
+				m.projectName = strings.TrimSpace(value)
+			})
+		case keymap.Matches(msg, keymap.Down):
+			if m.templateCursor < len(m.templates) {
+				m.templateCursor++
+			}
+		case keymap.Matches(msg, keymap.Up):
+			if m.templateCursor > 0 {
+				m.templateCursor--
+			}
+		case keymap.Matches(msg, keymap.Back):
 			return m, func() tea.Msg {
 				return types.TransitionMsg{To: types.StateSplash}
 			}
-		case "enter":
+		case keymap.Matches(msg, keymap.Select):
 			if m.projectName == "" {
 				return m, func() tea.Msg {
 					return types.ErrorMsg{Error: types.ErrEmptyProjectName}
@@ -56,23 +119,132 @@ func (m *NewProjectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return types.ErrorMsg{Error: err}
 				}
 			}
-			return m, func() tea.Msg {
+			toSplash := func() tea.Msg {
 				return types.TransitionMsg{To: types.StateSplash}
 			}
+			if t, ok := m.selectedTemplate(); ok {
+				project, err := m.projects.GetProject(m.projectName)
+				if err == nil {
+					err = templates.Apply(project.Path, t)
+				}
+				if errors.Is(err, templates.ErrEngineMissing) {
+					// The project was created, tell why it compiles with
+					// another engine.
+					return m, tea.Sequence(toSplash, func() tea.Msg {
+						return types.ErrorMsg{Error: err}
+					})
+				}
+				if err != nil {
+					return m, func() tea.Msg {
+						return types.ErrorMsg{Error: fmt.Errorf("failed to apply template %s: %w", t.Name, err)}
+					}
+				}
+			}
+			return m, toSplash
 		}
 	}
 	return m, nil
 }
 
+// Bindings returns the actions of the new project form.
+func (m *NewProjectModel) Bindings() keymap.Bindings {
+	return keymap.Get(keymap.Input, keymap.Editor, keymap.Down, keymap.Up, keymap.Select, keymap.Back, keymap.Palette, keymap.Help).
+		Describe(keymap.Input, "edit name").
+		Describe(keymap.Up, "template").
+		Describe(keymap.Down, "template").
+		Describe(keymap.Select, "create").
+		Describe(keymap.Back, "cancel")
+}
+
+// selectedTemplate returns the chosen template, or false for an empty
+// resume.
+func (m *NewProjectModel) selectedTemplate() (templates.Template, bool) {
+	if m.templateCursor == 0 || m.templateCursor > len(m.templates) {
+		return templates.Template{}, false
+	}
+	return m.templates[m.templateCursor-1], true
+}
+
 func (m *NewProjectModel) View() string {
 	content := ui.Title.Render("Create New Project") + "\n\n"
 	content += "Enter project name:\n"
-	content += ui.Input.Render(m.projectName)
+	content += ui.Input.Render(m.projectName) + "\n\n"
+
+	content += "Start from:\n"
+	names := []string{"Empty resume"}
+	for _, t := range m.templates {
+		name := t.Name
+		if !t.Builtin() {
+			name += " (yours)"
+		}
+		names = append(names, name)
+	}
+	for i, name := range names {
+		if i == m.templateCursor {
+			content += ui.SelectedItem.Render("► "+name) + "\n"
+		} else {
+			content += "  " + name + "\n"
+		}
+	}
 
-	help := ui.Help.Render("i: edit • enter: create • esc: cancel")
+	help := ui.Help.Render(keymap.HelpLine(m.Bindings()))
 
-	mainContent := ui.BaseDetails.Width(m.width / 2).Render(content)
-	joined := ui.JoinedContainer.Render(lipgloss.JoinVertical(lipgloss.Left, mainContent, help))
+	formWidth := m.width / 2
+	mainContent := ui.BaseList.Width(formWidth).Render(content)
+	preview := ui.BaseDetails.Width(m.width - formWidth - 6).Render(m.renderTemplatePreview())
+	joined := ui.JoinedContainer.Render(lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.JoinHorizontal(lipgloss.Top, mainContent, preview), help))
 	return joined
+}
+
+// renderTemplatePreview describes the selected template: what it needs to
+// compile and the sections it starts with.
+func (m *NewProjectModel) renderTemplatePreview() string {
+	t, ok := m.selectedTemplate()
+	if !ok {
+		content := ui.Title.Render("Empty resume") + "\n"
+		content += "Start without a resume and import one later with I on the\nresume field.\n"
+		if m.templateErr != nil {
+			content += "\n" + ui.ErrorTitle.Render("Some templates could not be loaded:") + "\n" + m.templateErr.Error() + "\n"
+		}
+		content += "\nAdd your own templates to " + templates.Dir()
+		return content
+	}
 
+	content := ui.Title.Render(t.Name) + "\n"
+	if t.Description != "" {
+		content += t.Description + "\n\n"
+	}
+	engine := "any"
+	if t.Engine != "" {
+		engine = t.Engine
+		if _, err := latex.FindEngine(t.Engine); err != nil {
+			engine += ui.StatusFailed.Render(" (not installed)")
+		}
+	}
+	content += "Engine: " + engine + "\n"
+	if class := t.Class(); class != "" {
+		content += "Class: " + class + "\n"
+	}
+	if len(t.Packages) > 0 {
+		content += "Packages: " + strings.Join(t.Packages, ", ") + "\n"
+	}
+	switch missing := m.missing[t.ID]; {
+	case !m.missingKnown:
+	case len(missing) > 0:
+		content += ui.StatusFailed.Render("Missing: "+strings.Join(missing, ", ")) + "\n"
+	default:
+		content += ui.StatusDone.Render("All packages installed") + "\n"
+	}
+	if !t.Builtin() {
+		content += "From: " + t.Path + "\n"
+	}
+
+	if outline := t.Outline(); len(outline) > 0 {
+		content += "\nSections:\n"
+		for _, s := range outline {
+			content += "  • " + s + "\n"
+		}
+	}
+	return content
 }
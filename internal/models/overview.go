@@ -1,28 +1,115 @@
 package models
 
 import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/keymap"
+	"github.com/FabricSoul/auto-resume/internal/reminders"
 	"github.com/FabricSoul/auto-resume/internal/types"
 	"github.com/FabricSoul/auto-resume/internal/ui"
+	"github.com/FabricSoul/auto-resume/pkg/config"
+	"github.com/FabricSoul/auto-resume/pkg/fuzzy"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// projectSort selects the order in which projects are listed.
+type projectSort int
+
+const (
+	SortRecent projectSort = iota
+	SortName
+	SortCreated
+)
+
+func (s projectSort) String() string {
+	switch s {
+	case SortName:
+		return "name"
+	case SortCreated:
+		return "created"
+	default:
+		return "recent"
+	}
+}
+
+// projectStats caches the per-project numbers shown in the details pane so
+// project.toml is only re-read when it changes on disk.
+type projectStats struct {
+	modTime       time.Time
+	outputs       int
+	lastGenerated time.Time
+}
+
 type SplashModel struct {
 	width   int
 	height  int
 	project *types.ProjectManager
 	// Add selected project index for navigation
 	selectedIndex int
+
+	sortMode  projectSort
+	filter    string
+	filtering bool
+	stats     map[string]projectStats
+
+	// reminders of all projects, see reminders.go.
+	reminders []reminders.Reminder
 }
 
 func NewSplashModel(pm *types.ProjectManager) *SplashModel {
 	return &SplashModel{
 		project: pm,
+		stats:   make(map[string]projectStats),
 	}
 }
 
+// statsLoadedMsg carries the project statistics, loaded in the background.
+type statsLoadedMsg struct {
+	stats map[string]projectStats
+}
+
 func (m *SplashModel) Init() tea.Cmd {
-	return nil
+	return tea.Batch(m.loadReminders(), m.loadStats())
+}
+
+// visibleProjects returns the projects matching the current filter, in the
+// current sort order. While a filter is active, the best matches come first.
+func (m *SplashModel) visibleProjects() []types.Project {
+	projects := make([]types.Project, len(m.project.Projects))
+	copy(projects, m.project.Projects)
+
+	sort.SliceStable(projects, func(i, j int) bool {
+		switch m.sortMode {
+		case SortName:
+			return strings.ToLower(projects[i].Name) < strings.ToLower(projects[j].Name)
+		case SortCreated:
+			return projects[i].CreatedAt.After(projects[j].CreatedAt)
+		default:
+			return projects[i].LastOpened.After(projects[j].LastOpened)
+		}
+	})
+
+	if m.filter == "" {
+		return projects
+	}
+
+	names := make([]string, len(projects))
+	for i, p := range projects {
+		names[i] = p.Name
+	}
+	var filtered []types.Project
+	for _, i := range fuzzy.Filter(m.filter, names) {
+		filtered = append(filtered, projects[i])
+	}
+	return filtered
 }
 
 func (m *SplashModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -31,33 +118,77 @@ func (m *SplashModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 
+	case types.TransitionMsg:
+		// Statuses and outputs may have changed while the splash screen
+		// was hidden.
+		return m, tea.Batch(m.loadReminders(), m.loadStats())
+
+	case remindersLoadedMsg:
+		m.reminders = msg.reminders
+
+	case statsLoadedMsg:
+		m.stats = msg.stats
+
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
+		if m.filtering {
+			return m.updateFilter(msg)
+		}
+
+		visible := m.visibleProjects()
+		switch {
+		case keymap.Matches(msg, keymap.Close):
 			return m, tea.Quit
-		case "n":
+		case keymap.Matches(msg, keymap.NewProject):
 			return m, func() tea.Msg {
 				return types.TransitionMsg{
 					To: types.StateNewProject,
 				}
 			}
-		case "M":
+		case keymap.Matches(msg, keymap.ManageModels):
 			return m, func() tea.Msg {
 				return types.TransitionMsg{
 					To: types.StateLLMManager,
 				}
 			}
-		case "up", "k":
+		case keymap.Matches(msg, keymap.ExportProject):
+			if m.selectedIndex < len(visible) {
+				return m, m.promptExport(visible[m.selectedIndex])
+			}
+		case keymap.Matches(msg, keymap.ImportProject):
+			return m, m.promptImport()
+		case keymap.Matches(msg, keymap.ExportReminders):
+			return m, m.promptCalendarExport()
+		case keymap.Matches(msg, keymap.Board):
+			params := boardParams{
+				projects: m.project.Projects,
+				back:     types.TransitionMsg{To: types.StateSplash},
+			}
+			return m, func() tea.Msg {
+				return types.TransitionMsg{To: types.StateBoard, Params: params}
+			}
+		case keymap.Matches(msg, keymap.Settings):
+			return m, func() tea.Msg {
+				return types.TransitionMsg{To: types.StateSettings}
+			}
+		case keymap.Matches(msg, keymap.Filter):
+			m.filtering = true
+		case keymap.Matches(msg, keymap.Back):
+			m.filter = ""
+			m.selectedIndex = 0
+		case keymap.Matches(msg, keymap.Sort):
+			m.sortMode = (m.sortMode + 1) % 3
+			m.selectedIndex = 0
+		case keymap.Matches(msg, keymap.Up):
 			if m.selectedIndex > 0 {
 				m.selectedIndex--
 			}
-		case "down", "j":
-			if m.selectedIndex < len(m.project.Projects)-1 {
+		case keymap.Matches(msg, keymap.Down):
+			if m.selectedIndex < len(visible)-1 {
 				m.selectedIndex++
 			}
-		case "enter":
-			if len(m.project.Projects) > 0 {
-				selectedProject := m.project.Projects[m.selectedIndex]
+		case keymap.Matches(msg, keymap.Select):
+			if m.selectedIndex < len(visible) {
+				selectedProject := visible[m.selectedIndex]
 				return m, func() tea.Msg {
 					return types.TransitionMsg{
 						To:     types.StateProjectOverview,
@@ -70,17 +201,159 @@ func (m *SplashModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// Bindings returns the actions of the projects list.
+func (m *SplashModel) Bindings() keymap.Bindings {
+	if m.filtering {
+		return nil
+	}
+	actions := []keymap.Action{keymap.Down, keymap.Up, keymap.Select, keymap.Close}
+	if m.filter != "" {
+		actions = append(actions, keymap.Back)
+	}
+	actions = append(actions, keymap.NewProject, keymap.ManageModels, keymap.Board,
+		keymap.ExportReminders, keymap.ExportProject, keymap.ImportProject, keymap.Filter,
+		keymap.Sort, keymap.Settings, keymap.Palette, keymap.Help)
+	return keymap.Get(actions...).
+		Describe(keymap.Select, "open").
+		Describe(keymap.Close, "quit").
+		Describe(keymap.Back, "clear filter")
+}
+
+// typing reports whether keys are typed into the filter.
+func (m *SplashModel) typing() bool {
+	return m.filtering
+}
+
+// promptExport asks for the destination of the project archive and writes it.
+func (m *SplashModel) promptExport(p types.Project) tea.Cmd {
+	return func() tea.Msg {
+		return types.ShowFloatInputMsg{
+			Prompt:       "Export to (.tar.gz or .zip)",
+			InitialValue: filepath.Join("~", p.Name+".tar.gz"),
+			Submit: func(value string) tea.Cmd {
+				dest := config.ExpandHome(strings.TrimSpace(value))
+				return func() tea.Msg {
+					if err := types.WriteArchive(p, dest); err != nil {
+						return types.ErrorMsg{Error: fmt.Errorf("failed to export project: %w", err)}
+					}
+					return nil
+				}
+			},
+		}
+	}
+}
+
+// archiveExtractedMsg carries a project archive unpacked in the background,
+// to be added to the project list on the update loop.
+type archiveExtractedMsg struct {
+	archive *types.ExtractedArchive
+}
+
+// promptImport asks for an archive path and adds the contained project.
+func (m *SplashModel) promptImport() tea.Cmd {
+	pm := m.project
+	return func() tea.Msg {
+		return types.ShowFloatInputMsg{
+			Prompt: "Import archive path",
+			Submit: func(value string) tea.Cmd {
+				src := config.ExpandHome(strings.TrimSpace(value))
+				return func() tea.Msg {
+					x, err := pm.ExtractArchive(src)
+					if err != nil {
+						return types.ErrorMsg{Error: fmt.Errorf("failed to import project: %w", err)}
+					}
+					return archiveExtractedMsg{archive: x}
+				}
+			},
+		}
+	}
+}
+
+// updateFilter handles key presses while the filter prompt is active. The
+// list is narrowed on every keystroke.
+func (m *SplashModel) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.filtering = false
+		m.filter = ""
+	case tea.KeyEnter:
+		m.filtering = false
+	case tea.KeyBackspace:
+		if r := []rune(m.filter); len(r) > 0 {
+			m.filter = string(r[:len(r)-1])
+		}
+	case tea.KeyUp:
+		if m.selectedIndex > 0 {
+			m.selectedIndex--
+		}
+		return m, nil
+	case tea.KeyDown:
+		if m.selectedIndex < len(m.visibleProjects())-1 {
+			m.selectedIndex++
+		}
+		return m, nil
+	case tea.KeyRunes, tea.KeySpace:
+		m.filter += string(msg.Runes)
+	default:
+		return m, nil
+	}
+	m.selectedIndex = 0
+	return m, nil
+}
+
+// loadStats reads the output statistics of every project in the background,
+// as loading a config may migrate it. Configs unchanged since they were last
+// read keep their statistics.
+func (m *SplashModel) loadStats() tea.Cmd {
+	projects := slices.Clone(m.project.Projects)
+	cached := maps.Clone(m.stats)
+	return func() tea.Msg {
+		stats := make(map[string]projectStats, len(projects))
+		for _, p := range projects {
+			info, err := os.Stat(filepath.Join(p.Path, types.ProjectConfigFile))
+			if err != nil {
+				continue
+			}
+			if c, ok := cached[p.Path]; ok && c.modTime.Equal(info.ModTime()) {
+				stats[p.Path] = c
+				continue
+			}
+			cfg, err := types.LoadProjectConfig(p.Path)
+			if err != nil {
+				continue
+			}
+			stats[p.Path] = projectStats{
+				modTime:       info.ModTime(),
+				outputs:       len(cfg.Outputs),
+				lastGenerated: cfg.LastGeneratedAt(),
+			}
+		}
+		return statsLoadedMsg{stats: stats}
+	}
+}
+
 func (m *SplashModel) View() string {
 	// Calculate section widths
 	listWidth := m.width / 3
 	detailsWidth := m.width - listWidth - 4 // Account for margins/padding
 
+	visible := m.visibleProjects()
+
 	// Projects list section
-	projectsList := ui.Title.Render("Projects") + "\n"
+	projectsList := ui.Title.Render(fmt.Sprintf("Projects (%s)", m.sortMode)) + "\n"
+	if m.filtering || m.filter != "" {
+		cursor := ""
+		if m.filtering {
+			cursor = "█"
+		}
+		projectsList += "/" + m.filter + cursor + "\n\n"
+	}
 	if len(m.project.Projects) == 0 {
 		projectsList += "No projects yet"
+	} else if len(visible) == 0 {
+		projectsList += "No matching projects"
 	} else {
-		for i, proj := range m.project.Projects {
+		for i, proj := range visible {
 			item := proj.Name
 			if i == m.selectedIndex {
 				item = ui.SelectedItem.Render("► " + item)
@@ -93,25 +366,46 @@ func (m *SplashModel) View() string {
 
 	// Details section
 	detailsContent := ui.Title.Render("Project Details") + "\n"
-	if len(m.project.Projects) > 0 && m.selectedIndex < len(m.project.Projects) {
-		selectedProject := m.project.Projects[m.selectedIndex]
+	if m.selectedIndex < len(visible) {
+		selectedProject := visible[m.selectedIndex]
 		detailsContent += "Name: " + selectedProject.Name + "\n"
 		detailsContent += "Created: " + selectedProject.CreatedAt.Format("2006-01-02") + "\n"
-		detailsContent += "Last Opened: " + selectedProject.LastOpened.Format("2006-01-02") + "\n"
+		detailsContent += "Last Opened: " + selectedProject.LastOpened.Format("2006-01-02 15:04") + "\n"
+		if stats, ok := m.stats[selectedProject.Path]; ok {
+			detailsContent += fmt.Sprintf("Outputs: %d\n", stats.outputs)
+			if stats.lastGenerated.IsZero() {
+				detailsContent += "Last Generated: never\n"
+			} else {
+				detailsContent += "Last Generated: " + stats.lastGenerated.Format("2006-01-02 15:04") + "\n"
+			}
+		}
 		detailsContent += "Path: " + selectedProject.Path + "\n"
 	} else {
 		detailsContent += "Select a project to view details"
 	}
 
 	// Help section
-	help := ui.Help.Render("n: New Project • M: Manage Models • q: Quit • ↑/↓: Navigate")
+	help := ui.Help.Render(keymap.HelpLine(m.Bindings()))
+	if m.filtering {
+		help = ui.Help.Render("type to filter • ↑/↓: navigate • enter: apply • esc: clear")
+	}
+
+	// Due reminders are shown above the projects.
+	dashboard := m.renderReminders(m.width - 2)
+	height := m.height - 4
+	if dashboard != "" {
+		height -= lipgloss.Height(dashboard)
+	}
 
 	// Layout sections
-	leftSection := ui.BaseList.Width(listWidth).Height(m.height - 4).Render(projectsList)
-	rightSection := ui.BaseDetails.Width(detailsWidth).Height(m.height - 4).Render(detailsContent)
+	leftSection := ui.BaseList.Width(listWidth).Height(height).Render(projectsList)
+	rightSection := ui.BaseDetails.Width(detailsWidth).Height(height).Render(detailsContent)
 
 	// Combine horizontally
 	content := lipgloss.JoinHorizontal(lipgloss.Top, leftSection, rightSection)
+	if dashboard != "" {
+		content = lipgloss.JoinVertical(lipgloss.Left, dashboard, content)
+	}
 
 	// Add help at bottom
 	return lipgloss.JoinVertical(lipgloss.Left, content, help)
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/FabricSoul/auto-resume/internal/generator"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// promptPageLimit asks for the number of pages outputs must fit on.
+func (m *ProjectDetailModel) promptPageLimit() tea.Cmd {
+	initial := ""
+	if m.pageLimit > 0 {
+		initial = strconv.Itoa(m.pageLimit)
+	}
+	return func() tea.Msg {
+		return types.ShowFloatInputMsg{
+			Prompt:       "Page limit (empty or 0 for none)",
+			InitialValue: initial,
+			Submit: func(value string) tea.Cmd {
+				value = strings.TrimSpace(value)
+				limit := 0
+				if value != "" {
+					n, err := strconv.Atoi(value)
+					if err != nil || n < 0 {
+						return func() tea.Msg {
+							return types.ErrorMsg{Error: fmt.Errorf("invalid page limit %q, enter a number of pages", value)}
+						}
+					}
+					limit = n
+				}
+				m.pageLimit = limit
+				return m.saveProjectConfig()
+			},
+		}
+	}
+}
+
+// fitToPageLimit has the output named by slug shortened in the background
+// until it fits the page limit. It compiled to pages pages. The shortened
+// LaTeX is written when the result is applied and then compiled again for
+// the preview.
+func (m *ProjectDetailModel) fitToPageLimit(slug string, pages int) tea.Cmd {
+	i := m.outputIndex(slug)
+	if i < 0 {
+		return nil
+	}
+	current := m.outputs[i]
+	model := m.llmOptions[m.selectedLLMIndex]
+	projectDir := m.projectDir
+	cfg := types.ProjectConfig{Engine: m.engine}
+	limit := m.pageLimit
+	m.resumeNotice = fmt.Sprintf("%s runs %d page(s) over the limit of %d, shortening with %s",
+		current.Name, pages-limit, limit, model.Name)
+
+	// stopped is set by run, before after runs with its result.
+	var stopped error
+	return startGeneration(generationJob{
+		projectDir: projectDir,
+		label:      fmt.Sprintf("%s: fit %s to %d page(s)", m.overviewProjectName, current.Name, limit),
+		run: func(ctx context.Context) (func([]types.Output) ([]types.Output, string), error) {
+			fitted, result, err := generator.FitToPages(ctx, model, projectDir, cfg, current, pages, limit)
+			if err != nil {
+				return nil, err
+			}
+			stopped = result.Stopped
+			message := fmt.Sprintf("Shorten %s to %d page(s) with %s", current.Name, result.Pages, model.Name)
+			if !result.Fits(limit) {
+				message = fmt.Sprintf("Shorten %s with %s, still %d page(s) after %d attempts",
+					current.Name, model.Name, result.Pages, result.Attempts)
+			}
+			return func(outputs []types.Output) ([]types.Output, string) {
+				for i := range outputs {
+					if outputs[i].Slug == current.Slug {
+						outputs[i].GeneratedOutput = fitted.GeneratedOutput
+					}
+				}
+				return outputs, message
+			}, nil
+		},
+		after: func(outputs []types.Output) tea.Cmd {
+			i := slices.IndexFunc(outputs, func(out types.Output) bool { return out.Slug == current.Slug })
+			if i < 0 {
+				return nil
+			}
+			compile := previewOutput(projectDir, cfg, outputs[i], true)
+			if stopped == nil {
+				return compile
+			}
+			err := fmt.Errorf("stopped shortening %s, kept the last draft that compiled: %w", current.Name, stopped)
+			return tea.Batch(compile, func() tea.Msg { return types.ErrorMsg{Error: err} })
+		},
+	})
+}
@@ -0,0 +1,123 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/FabricSoul/auto-resume/internal/keymap"
+	"github.com/FabricSoul/auto-resume/internal/ui"
+	"github.com/FabricSoul/auto-resume/pkg/fuzzy"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// paletteRows is the number of commands listed at once.
+const paletteRows = 12
+
+// bindingsProvider is implemented by screens whose actions are listed in
+// the command palette and the help.
+type bindingsProvider interface {
+	Bindings() keymap.Bindings
+}
+
+// typist is implemented by screens that sometimes take typed text, during
+// which the palette key is typed instead of opening the palette.
+type typist interface {
+	typing() bool
+}
+
+// PaletteModel lists the actions of the current screen, filtered as the
+// user types, and runs the chosen one by sending its key.
+type PaletteModel struct {
+	bindings keymap.Bindings
+	query    string
+	matches  []int
+	cursor   int
+	width    int
+	height   int
+}
+
+// NewPaletteModel lists bindings, with quitting added so it is always
+// available. Actions bound to the same help text are listed once each.
+func NewPaletteModel(bindings keymap.Bindings, width, height int) *PaletteModel {
+	m := &PaletteModel{width: width, height: height}
+	seen := make(map[keymap.Action]bool)
+	for _, b := range append(bindings, keymap.Get(keymap.Quit)...) {
+		if b.Action == keymap.Palette || seen[b.Action] {
+			continue
+		}
+		seen[b.Action] = true
+		m.bindings = append(m.bindings, b)
+	}
+	m.filter()
+	return m
+}
+
+// filter matches the query against the description, category and keys of
+// each binding.
+func (m *PaletteModel) filter() {
+	items := make([]string, len(m.bindings))
+	for i, b := range m.bindings {
+		items[i] = b.Summary() + " " + b.Title + " " + b.Category + " " + strings.Join(b.Keys, " ")
+	}
+	m.matches = fuzzy.Filter(m.query, items)
+	m.cursor = 0
+}
+
+// update handles a key press. It reports whether the palette is closed,
+// together with the command running the chosen action.
+func (m *PaletteModel) update(msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		return true, nil
+	case tea.KeyEnter:
+		if m.cursor >= len(m.matches) {
+			return true, nil
+		}
+		key := keymap.KeyMsg(m.bindings[m.matches[m.cursor]].Keys[0])
+		return true, func() tea.Msg { return key }
+	case tea.KeyUp, tea.KeyCtrlP:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case tea.KeyDown, tea.KeyCtrlN:
+		if m.cursor < len(m.matches)-1 {
+			m.cursor++
+		}
+	case tea.KeyBackspace:
+		if r := []rune(m.query); len(r) > 0 {
+			m.query = string(r[:len(r)-1])
+			m.filter()
+		}
+	case tea.KeyCtrlU:
+		m.query = ""
+		m.filter()
+	case tea.KeySpace:
+		m.query += " "
+		m.filter()
+	case tea.KeyRunes:
+		m.query += string(msg.Runes)
+		m.filter()
+	}
+	return false, nil
+}
+
+func (m *PaletteModel) View() string {
+	content := ui.Title.Render("Commands") + "\n"
+	content += ui.Input.Render("> "+m.query+"█") + "\n\n"
+
+	if len(m.matches) == 0 {
+		content += "No matching commands\n"
+	}
+	start := max(0, m.cursor-paletteRows+1)
+	for i := start; i < len(m.matches) && i < start+paletteRows; i++ {
+		b := m.bindings[m.matches[i]]
+		detail := ui.Help.UnsetMarginTop().Render("  " + b.Category + " • " + keymap.DisplayKeys(b))
+		if i == m.cursor {
+			content += ui.SelectedItem.Render("► "+b.Summary()) + detail + "\n"
+		} else {
+			content += "  " + b.Summary() + detail + "\n"
+		}
+	}
+	content += ui.Help.Render("↑/↓: navigate • enter: run • esc: close")
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, ui.FloatBox.Render(content))
+}
@@ -0,0 +1,324 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/export"
+	"github.com/FabricSoul/auto-resume/internal/keymap"
+	"github.com/FabricSoul/auto-resume/internal/pdf"
+	"github.com/FabricSoul/auto-resume/internal/termimg"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/FabricSoul/auto-resume/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// defaultPageLimit is the page count a resume is expected to fit on.
+const defaultPageLimit = 1
+
+// previewDPI is the resolution pages are rasterised at before the terminal
+// scales them to the preview area.
+const previewDPI = 110
+
+// previewTimeout bounds reading and rasterising a PDF.
+const previewTimeout = 30 * time.Second
+
+// previewParams are passed with the transition to StatePreview.
+type previewParams struct {
+	title   string
+	pdfPath string
+	// source is the LaTeX of the PDF, laid out as text when pdftotext is
+	// not installed.
+	source    string
+	pageLimit int
+	// back is the transition taken when leaving the preview.
+	back types.TransitionMsg
+}
+
+// previewLoadedMsg carries the page count and text layout of a PDF.
+type previewLoadedMsg struct {
+	path  string
+	pages int
+	texts []string
+	// approx is set when texts come from the LaTeX instead of the PDF.
+	approx bool
+	err    error
+}
+
+// pageRenderedMsg carries the escape sequence drawing a page.
+type pageRenderedMsg struct {
+	path       string
+	page       int
+	cols, rows int
+	image      string
+	err        error
+}
+
+// PreviewModel shows the pages of a compiled PDF, as images in terminals
+// with a graphics protocol and as their text layout otherwise, together
+// with whether the resume fits its page limit.
+type PreviewModel struct {
+	width, height int
+
+	params   previewParams
+	protocol termimg.Protocol
+
+	loaded bool
+	pages  int
+	texts  []string
+	approx bool
+	err    error
+
+	page     int // counted from 0
+	showText bool
+
+	// image draws page imagePage at imageCols by imageRows cells.
+	image                string
+	imagePage            int
+	imageCols, imageRows int
+	rendering            bool
+	renderErr            error
+}
+
+func NewPreviewModel(params previewParams) *PreviewModel {
+	if params.pageLimit <= 0 {
+		params.pageLimit = defaultPageLimit
+	}
+	m := &PreviewModel{params: params, protocol: termimg.Detect(), imagePage: -1}
+	if !pdf.CanRender() {
+		m.protocol = termimg.None
+	}
+	return m
+}
+
+func (m *PreviewModel) Init() tea.Cmd {
+	params := m.params
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), previewTimeout)
+		defer cancel()
+		msg := previewLoadedMsg{path: params.pdfPath}
+		msg.pages, msg.err = pdf.PageCount(ctx, params.pdfPath)
+		if msg.err != nil {
+			return msg
+		}
+		texts, err := pdf.PageTexts(ctx, params.pdfPath)
+		switch {
+		case err == nil:
+			msg.texts = texts
+		case errors.Is(err, pdf.ErrNoPdftotext):
+			msg.texts = []string{export.Text(export.Parse(params.source))}
+			msg.approx = true
+		default:
+			msg.err = err
+		}
+		return msg
+	}
+}
+
+// graphics reports whether pages are drawn as images.
+func (m *PreviewModel) graphics() bool {
+	return m.protocol != termimg.None && !m.showText
+}
+
+// area returns the cells available for a page below the header and above
+// the help line.
+func (m *PreviewModel) area() (int, int) {
+	return max(m.width-4, 10), max(m.height-6, 5)
+}
+
+// renderPage rasterises the current page unless it is already shown at the
+// current size.
+func (m *PreviewModel) renderPage() tea.Cmd {
+	cols, rows := m.area()
+	if !m.loaded || !m.graphics() || m.rendering ||
+		(m.imagePage == m.page && m.imageCols == cols && m.imageRows == rows) {
+		return nil
+	}
+	m.rendering = true
+	path, page, protocol := m.params.pdfPath, m.page, m.protocol
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), previewTimeout)
+		defer cancel()
+		msg := pageRenderedMsg{path: path, page: page, cols: cols, rows: rows}
+		data, err := pdf.RenderPage(ctx, path, page+1, previewDPI)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		msg.image, msg.err = termimg.Encode(protocol, data, cols, rows)
+		return msg
+	}
+}
+
+func (m *PreviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, m.renderPage()
+
+	case previewLoadedMsg:
+		if msg.path != m.params.pdfPath {
+			return m, nil
+		}
+		m.loaded = true
+		m.pages, m.texts, m.approx, m.err = msg.pages, msg.texts, msg.approx, msg.err
+		return m, m.renderPage()
+
+	case pageRenderedMsg:
+		if msg.path != m.params.pdfPath {
+			return m, nil
+		}
+		m.rendering = false
+		m.renderErr = msg.err
+		if msg.err == nil {
+			m.image, m.imagePage, m.imageCols, m.imageRows = msg.image, msg.page, msg.cols, msg.rows
+		}
+		// The page may have changed while this one was rendered.
+		return m, m.renderPage()
+
+	case tea.KeyMsg:
+		switch {
+		case keymap.Matches(msg, keymap.Back), keymap.Matches(msg, keymap.Close):
+			back := m.params.back
+			return m, tea.Sequence(m.clearImage(), func() tea.Msg { return back })
+		case keymap.Matches(msg, keymap.NextPage):
+			if m.page < m.pages-1 {
+				m.page++
+			}
+		case keymap.Matches(msg, keymap.PrevPage):
+			if m.page > 0 {
+				m.page--
+			}
+		case keymap.Matches(msg, keymap.First):
+			m.page = 0
+		case keymap.Matches(msg, keymap.Last):
+			m.page = max(m.pages-1, 0)
+		case keymap.Matches(msg, keymap.ToggleText):
+			if m.protocol != termimg.None {
+				m.showText = !m.showText
+				return m, tea.Sequence(m.clearImage(), m.renderPage())
+			}
+		}
+		return m, m.renderPage()
+	}
+	return m, nil
+}
+
+// Bindings returns the actions of the preview. Switching to text is offered
+// when pages are drawn as images.
+func (m *PreviewModel) Bindings() keymap.Bindings {
+	actions := []keymap.Action{keymap.PrevPage, keymap.NextPage, keymap.First, keymap.Last}
+	if m.protocol != termimg.None {
+		actions = append(actions, keymap.ToggleText)
+	}
+	return keymap.Get(append(actions, keymap.Back, keymap.Palette, keymap.Help)...)
+}
+
+// clearImage removes images the terminal keeps apart from the text.
+func (m *PreviewModel) clearImage() tea.Cmd {
+	seq := termimg.Clear(m.protocol)
+	if seq == "" {
+		return nil
+	}
+	m.imagePage = -1
+	return tea.Printf("%s", seq)
+}
+
+// overflowLines counts the lines of text past the page limit.
+func (m *PreviewModel) overflowLines() int {
+	if m.approx {
+		return 0
+	}
+	n := 0
+	for i := m.params.pageLimit; i < len(m.texts); i++ {
+		for _, line := range strings.Split(m.texts[i], "\n") {
+			if strings.TrimSpace(line) != "" {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// fitStatus says whether the PDF fits its page limit.
+func (m *PreviewModel) fitStatus() string {
+	limit := m.params.pageLimit
+	noun := "pages"
+	if limit == 1 {
+		noun = "page"
+	}
+	if m.pages <= limit {
+		return ui.StatusDone.Render(fmt.Sprintf("Fits on %d %s", limit, noun))
+	}
+	status := fmt.Sprintf("%d page(s) over the limit of %d", m.pages-limit, limit)
+	if n := m.overflowLines(); n > 0 {
+		status += fmt.Sprintf(", %d line(s) spill over", n)
+	}
+	return ui.StatusFailed.Render(status)
+}
+
+func (m *PreviewModel) View() string {
+	cols, rows := m.area()
+	header := ui.Title.Render("Preview: "+m.params.title) + "\n"
+
+	var status string
+	var body []string
+	switch {
+	case !m.loaded:
+		status = "Reading " + m.params.pdfPath + "..."
+	case m.err != nil:
+		status = ui.StatusFailed.Render("Failed to read PDF: " + m.err.Error())
+	default:
+		status = fmt.Sprintf(" Page %d of %d • %s", m.page+1, m.pages, m.fitStatus())
+		if m.graphics() {
+			switch {
+			case m.renderErr != nil:
+				body = []string{ui.StatusFailed.Render("Failed to render page: " + m.renderErr.Error())}
+			case m.imagePage != m.page:
+				body = []string{"Rendering page..."}
+			}
+		} else {
+			body = m.textPage(cols, rows)
+		}
+	}
+
+	// Blank lines reserve the area the image is drawn over.
+	for len(body) < rows {
+		body = append(body, "")
+	}
+
+	help := ui.Help.Render(keymap.HelpLine(m.Bindings()))
+	if m.graphics() && m.loaded && m.err == nil && m.imagePage == m.page {
+		// Drawn last, from the help line, so the blank lines above do not
+		// paint over it. The cursor is restored afterwards.
+		help += "\x1b7" + fmt.Sprintf("\x1b[%dA\r\x1b[2C", rows+1) + m.image + "\x1b8"
+	}
+	return header + status + "\n\n" + strings.Join(body, "\n") + "\n" + help
+}
+
+// textPage lays out the text of the current page in the preview area, with
+// a note when it is approximate.
+func (m *PreviewModel) textPage(cols, rows int) []string {
+	var lines []string
+	if m.approx {
+		lines = append(lines, ui.Help.Render("Approximate layout from the LaTeX, install poppler-utils (pdftotext) for the real one"), "")
+	}
+	text := ""
+	if m.page < len(m.texts) {
+		text = m.texts[m.page]
+	}
+	all := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range all {
+		if len(lines) == rows-1 && i < len(all)-1 {
+			lines = append(lines, ui.Help.Render(fmt.Sprintf("... %d more line(s)", len(all)-i)))
+			break
+		}
+		lines = append(lines, "  "+ansi.Truncate(line, cols-2, "…"))
+	}
+	return lines
+}
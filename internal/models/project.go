@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -15,9 +16,16 @@ import (
 
 	"errors"
 
+	"github.com/FabricSoul/auto-resume/internal/batch"
+	"github.com/FabricSoul/auto-resume/internal/generator"
+	"github.com/FabricSoul/auto-resume/internal/jobdesc"
+	"github.com/FabricSoul/auto-resume/internal/jsonresume"
+	"github.com/FabricSoul/auto-resume/internal/keymap"
+	"github.com/FabricSoul/auto-resume/internal/latex"
 	"github.com/FabricSoul/auto-resume/internal/types"
 	"github.com/FabricSoul/auto-resume/internal/ui"
-	"github.com/teilomillet/gollm"
+	"github.com/FabricSoul/auto-resume/internal/vcs"
+	"github.com/FabricSoul/auto-resume/pkg/config"
 )
 
 var debugLog *log.Logger
@@ -41,6 +49,7 @@ const (
 	OverviewFieldProjectName = iota
 	OverviewFieldResumeInput
 	OverviewFieldLLM
+	OverviewFieldPageLimit
 )
 
 const (
@@ -48,7 +57,14 @@ const (
 	JobFieldDescription
 	JobFieldOutput
 	JobFieldGenerate
-	JobFieldSavePDF
+	JobFieldExport
+)
+
+// Tabs of the job section. The cover letter tab reuses the job fields for
+// the letter instead of the resume.
+const (
+	JobTabResume = iota
+	JobTabCoverLetter
 )
 
 // ProjectDetailModel represents a detailed project configuration screen.
@@ -68,10 +84,15 @@ type ProjectDetailModel struct {
 	// Within the job-specific section:
 	// 0 = job description input, 1 = generate button, 2 = save-to-PDF button.
 	jobField int
+	jobTab   int
 
 	// Overview section fields.
 	overviewProjectName string
 	resumeInput         string
+	resumeSource        string
+	resumeTemplate      string
+	engine              string
+	pageLimit           int
 
 	// LLM options drawn from the application config.
 	llmOptions []types.AIModel
@@ -79,6 +100,8 @@ type ProjectDetailModel struct {
 	// Outputs section: list of outputs and selection index.
 	outputs             []types.Output
 	selectedOutputIndex int
+	outputSort          outputSort
+	outputFilter        string // see outputFilters
 
 	// The project directory where the project config (project.toml) is saved.
 	projectDir string
@@ -88,9 +111,35 @@ type ProjectDetailModel struct {
 	llmList          []types.AIModel
 	selectedLLM      types.AIModel
 	projects         *types.ProjectManager
-	isGenerating     bool
-	showOutputViewer bool
-	outputViewer     textarea.Model
+	// pendingGenerations is the number of background jobs for this project,
+	// kept up to date by MainModel.
+	pendingGenerations int
+	showOutputViewer   bool
+	outputViewer       textarea.Model
+	outputViewerTitle  string
+
+	showHistory    bool
+	history        []vcs.Commit
+	selectedCommit int
+
+	// Application status selector of the selected output.
+	showStatusSelector bool
+	statusCursor       int
+	// statusSlug is the output the selector was opened for.
+	statusSlug string
+
+	// Export menu of the selected output, see export.go.
+	showExportMenu bool
+	exportChoices  []exportChoice
+	exportCursor   int
+	lastExport     string
+
+	// Polling state of the imported resume source, see resume_watch.go.
+	watchBundle       *latex.Bundle
+	watchStamp        time.Time
+	resumeNotice      string
+	fingerprint       latex.Fingerprint
+	fingerprintSource string
 }
 
 // NewProjectDetailModel constructs and initializes the project detail model.
@@ -112,6 +161,13 @@ func NewProjectDetailModel(projectDir string, pm *types.ProjectManager) *Project
 			Outputs:     []types.Output{},
 		}
 	}
+	// Outputs created before job details were extracted get them now; they
+	// are written with the next save.
+	for i := range config.Outputs {
+		if config.Outputs[i].Job.IsZero() && strings.TrimSpace(config.Outputs[i].JobDescription) != "" {
+			config.Outputs[i].Job = jobdesc.Extract(config.Outputs[i].JobDescription)
+		}
+	}
 	llmOptions := pm.GetModels()
 	selectedLLMIndex := 0
 	for i, m := range llmOptions {
@@ -120,9 +176,13 @@ func NewProjectDetailModel(projectDir string, pm *types.ProjectManager) *Project
 			break
 		}
 	}
-	return &ProjectDetailModel{
+	m := &ProjectDetailModel{
 		overviewProjectName: config.Name,
 		resumeInput:         config.ResumeInput,
+		resumeSource:        config.ResumeSource,
+		resumeTemplate:      config.ResumeTemplate,
+		engine:              config.Engine,
+		pageLimit:           config.PageLimit,
 		outputs:             config.Outputs,
 		llmOptions:          llmOptions,
 		selectedLLMIndex:    selectedLLMIndex,
@@ -133,10 +193,12 @@ func NewProjectDetailModel(projectDir string, pm *types.ProjectManager) *Project
 		projects:            pm,
 		outputViewer:        ta,
 	}
+	m.seedResumeWatch()
+	return m
 }
 
 func (m *ProjectDetailModel) Init() tea.Cmd {
-	return nil
+	return watchResume(m.projectDir)
 }
 
 func (m *ProjectDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -145,33 +207,60 @@ func (m *ProjectDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 
+	case resumeWatchMsg:
+		return m, m.checkResumeSource(msg)
+
+	case jobImportedMsg:
+		return m, m.applyPosting(msg)
+
+	case resumeImportedMsg:
+		return m, m.applyResumeImport(msg)
+
+	case projectRestoredMsg:
+		if msg.projectDir == m.projectDir {
+			m.applyConfig(msg.config)
+			m.resumeNotice = "Restored the project from " + msg.commit
+		}
+		if msg.committed != nil {
+			return m, func() tea.Msg { return msg.committed }
+		}
+
+	case exportDoneMsg:
+		if msg.projectDir != m.projectDir {
+			break
+		}
+		m.lastExport = msg.path
+		if msg.preview {
+			m.resumeNotice = fmt.Sprintf("Compiled %s to %s, %d page(s)", msg.name, msg.path, msg.pages)
+			if msg.fitted {
+				m.resumeNotice = fmt.Sprintf("Compiled shortened %s to %s, %d page(s)", msg.name, msg.path, msg.pages)
+			} else if m.pageLimit > 0 && msg.pages > m.pageLimit && len(m.llmOptions) > 0 {
+				return m, m.fitToPageLimit(msg.slug, msg.pages)
+			}
+			return m, m.openPreview(msg.path, msg.slug)
+		}
+
 	case tea.KeyMsg:
-		// Reset generating state when going back to splash screen
-		if msg.String() == "q" || msg.String() == "ctrl+c" {
-			m.isGenerating = false
+		// Running generations continue in the background.
+		if keymap.Matches(msg, keymap.Close) {
 			return m, func() tea.Msg {
 				return types.TransitionMsg{To: types.StateSplash}
 			}
 		}
 
-		// Don't process other key events while generating
-		if m.isGenerating {
-			return m, nil
-		}
-
 		if m.showLLMSelector {
-			switch msg.String() {
-			case "esc":
+			switch {
+			case keymap.Matches(msg, keymap.Back):
 				m.showLLMSelector = false
-			case "j", "down":
+			case keymap.Matches(msg, keymap.Down):
 				if m.selectedLLMIndex < len(m.llmList)-1 {
 					m.selectedLLMIndex++
 				}
-			case "k", "up":
+			case keymap.Matches(msg, keymap.Up):
 				if m.selectedLLMIndex > 0 {
 					m.selectedLLMIndex--
 				}
-			case "enter":
+			case keymap.Matches(msg, keymap.Select):
 				// Set selected LLM and hide selector
 				if len(m.llmList) > 0 {
 					m.selectedLLM = m.llmList[m.selectedLLMIndex]
@@ -181,9 +270,38 @@ func (m *ProjectDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.showStatusSelector {
+			return m, m.updateStatusSelector(msg)
+		}
+
+		if m.showExportMenu {
+			return m, m.updateExportMenu(msg)
+		}
+
+		if m.showHistory {
+			switch {
+			case keymap.Matches(msg, keymap.Back), keymap.Matches(msg, keymap.History):
+				m.showHistory = false
+			case keymap.Matches(msg, keymap.Down):
+				if m.selectedCommit < len(m.history)-1 {
+					m.selectedCommit++
+				}
+			case keymap.Matches(msg, keymap.Up):
+				if m.selectedCommit > 0 {
+					m.selectedCommit--
+				}
+			case keymap.Matches(msg, keymap.Select):
+				if m.selectedCommit < len(m.history) {
+					m.showHistory = false
+					return m, m.restoreCommit(m.history[m.selectedCommit])
+				}
+			}
+			return m, nil
+		}
+
 		if m.showOutputViewer {
-			switch msg.String() {
-			case "esc":
+			switch {
+			case keymap.Matches(msg, keymap.Back):
 				m.showOutputViewer = false
 				return m, nil
 			default:
@@ -193,119 +311,121 @@ func (m *ProjectDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		switch msg.String() {
-		case "tab":
+		switch {
+		case keymap.Matches(msg, keymap.NextSection):
 			m.focusArea = (m.focusArea + 1) % 3
-		case "shift+tab":
+		case keymap.Matches(msg, keymap.PrevSection):
 			m.focusArea = (m.focusArea + 2) % 3
-		case "i":
-			switch m.focusArea {
-			case FocusOverview:
-				var prompt, initialValue string
-				var callback func(string)
-
-				switch m.overviewField {
-				case OverviewFieldProjectName:
-					prompt = "Enter Project Name"
-					initialValue = m.overviewProjectName
-					callback = func(value string) {
-						m.overviewProjectName = value
-					}
-				case OverviewFieldResumeInput:
-					prompt = "Enter Resume Input"
-					initialValue = m.resumeInput
-					callback = func(value string) {
-						m.resumeInput = value
-					}
-				}
-
-				if callback != nil {
+		case keymap.Matches(msg, keymap.Input):
+			if m.focusArea == FocusOverview && m.overviewField == OverviewFieldPageLimit {
+				return m, m.promptPageLimit()
+			}
+			return m, m.inputFocusedField()
+		case keymap.Matches(msg, keymap.Editor):
+			return m, m.editFocusedField()
+		case keymap.Matches(msg, keymap.Import):
+			if m.focusArea == FocusOverview && m.overviewField == OverviewFieldResumeInput {
+				return m, m.promptResumeImport()
+			}
+			if m.canImportJob() {
+				return m, m.promptJobImport()
+			}
+		case keymap.Matches(msg, keymap.PasteJob):
+			if m.canImportJob() {
+				return m, m.pasteJob()
+			}
+		case keymap.Matches(msg, keymap.Reimport):
+			if m.focusArea == FocusOverview && m.overviewField == OverviewFieldResumeInput {
+				if m.resumeSource == "" {
 					return m, func() tea.Msg {
-						return types.ShowFloatInputMsg{
-							Prompt:       prompt,
-							InitialValue: initialValue,
-							Callback:     callback,
-						}
+						return types.ErrorMsg{Error: errors.New("resume was not imported from a file, press I to import one")}
 					}
 				}
-			case FocusJob:
-				var prompt, initialValue string
-				var callback func(string)
-
-				current := &m.outputs[m.selectedOutputIndex]
-				switch m.jobField {
-				case JobFieldName:
-					prompt = "Enter Job Name"
-					initialValue = current.Name
-					callback = func(value string) {
-						current.Name = value
-					}
-				case JobFieldDescription:
-					prompt = "Enter Job Description"
-					initialValue = current.JobDescription
-					callback = func(value string) {
-						current.JobDescription = value
-					}
-				}
-
-				if callback != nil {
-					return m, func() tea.Msg {
-						return types.ShowFloatInputMsg{
-							Prompt:       prompt,
-							InitialValue: initialValue,
-							Callback:     callback,
-						}
-					}
+				if jsonresume.IsJSON(m.resumeSource) {
+					return m, m.importJSONResume(m.resumeSource, m.resumeTemplate)
 				}
+				return m, m.importResume(m.resumeSource)
+			}
+		case keymap.Matches(msg, keymap.RegenerateStale):
+			if m.focusArea == FocusOutputs {
+				return m, m.regenerateStale()
+			}
+		case keymap.Matches(msg, keymap.Sort):
+			if m.focusArea == FocusOutputs {
+				m.outputSort = (m.outputSort + 1) % outputSortCount
+			}
+		case keymap.Matches(msg, keymap.CycleFilter):
+			if m.focusArea == FocusOutputs {
+				m.cycleOutputFilter()
+			}
+		case keymap.Matches(msg, keymap.SetStatus):
+			if m.focusArea != FocusOverview {
+				m.openStatusSelector()
+			}
+		case keymap.Matches(msg, keymap.AddNote):
+			if m.focusArea != FocusOverview {
+				return m, m.promptApplicationNote()
 			}
-		case "a":
+		case keymap.Matches(msg, keymap.Board):
+			return m, m.openBoard()
+		case keymap.Matches(msg, keymap.Preview):
+			if m.focusArea != FocusOverview && len(m.outputs) > 0 {
+				return m, m.exportCurrentOutput(exportChoices()[0])
+			}
+		case keymap.Matches(msg, keymap.Add):
 			if m.focusArea == FocusOutputs {
 				newOutput := types.Output{
-					Name:           "New Output",
+					Name:           defaultOutputName,
 					JobDescription: "",
 				}
 				m.outputs = append(m.outputs, newOutput)
 				m.selectedOutputIndex = len(m.outputs) - 1
-				return m, m.saveProjectConfig
+				return m, m.saveProjectConfig()
 			}
-		case "l", "enter":
+		case keymap.Matches(msg, keymap.Right), keymap.Matches(msg, keymap.Select):
 			if m.focusArea == FocusOverview && m.overviewField == OverviewFieldLLM {
 				m.showLLMSelector = true
 				m.llmList = m.projects.GetModels()
 				return m, nil
 			}
-		case "j", "down":
+		case keymap.Matches(msg, keymap.Down):
 			switch m.focusArea {
 			case FocusOverview:
-				if m.overviewField < OverviewFieldLLM {
+				if m.overviewField < OverviewFieldPageLimit {
 					m.overviewField++
 				}
 			case FocusOutputs:
-				if m.selectedOutputIndex < len(m.outputs)-1 {
-					m.selectedOutputIndex++
-				}
+				m.moveOutputSelection(1)
 			case FocusJob:
-				if m.jobField < JobFieldSavePDF {
+				if m.jobField < JobFieldExport {
 					m.jobField++
 				}
 			}
-		case "k", "up":
+		case keymap.Matches(msg, keymap.Up):
 			switch m.focusArea {
 			case FocusOverview:
 				if m.overviewField > 0 {
 					m.overviewField--
 				}
 			case FocusOutputs:
-				if m.selectedOutputIndex > 0 {
-					m.selectedOutputIndex--
-				}
+				m.moveOutputSelection(-1)
 			case FocusJob:
 				if m.jobField > JobFieldName {
 					m.jobField--
 				}
 			}
-		case "ctrl+s":
-			return m, m.saveProjectConfig
+		case keymap.Matches(msg, keymap.Save):
+			return m, m.saveProjectConfig()
+		case keymap.Matches(msg, keymap.Batch):
+			return m, m.promptBatch()
+		case keymap.Matches(msg, keymap.Compare):
+			if m.focusArea != FocusOverview {
+				return m, m.compareModels()
+			}
+		case keymap.Matches(msg, keymap.EnableHistory):
+			return m, m.enableHistory
+		case keymap.Matches(msg, keymap.History):
+			return m, m.openHistory()
 		}
 
 		if m.focusArea == FocusJob {
@@ -313,55 +433,29 @@ func (m *ProjectDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				break
 			}
 
-			switch msg.String() {
-			case "i":
-				var prompt, initialValue string
-				var callback func(string)
-
-				current := &m.outputs[m.selectedOutputIndex]
-				switch m.jobField {
-				case JobFieldName:
-					prompt = "Enter Job Name"
-					initialValue = current.Name
-					callback = func(value string) {
-						current.Name = value
-					}
-				case JobFieldDescription:
-					prompt = "Enter Job Description"
-					initialValue = current.JobDescription
-					callback = func(value string) {
-						current.JobDescription = value
-					}
-				}
-
-				if callback != nil {
-					return m, func() tea.Msg {
-						return types.ShowFloatInputMsg{
-							Prompt:       prompt,
-							InitialValue: initialValue,
-							Callback:     callback,
-						}
-					}
+			switch {
+			case keymap.Matches(msg, keymap.SwitchTab):
+				m.jobTab = (m.jobTab + 1) % 2
+			case keymap.Matches(msg, keymap.Extract):
+				return m, m.extractJobDetails()
+			case keymap.Matches(msg, keymap.Refine):
+				return m, m.refineJobDetails()
+			case keymap.Matches(msg, keymap.Select):
+				if m.jobTab == JobTabCoverLetter {
+					return m, m.coverLetterAction()
 				}
-
-			case "enter":
 				switch m.jobField {
 				case JobFieldGenerate:
 					currentOutput := m.outputs[m.selectedOutputIndex]
 					debugLog.Println("Generate button pressed")
-					cmd := m.generateResume(currentOutput.JobDescription)
-					if cmd, ok := cmd.(func() tea.Msg); ok {
-						debugLog.Println("Executing generate command")
-						return m, cmd
-					}
-					debugLog.Println("Failed to get generate command")
-					return m, nil
-				case JobFieldSavePDF:
-					return m, m.saveCurrentOutputToPDF
+					return m, m.generateResume(currentOutput.JobDescription, currentOutput.Job)
+				case JobFieldExport:
+					m.openExportMenu()
 				case JobFieldOutput:
 					if len(m.outputs) > 0 {
 						current := m.outputs[m.selectedOutputIndex]
 						m.outputViewer.SetValue(current.GeneratedOutput)
+						m.outputViewerTitle = "Generated Output"
 						m.showOutputViewer = true
 					}
 				}
@@ -372,20 +466,22 @@ func (m *ProjectDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *ProjectDetailModel) View() string {
-	if m.isGenerating {
-		return lipgloss.Place(
-			m.width,
-			m.height,
-			lipgloss.Center,
-			lipgloss.Center,
-			ui.FloatBox.Render("Generating resume...\nPlease stand by..."),
-		)
-	}
-
 	if m.showLLMSelector {
 		return m.renderLLMSelector()
 	}
 
+	if m.showStatusSelector {
+		return m.renderStatusSelector()
+	}
+
+	if m.showExportMenu {
+		return m.renderExportMenu()
+	}
+
+	if m.showHistory {
+		return m.renderHistory()
+	}
+
 	if m.showOutputViewer {
 		return lipgloss.Place(
 			m.width,
@@ -393,7 +489,7 @@ func (m *ProjectDetailModel) View() string {
 			lipgloss.Center,
 			lipgloss.Center,
 			ui.FloatBox.Render(
-				"Generated Output (ESC to close)\n\n"+
+				m.outputViewerTitle+" ("+keymap.HelpLine(keymap.Get(keymap.Back).Describe(keymap.Back, "close"))+")\n\n"+
 					m.outputViewer.View(),
 			),
 		)
@@ -414,11 +510,125 @@ func (m *ProjectDetailModel) View() string {
 	rightSection := ui.BaseDetails.Width(rightWidth).Render(jobView)
 
 	mainView := lipgloss.JoinHorizontal(lipgloss.Top, leftSection, rightSection)
-	help := ui.Help.Render("tab: switch section • j/k: navigate • i: input • enter: action • ctrl+s: save")
+	helpText := keymap.HelpLine(m.Bindings())
+	if m.pendingGenerations > 0 {
+		helpText = fmt.Sprintf("%d generation(s) running in the background • ", m.pendingGenerations) + helpText
+	}
+	if m.resumeNotice != "" {
+		helpText = m.resumeNotice + "\n" + helpText
+	}
+	help := ui.Help.Render(helpText)
 	joined := ui.JoinedContainer.Render(lipgloss.JoinVertical(lipgloss.Left, mainView, help))
 	return joined
 }
 
+// Bindings returns the actions available in the focused section, or in the
+// overlay shown over the project.
+func (m *ProjectDetailModel) Bindings() keymap.Bindings {
+	switch {
+	case m.showLLMSelector:
+		return menuBindings("select")
+	case m.showStatusSelector:
+		return menuBindings("set status")
+	case m.showExportMenu:
+		return menuBindings("export")
+	case m.showHistory:
+		return menuBindings("restore")
+	case m.showOutputViewer:
+		return keymap.Get(keymap.Back).Describe(keymap.Back, "close")
+	}
+
+	actions := []keymap.Action{keymap.NextSection, keymap.PrevSection, keymap.Down, keymap.Up,
+		keymap.Input, keymap.Editor, keymap.Import, keymap.Reimport}
+	selectHelp := m.selectHelp()
+	choosingModel := m.focusArea == FocusOverview && m.overviewField == OverviewFieldLLM
+	if choosingModel {
+		actions = append(actions, keymap.Right)
+	}
+	if selectHelp != "" {
+		actions = append(actions, keymap.Select)
+	}
+	if m.focusArea == FocusOutputs {
+		actions = append(actions, keymap.Add)
+	}
+	actions = append(actions, keymap.Save, keymap.Batch)
+	if vcs.IsRepo(m.projectDir) {
+		actions = append(actions, keymap.History)
+	} else {
+		actions = append(actions, keymap.EnableHistory)
+	}
+	if m.focusArea != FocusOverview && len(m.outputs) > 0 {
+		actions = append(actions, keymap.Compare, keymap.SetStatus, keymap.AddNote, keymap.Preview)
+	}
+	if m.focusArea == FocusOutputs && len(m.outputs) > 1 {
+		actions = append(actions, keymap.Sort, keymap.CycleFilter)
+	}
+	actions = append(actions, keymap.Board)
+	if m.focusArea == FocusJob {
+		actions = append(actions, keymap.SwitchTab, keymap.Extract, keymap.Refine)
+	}
+	if m.canImportJob() {
+		actions = append(actions, keymap.PasteJob)
+	}
+	if len(m.staleOutputs()) > 0 {
+		actions = append(actions, keymap.RegenerateStale)
+	}
+	bindings := keymap.Get(append(actions, keymap.Close, keymap.Palette, keymap.Help)...).
+		Describe(keymap.Select, selectHelp).
+		Describe(keymap.Add, "add output").
+		Describe(keymap.Batch, "batch generate")
+	if choosingModel {
+		bindings = bindings.Describe(keymap.Right, selectHelp)
+	}
+	if m.canImportJob() {
+		bindings = bindings.Describe(keymap.Import, "import job")
+	}
+	if _, err := os.Stat(filepath.Join(m.projectDir, batch.QueueFile)); err == nil {
+		bindings = bindings.Describe(keymap.Batch, "open batch")
+	}
+	return bindings
+}
+
+// selectHelp describes what enter does on the focused field, or returns ""
+// when it does nothing there.
+func (m *ProjectDetailModel) selectHelp() string {
+	switch {
+	case m.focusArea == FocusOverview && m.overviewField == OverviewFieldLLM:
+		return "choose model"
+	case m.focusArea != FocusJob || len(m.outputs) == 0:
+		return ""
+	}
+	document := "resume"
+	if m.jobTab == JobTabCoverLetter {
+		document = "cover letter"
+	}
+	switch m.jobField {
+	case JobFieldOutput:
+		return "view " + document
+	case JobFieldGenerate:
+		return "generate " + document
+	case JobFieldExport:
+		if m.jobTab == JobTabCoverLetter {
+			return "compile cover letter"
+		}
+		return "export resume"
+	}
+	return ""
+}
+
+// typing reports whether keys go to the output viewer.
+func (m *ProjectDetailModel) typing() bool {
+	return m.showOutputViewer
+}
+
+// menuBindings returns the actions of a list overlay, confirmed with the
+// help selectHelp.
+func menuBindings(selectHelp string) keymap.Bindings {
+	return keymap.Get(keymap.Down, keymap.Up, keymap.Select, keymap.Back).
+		Describe(keymap.Select, selectHelp).
+		Describe(keymap.Back, "cancel")
+}
+
 func (m *ProjectDetailModel) renderOverviewSection() string {
 	title := ui.Title.Render("Project Overview")
 	nameField := "Project Name: " + m.overviewProjectName
@@ -429,6 +639,13 @@ func (m *ProjectDetailModel) renderOverviewSection() string {
 		resumePreview = resumePreview[:10] + "..."
 	}
 	resumeField := "Resume Input: " + resumePreview
+	if m.resumeSource != "" {
+		resumeField += " (from " + filepath.Base(m.resumeSource)
+		if m.resumeTemplate != "" {
+			resumeField += ", " + filepath.Base(m.resumeTemplate) + " template"
+		}
+		resumeField += ")"
+	}
 
 	llmField := "LLM: "
 	if len(m.llmOptions) > 0 {
@@ -437,6 +654,11 @@ func (m *ProjectDetailModel) renderOverviewSection() string {
 		llmField += "None"
 	}
 
+	pageLimitField := "Page Limit: none"
+	if m.pageLimit > 0 {
+		pageLimitField = fmt.Sprintf("Page Limit: %d", m.pageLimit)
+	}
+
 	// Highlight the active field if the overview section has focus
 	if m.focusArea == FocusOverview {
 		switch m.overviewField {
@@ -446,21 +668,45 @@ func (m *ProjectDetailModel) renderOverviewSection() string {
 			resumeField = ui.SelectedItem.Render("► " + resumeField)
 		case OverviewFieldLLM:
 			llmField = ui.SelectedItem.Render("► " + llmField)
+		case OverviewFieldPageLimit:
+			pageLimitField = ui.SelectedItem.Render("► " + pageLimitField)
 		}
 	}
 
-	fields := []string{nameField, resumeField, llmField}
+	fields := []string{nameField, resumeField, llmField, pageLimitField}
 	return title + "\n" + strings.Join(fields, "\n")
 }
 
 func (m *ProjectDetailModel) renderOutputsSection() string {
+	var shown []string
+	if m.outputSort != OutputSortAdded {
+		shown = append(shown, "by "+m.outputSort.String())
+	}
+	if m.outputFilter != filterAll {
+		shown = append(shown, m.outputFilter)
+	}
 	title := ui.Title.Render("Outputs")
+	if len(shown) > 0 {
+		title = ui.Title.Render(fmt.Sprintf("Outputs (%s)", strings.Join(shown, ", ")))
+	}
+	order := m.outputOrder()
 	var outputLines []string
-	if len(m.outputs) == 0 {
+	switch {
+	case len(m.outputs) == 0:
 		outputLines = append(outputLines, "No outputs. Press 'a' to add.")
-	} else {
-		for i, out := range m.outputs {
+	case len(order) == 0:
+		outputLines = append(outputLines, "No "+m.outputFilter+" outputs. Press 'f' to change the filter.")
+	default:
+		current := m.resumeFingerprint()
+		for _, i := range order {
+			out := m.outputs[i]
 			line := out.Name
+			if _, stale := out.StaleSections(current); stale {
+				line += " (stale)"
+			}
+			if status := out.Application.CurrentStatus(); status != types.StatusDrafted {
+				line += " " + applicationStyle(status).Render("["+string(status)+"]")
+			}
 			if i == m.selectedOutputIndex && m.focusArea == FocusOutputs {
 				line = ui.SelectedItem.Render("► " + line)
 			} else {
@@ -495,7 +741,24 @@ func (m *ProjectDetailModel) renderJobSection() string {
 	descField := "Job Description: " + descPreview
 	outputField := "View Generated Output"
 	generateButton := "[ Generate ]"
-	saveButton := "[ Save to PDF ]"
+	saveButton := "[ Export ]"
+
+	tabs := []string{"Resume", "Cover Letter"}
+	for i := range tabs {
+		if i == m.jobTab {
+			tabs[i] = ui.SelectedItem.Render("[" + tabs[i] + "]")
+		} else {
+			tabs[i] = " " + tabs[i] + " "
+		}
+	}
+	if m.jobTab == JobTabCoverLetter {
+		outputField = "View Cover Letter"
+		if currentOutput.CoverLetter == "" {
+			outputField += " (none yet)"
+		}
+		generateButton = "[ Generate Letter ]"
+		saveButton = "[ Save Letter to PDF ]"
+	}
 
 	// Highlight the active element in the job-specific section.
 	if m.focusArea == FocusJob {
@@ -508,48 +771,416 @@ func (m *ProjectDetailModel) renderJobSection() string {
 			outputField = ui.SelectedItem.Render("► " + outputField)
 		case JobFieldGenerate:
 			generateButton = ui.SelectedItem.Render("► " + generateButton)
-		case JobFieldSavePDF:
+		case JobFieldExport:
 			saveButton = ui.SelectedItem.Render("► " + saveButton)
 		}
 	}
 
-	content := title + "\n" + nameField + "\n" + descField + "\n" + outputField + "\n\n" + generateButton + "    " + saveButton
+	content := title + "\n" + strings.Join(tabs, " ") + "\n\n" + nameField + "\n" + descField + "\n" + outputField + "\n\n" + generateButton + "    " + saveButton
+	if details := renderJobDetails(currentOutput, max(m.width-m.width/2-8, 20)); details != "" {
+		content += "\n\n" + details
+	}
+	if len(m.outputs) > 0 {
+		content += "\n\n" + renderApplication(currentOutput.Application, 3)
+	}
+	if m.lastExport != "" && currentOutput.Slug != "" && filepath.Dir(m.lastExport) == types.OutputDir(m.projectDir, currentOutput.Slug) {
+		content += "\n\n" + ui.StatusDone.Render("Exported to "+m.lastExport)
+	}
+	if changed, stale := currentOutput.StaleSections(m.resumeFingerprint()); stale {
+		note := "Stale: base resume changed since generation"
+		if len(changed) > 0 {
+			note += " (" + strings.Join(changed, ", ") + ")"
+		}
+		content += "\n\n" + ui.ErrorTitle.Render(note)
+	}
 	return content
 }
 
-// saveProjectConfig constructs a ProjectConfig and writes it to the project's config file.
-func (m *ProjectDetailModel) saveProjectConfig() tea.Msg {
+// saveProjectConfig writes the project config without a specific history
+// message.
+func (m *ProjectDetailModel) saveProjectConfig() tea.Cmd {
+	return m.saveWithMessage("Update project configuration")
+}
+
+// saveWithMessage returns the command writing the project config and, for
+// projects with history, committing it with the given message. It must be
+// called from Update: the command works on a snapshot, so later edits do not
+// race with the write.
+func (m *ProjectDetailModel) saveWithMessage(message string) tea.Cmd {
+	config := m.projectConfig()
+	projectDir := m.projectDir
+	return func() tea.Msg {
+		if err := types.SaveProjectConfig(projectDir, config); err != nil {
+			return types.ErrorMsg{Error: fmt.Errorf("failed to save project config: %w", err)}
+		}
+		return commitProject(projectDir, message)
+	}
+}
+
+// projectConfig returns the project config as edited, with its own copy of
+// the outputs. New outputs are given their slug here, so saving in the
+// background writes nothing the screen reads.
+func (m *ProjectDetailModel) projectConfig() types.ProjectConfig {
+	types.AssignSlugs(m.outputs)
 	config := types.ProjectConfig{
-		Name:        m.overviewProjectName,
-		Model:       "",
-		ResumeInput: m.resumeInput,
-		Outputs:     m.outputs,
+		Name:           m.overviewProjectName,
+		Model:          "",
+		ResumeInput:    m.resumeInput,
+		ResumeSource:   m.resumeSource,
+		ResumeTemplate: m.resumeTemplate,
+		Engine:         m.engine,
+		PageLimit:      m.pageLimit,
+		Outputs:        slices.Clone(m.outputs),
 	}
 	if len(m.llmOptions) > 0 {
 		config.Model = m.llmOptions[m.selectedLLMIndex].Name
 	}
-	err := types.SaveProjectConfig(m.projectDir, config)
-	if err != nil {
-		return types.ErrorMsg{Error: fmt.Errorf("failed to save project config: %w", err)}
+	return config
+}
+
+// inputFocusedField opens the floating input for the focused text field.
+func (m *ProjectDetailModel) inputFocusedField() tea.Cmd {
+	var prompt, initialValue string
+	var multiline bool
+	var callback func(string)
+
+	switch m.focusArea {
+	case FocusOverview:
+		switch m.overviewField {
+		case OverviewFieldProjectName:
+			prompt = "Enter Project Name"
+			initialValue = m.overviewProjectName
+			callback = func(value string) {
+				m.overviewProjectName = value
+			}
+		case OverviewFieldResumeInput:
+			prompt = "Enter Resume Input"
+			initialValue = m.resumeInput
+			multiline = true
+			callback = func(value string) {
+				m.resumeInput = value
+			}
+		}
+	case FocusJob:
+		if len(m.outputs) == 0 {
+			return nil
+		}
+		current := m.outputs[m.selectedOutputIndex]
+		switch m.jobField {
+		case JobFieldName:
+			prompt = "Enter Job Name"
+			initialValue = current.Name
+			callback = m.editSelectedOutput(func(out *types.Output, value string) {
+				out.Name = value
+			})
+		case JobFieldDescription:
+			prompt = "Enter Job Description"
+			initialValue = current.JobDescription
+			multiline = true
+			callback = m.editSelectedOutput(setJobDescription)
+		case JobFieldOutput:
+			if m.jobTab == JobTabCoverLetter {
+				prompt = "Edit Cover Letter"
+				initialValue = current.CoverLetter
+				multiline = true
+				callback = m.editSelectedOutput(func(out *types.Output, value string) {
+					out.CoverLetter = value
+				})
+			}
+		}
+	}
+
+	if callback == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return types.ShowFloatInputMsg{
+			Prompt:       prompt,
+			InitialValue: initialValue,
+			Multiline:    multiline,
+			Callback:     callback,
+		}
+	}
+}
+
+// editFocusedField opens the focused text field in the external editor.
+func (m *ProjectDetailModel) editFocusedField() tea.Cmd {
+	switch m.focusArea {
+	case FocusOverview:
+		switch m.overviewField {
+		case OverviewFieldProjectName:
+			return openInEditor(m.overviewProjectName, ".txt", func(value string) {
+				m.overviewProjectName = strings.TrimSpace(value)
+			})
+		case OverviewFieldResumeInput:
+			return openInEditor(m.resumeInput, ".tex", func(value string) {
+				m.resumeInput = value
+			})
+		}
+	case FocusJob:
+		if len(m.outputs) == 0 {
+			return nil
+		}
+		current := m.outputs[m.selectedOutputIndex]
+		switch m.jobField {
+		case JobFieldName:
+			return openInEditor(current.Name, ".txt", m.editSelectedOutput(func(out *types.Output, value string) {
+				out.Name = strings.TrimSpace(value)
+			}))
+		case JobFieldDescription:
+			return openInEditor(current.JobDescription, ".md", m.editSelectedOutput(setJobDescription))
+		case JobFieldOutput:
+			if m.jobTab == JobTabCoverLetter {
+				return openInEditor(current.CoverLetter, ".txt", m.editSelectedOutput(func(out *types.Output, value string) {
+					out.CoverLetter = value
+				}))
+			}
+			return openInEditor(current.GeneratedOutput, ".tex", m.editSelectedOutput(func(out *types.Output, value string) {
+				out.GeneratedOutput = value
+			}))
+		}
 	}
 	return nil
 }
 
-// saveCurrentOutputToPDF simulates generating a PDF by writing the generated output
-// to a file in the project directory.
-func (m *ProjectDetailModel) saveCurrentOutputToPDF() tea.Msg {
-	if len(m.outputs) == 0 {
-		return types.ErrorMsg{Error: fmt.Errorf("no output to save")}
+// editSelectedOutput returns a callback applying edit to the selected output
+// once the edit is confirmed. The output is looked up by slug then, since
+// generations and reloads replace m.outputs while a field is being edited.
+// Edits of outputs removed meanwhile are dropped.
+func (m *ProjectDetailModel) editSelectedOutput(edit func(out *types.Output, value string)) func(string) {
+	types.AssignSlugs(m.outputs)
+	slug := m.outputs[m.selectedOutputIndex].Slug
+	return func(value string) {
+		if i := m.outputIndex(slug); i >= 0 {
+			edit(&m.outputs[i], value)
+		}
 	}
-	current := m.outputs[m.selectedOutputIndex]
-	pdfPath := filepath.Join(m.projectDir, current.Name+".pdf")
-	err := os.WriteFile(pdfPath, []byte(current.GeneratedOutput), 0644)
+}
+
+// outputIndex returns the index of the output with slug, or -1.
+func (m *ProjectDetailModel) outputIndex(slug string) int {
+	return slices.IndexFunc(m.outputs, func(out types.Output) bool { return out.Slug == slug })
+}
+
+// promptResumeImport asks for a .tex file, directory or resume.json to
+// import the base resume from.
+func (m *ProjectDetailModel) promptResumeImport() tea.Cmd {
+	return func() tea.Msg {
+		return types.ShowFloatInputMsg{
+			Prompt:       "Import resume from .tex file, directory or resume.json",
+			InitialValue: m.resumeSource,
+			Submit: func(value string) tea.Cmd {
+				source := config.ExpandHome(strings.TrimSpace(value))
+				if jsonresume.IsJSON(source) {
+					return m.promptResumeTemplate(source)
+				}
+				return m.importResume(source)
+			},
+		}
+	}
+}
+
+// resumeImportedMsg carries a base resume imported in the background.
+type resumeImportedMsg struct {
+	projectDir string
+	// source is the absolute path imported from, template the template a
+	// JSON Resume was rendered with.
+	source   string
+	template string
+	tex      string
+	// bundle is the imported LaTeX, nil for JSON Resumes, and stamp the
+	// modification time the source is watched from.
+	bundle  *latex.Bundle
+	stamp   time.Time
+	message string
+}
+
+// importResume loads the resume at source in the background, flattening its
+// includes and copying the files it depends on into the project. The project
+// is saved once the result arrives.
+func (m *ProjectDetailModel) importResume(source string) tea.Cmd {
+	projectDir := m.projectDir
+	return func() tea.Msg {
+		bundle, err := latex.Load(source)
+		if err != nil {
+			return types.ErrorMsg{Error: fmt.Errorf("failed to import resume: %w", err)}
+		}
+		// Never let an asset overwrite the files that make up the project itself.
+		if err := bundle.CopyAssets(projectDir, types.ProjectConfigFile, types.DefaultResumeFile); err != nil {
+			return types.ErrorMsg{Error: fmt.Errorf("failed to copy resume files: %w", err)}
+		}
+		abs, err := filepath.Abs(source)
+		if err != nil {
+			abs = source
+		}
+		return resumeImportedMsg{
+			projectDir: projectDir,
+			source:     abs,
+			tex:        bundle.Source,
+			bundle:     bundle,
+			stamp:      bundle.ModTime(),
+			message:    "Import resume from " + abs,
+		}
+	}
+}
+
+// applyResumeImport makes an imported resume the base resume and saves the
+// project.
+func (m *ProjectDetailModel) applyResumeImport(msg resumeImportedMsg) tea.Cmd {
+	if msg.projectDir != m.projectDir {
+		return nil
+	}
+	m.resumeInput = msg.tex
+	m.resumeSource = msg.source
+	m.resumeTemplate = msg.template
+	m.watchBundle = msg.bundle
+	m.watchStamp = msg.stamp
+	return m.saveWithMessage(msg.message)
+}
+
+// commitProject records the current state of a version-controlled project
+// directory. Projects without history are left alone.
+func commitProject(projectDir, message string) tea.Msg {
+	if err := vcs.CommitIfEnabled(projectDir, message); err != nil {
+		return types.ErrorMsg{Error: fmt.Errorf("failed to commit project history: %w", err)}
+	}
+	return nil
+}
+
+// enableHistory puts the project directory under git version control.
+func (m *ProjectDetailModel) enableHistory() tea.Msg {
+	if vcs.IsRepo(m.projectDir) {
+		return nil
+	}
+	if _, err := vcs.Init(m.projectDir); err != nil {
+		return types.ErrorMsg{Error: fmt.Errorf("failed to enable project history: %w", err)}
+	}
+	return nil
+}
+
+// openHistory loads the project's commit log and shows the history view.
+func (m *ProjectDetailModel) openHistory() tea.Cmd {
+	repo := vcs.Open(m.projectDir)
+	if repo == nil {
+		return func() tea.Msg {
+			return types.ErrorMsg{Error: errors.New("project history is not enabled, press G to enable it")}
+		}
+	}
+	history, err := repo.Log(100)
 	if err != nil {
-		return types.ErrorMsg{Error: fmt.Errorf("failed to save PDF: %w", err)}
+		return func() tea.Msg {
+			return types.ErrorMsg{Error: fmt.Errorf("failed to read project history: %w", err)}
+		}
 	}
+	m.history = history
+	m.selectedCommit = 0
+	m.showHistory = true
 	return nil
 }
 
+// projectRestoredMsg carries the project config as restored from a commit.
+// committed is the result of recording the restore in the history, an
+// ErrorMsg or nil.
+type projectRestoredMsg struct {
+	projectDir string
+	commit     string
+	config     types.ProjectConfig
+	committed  tea.Msg
+}
+
+// restoreCommit brings back the project files as they were at the given
+// commit in the background, then shows them. Running generations would save
+// their outputs over the restored ones, so restoring waits for them.
+func (m *ProjectDetailModel) restoreCommit(c vcs.Commit) tea.Cmd {
+	if m.pendingGenerations > 0 {
+		err := fmt.Errorf("%d generation(s) still running for this project, restore once they finish", m.pendingGenerations)
+		return func() tea.Msg { return types.ErrorMsg{Error: err} }
+	}
+	projectDir := m.projectDir
+	return func() tea.Msg {
+		repo := vcs.Open(projectDir)
+		if repo == nil {
+			return nil
+		}
+		if err := repo.Restore(c.Hash, "."); err != nil {
+			return types.ErrorMsg{Error: fmt.Errorf("failed to restore project: %w", err)}
+		}
+		config, err := types.LoadProjectConfig(projectDir)
+		if err != nil {
+			return types.ErrorMsg{Error: err}
+		}
+		return projectRestoredMsg{
+			projectDir: projectDir,
+			commit:     c.ShortHash,
+			config:     config,
+			committed:  commitProject(projectDir, fmt.Sprintf("Restore project from %s", c.ShortHash)),
+		}
+	}
+}
+
+// applyConfig replaces the editable state of the screen with config.
+func (m *ProjectDetailModel) applyConfig(config types.ProjectConfig) {
+	m.overviewProjectName = config.Name
+	m.resumeInput = config.ResumeInput
+	m.resumeSource = config.ResumeSource
+	m.resumeTemplate = config.ResumeTemplate
+	m.engine = config.Engine
+	m.pageLimit = config.PageLimit
+	m.outputs = config.Outputs
+	if m.selectedOutputIndex >= len(m.outputs) {
+		m.selectedOutputIndex = 0
+	}
+	for i, model := range m.llmOptions {
+		if model.Name == config.Model {
+			m.selectedLLMIndex = i
+			break
+		}
+	}
+}
+
+// reloadOutputs picks up outputs added on disk, such as by a running batch.
+// Outputs already shown are kept as they are so unsaved edits survive.
+func (m *ProjectDetailModel) reloadOutputs() {
+	config, err := types.LoadProjectConfig(m.projectDir)
+	if err != nil {
+		return
+	}
+	known := make(map[string]bool, len(m.outputs))
+	for _, out := range m.outputs {
+		known[out.Slug] = true
+	}
+	for _, out := range config.Outputs {
+		if !known[out.Slug] {
+			m.outputs = append(m.outputs, out)
+		}
+	}
+}
+
+func (m *ProjectDetailModel) renderHistory() string {
+	content := ui.Title.Render("Project History") + "\n\n"
+	if len(m.history) == 0 {
+		content += "No commits yet\n"
+	}
+	for i, c := range m.history {
+		item := fmt.Sprintf("%s  %s  %s", c.ShortHash, c.Date.Format("2006-01-02 15:04"), c.Subject)
+		if i == m.selectedCommit {
+			item = ui.SelectedItem.Render("► " + item)
+		} else {
+			item = "  " + item
+		}
+		content += item + "\n"
+	}
+	content += "\n" + ui.Help.Render(keymap.HelpLine(menuBindings("restore")))
+
+	return lipgloss.Place(
+		m.width,
+		m.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		ui.FloatBox.Width(90).Render(content),
+	)
+}
+
 func (m *ProjectDetailModel) renderLLMSelector() string {
 	content := ui.Title.Render("Select LLM Model") + "\n\n"
 	for i, model := range m.llmList {
@@ -561,7 +1192,7 @@ func (m *ProjectDetailModel) renderLLMSelector() string {
 		}
 		content += item + "\n"
 	}
-	content += "\n" + ui.Help.Render("↑/↓: Navigate • enter: Select • esc: Cancel")
+	content += "\n" + ui.Help.Render(keymap.HelpLine(menuBindings("select")))
 
 	return lipgloss.Place(
 		m.width,
@@ -589,115 +1220,43 @@ type GollmResponse struct {
 	} `json:"choices"`
 }
 
-// Add this method to ProjectDetailModel
-func (m *ProjectDetailModel) generateResume(jobDescription string) tea.Msg {
-	debugLog.Println("Starting generateResume")
-
-	if m.isGenerating {
-		debugLog.Println("Already generating, returning nil")
-		return nil
-	}
-
-	m.isGenerating = true
-	return func() tea.Msg {
-		defer func() {
-			m.isGenerating = false
-		}()
-
-		debugLog.Println("Checking LLM options")
-		if len(m.llmOptions) == 0 || m.selectedLLMIndex >= len(m.llmOptions) {
+// generateResume queues the generation of a new output for jobDescription.
+// The LLM is called in the background; the output is added once it
+// finishes, even if the project was closed in the meantime.
+func (m *ProjectDetailModel) generateResume(jobDescription string, job types.JobMetadata) tea.Cmd {
+	if len(m.llmOptions) == 0 || m.selectedLLMIndex >= len(m.llmOptions) {
+		return func() tea.Msg {
 			return types.ErrorMsg{Error: errors.New("no LLM model selected")}
 		}
-
-		selectedModel := m.llmOptions[m.selectedLLMIndex]
-		debugLog.Printf("Selected model: %+v", selectedModel)
-
-		debugLog.Println("Creating LLM instance")
-		llm, err := gollm.NewLLM(
-			gollm.SetProvider(selectedModel.Provider),
-			gollm.SetModel(selectedModel.Model),
-			gollm.SetAPIKey(selectedModel.APIKey),
-			gollm.SetTimeout(99*time.Minute),
-			gollm.SetMaxTokens(100000), // Increase max response length
-			gollm.SetTemperature(0.7),  // Adjust creativity (0.0-1.0)
-			gollm.SetMemory(100000),    // Increase context window
-		)
-		if err != nil {
-			debugLog.Printf("LLM creation error: %v", err)
-			return types.ErrorMsg{Error: fmt.Errorf("failed to create LLM instance: %w", err)}
-		}
-
-		ctx, cancel := context.WithTimeout(context.Background(), 99*time.Minute)
-		defer cancel()
-
-		debugLog.Println("Preparing prompt")
-		promptText := `You are a professional resume writer. Your task is to modify the given resume to better target a specific job description.
-Follow these rules:
-1. Keep the same LaTeX format
-2. Highlight relevant skills and experiences
-3. Use keywords from the job description
-4. Be concise and professional
-5. Do not invent new experiences
-
-Original Resume:
-%s
-
-Job Description:
-%s
-
-Please provide the modified resume in LaTeX format.`
-
-		promptText = fmt.Sprintf(promptText, m.resumeInput, jobDescription)
-		prompt := gollm.NewPrompt(promptText)
-
-		debugLog.Println("Calling LLM Generate")
-		response, err := llm.Generate(ctx, prompt)
-		if err != nil {
-			debugLog.Printf("Generation error: %v", err)
-			// Handle the error based on the error message
-			errMsg := err.Error()
-			switch {
-			case strings.Contains(errMsg, "invalid API key"):
-				return types.ErrorMsg{Error: fmt.Errorf("invalid API key for %s", selectedModel.Provider)}
-			case strings.Contains(errMsg, "rate limit"):
-				return types.ErrorMsg{Error: fmt.Errorf("rate limit exceeded for %s", selectedModel.Provider)}
-			case strings.Contains(errMsg, "model not found"):
-				return types.ErrorMsg{Error: fmt.Errorf("model %s not found for %s", selectedModel.Model, selectedModel.Provider)}
-			case strings.Contains(errMsg, "connection refused"):
-				return types.ErrorMsg{Error: fmt.Errorf("connection to %s failed - is the service running?", selectedModel.Provider)}
-			default:
-				return types.ErrorMsg{Error: fmt.Errorf("LLM error: %w", err)}
-			}
-		}
-
-		if response == "" {
-			return types.ErrorMsg{Error: errors.New("received empty response from LLM")}
-		}
-
-		// Update project config with new output
-		outputName := time.Now().Format("2006-01-02-15-04-05")
-		newOutput := types.Output{
-			Name:            outputName,
-			JobDescription:  jobDescription,
-			GeneratedOutput: response,
-		}
-
-		config, err := types.LoadProjectConfig(m.projectDir)
-		if err != nil {
-			return types.ErrorMsg{Error: fmt.Errorf("failed to load project config: %w", err)}
-		}
-
-		config.Outputs = append(config.Outputs, newOutput)
-		if err := types.SaveProjectConfig(m.projectDir, config); err != nil {
-			return types.ErrorMsg{Error: fmt.Errorf("failed to save project config: %w", err)}
-		}
-
-		// Update model's outputs list
-		m.outputs = config.Outputs
-		m.selectedOutputIndex = len(m.outputs) - 1
-
-		// If we get here, generation was successful
-		debugLog.Printf("Generation complete, response length: %d", len(response))
-		return types.GenerationCompleteMsg{} // Add this new message type
 	}
+	selectedModel := m.llmOptions[m.selectedLLMIndex]
+	resume := m.resumeInput
+	outputName := time.Now().Format("2006-01-02-15-04-05")
+	debugLog.Printf("Queueing generation %s with model %s", outputName, selectedModel.Name)
+
+	return startGeneration(generationJob{
+		projectDir: m.projectDir,
+		label:      fmt.Sprintf("%s: %s", m.overviewProjectName, outputName),
+		run: func(ctx context.Context) (func([]types.Output) ([]types.Output, string), error) {
+			response, err := generator.GenerateResume(ctx, selectedModel, resume, jobDescription, job)
+			if err != nil {
+				debugLog.Printf("Generation error: %v", err)
+				return nil, err
+			}
+			debugLog.Printf("Generation complete, response length: %d", len(response))
+
+			newOutput := types.Output{
+				Name:              outputName,
+				JobDescription:    jobDescription,
+				GeneratedOutput:   response,
+				GeneratedAt:       time.Now(),
+				ResumeFingerprint: latex.NewFingerprint(resume),
+				Job:               job,
+			}
+			return func(outputs []types.Output) ([]types.Output, string) {
+				return append(outputs, newOutput), fmt.Sprintf("Generate %s with %s (%s/%s)",
+					outputName, selectedModel.Name, selectedModel.Provider, selectedModel.Model)
+			}, nil
+		},
+	})
 }
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/reminders"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/FabricSoul/auto-resume/internal/ui"
+	"github.com/FabricSoul/auto-resume/pkg/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxDashboardReminders is how many reminders the splash screen lists.
+const maxDashboardReminders = 5
+
+// remindersLoadedMsg carries the reminders of all projects.
+type remindersLoadedMsg struct {
+	reminders []reminders.Reminder
+	skipped   int
+}
+
+// loadReminders evaluates the reminder rules in the background, as every
+// project config has to be read.
+func (m *SplashModel) loadReminders() tea.Cmd {
+	projects := append([]types.Project(nil), m.project.Projects...)
+	rules := m.project.ReminderRules()
+	return func() tea.Msg {
+		found, skipped := reminders.Collect(projects, rules)
+		return remindersLoadedMsg{reminders: found, skipped: skipped}
+	}
+}
+
+// promptCalendarExport asks for a path and writes all reminders to it as an
+// iCalendar file.
+func (m *SplashModel) promptCalendarExport() tea.Cmd {
+	all := m.reminders
+	return func() tea.Msg {
+		return types.ShowFloatInputMsg{
+			Prompt:       fmt.Sprintf("Export %d reminder(s) to calendar file", len(all)),
+			InitialValue: filepath.Join("~", "auto-resume-reminders.ics"),
+			Submit: func(value string) tea.Cmd {
+				dest := config.ExpandHome(strings.TrimSpace(value))
+				return func() tea.Msg {
+					if err := writeCalendar(dest, all); err != nil {
+						return types.ErrorMsg{Error: fmt.Errorf("failed to export reminders: %w", err)}
+					}
+					return nil
+				}
+			},
+		}
+	}
+}
+
+func writeCalendar(path string, all []reminders.Reminder) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := reminders.WriteICS(f, all, time.Now()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// renderReminders shows the reminders that are due, or nothing when there
+// are none.
+func (m *SplashModel) renderReminders(width int) string {
+	now := time.Now()
+	due := reminders.Due(m.reminders, now)
+	if len(due) == 0 {
+		return ""
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	lines := []string{ui.Title.Render(fmt.Sprintf("Reminders (%d due)", len(due)))}
+	for i, r := range due {
+		if i == maxDashboardReminders {
+			lines = append(lines, ui.StatusQueued.Render(fmt.Sprintf("+%d more, run 'auto-resume reminders' to list all", len(due)-i)))
+			break
+		}
+		style := ui.StatusRunning
+		if r.Due.Before(today) {
+			style = ui.StatusFailed
+		}
+		when := fmt.Sprintf("%-11s", relativeDays(r.Due))
+		text := r.Rule.Title() + ": " + r.Subject()
+		line := style.Render(when) + text
+		if room := width - 4 - len([]rune(when)) - len([]rune(text)); room > 1 {
+			line += ui.StatusQueued.Render(truncate("  "+r.Project.Name+" • "+r.Reason, room))
+		}
+		lines = append(lines, line)
+	}
+	return ui.BaseDetails.Width(width).Render(strings.Join(lines, "\n"))
+}
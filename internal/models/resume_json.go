@@ -0,0 +1,108 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/jsonresume"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/FabricSoul/auto-resume/pkg/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// promptResumeTemplate asks which template to render the resume.json at
+// source with, offering the one used last.
+func (m *ProjectDetailModel) promptResumeTemplate(source string) tea.Cmd {
+	initial := m.resumeTemplate
+	if initial == "" {
+		initial = jsonresume.DefaultTemplate
+	}
+	return func() tea.Msg {
+		return types.ShowFloatInputMsg{
+			Prompt:       fmt.Sprintf("Template (%s or a .tex file)", strings.Join(jsonresume.Templates(), ", ")),
+			InitialValue: initial,
+			Submit: func(value string) tea.Cmd {
+				return m.importJSONResume(source, config.ExpandHome(strings.TrimSpace(value)))
+			},
+		}
+	}
+}
+
+// importJSONResume renders the resume.json at source with the template in
+// the background and makes it the base resume. A copy of the JSON is kept in
+// the project as the format-neutral source of the resume.
+func (m *ProjectDetailModel) importJSONResume(source, template string) tea.Cmd {
+	projectDir := m.projectDir
+	return func() tea.Msg {
+		resume, tex, err := renderJSONResume(source, template)
+		if err != nil {
+			return types.ErrorMsg{Error: fmt.Errorf("failed to import resume: %w", err)}
+		}
+		if err := jsonresume.Save(jsonresume.ProjectPath(projectDir), resume); err != nil {
+			return types.ErrorMsg{Error: fmt.Errorf("failed to copy resume.json: %w", err)}
+		}
+		abs, err := filepath.Abs(source)
+		if err != nil {
+			abs = source
+		}
+		return resumeImportedMsg{
+			projectDir: projectDir,
+			source:     abs,
+			template:   template,
+			tex:        tex,
+			stamp:      modTime(abs),
+			message:    "Import JSON Resume from " + abs,
+		}
+	}
+}
+
+// renderJSONResume loads a resume.json and renders it with the template.
+func renderJSONResume(source, template string) (jsonresume.Resume, string, error) {
+	resume, err := jsonresume.Load(source)
+	if err != nil {
+		return jsonresume.Resume{}, "", err
+	}
+	tex, err := jsonresume.Render(resume, template)
+	if err != nil {
+		return jsonresume.Resume{}, "", err
+	}
+	return resume, tex, nil
+}
+
+// checkJSONSource renders the resume.json source again after it changed.
+func (m *ProjectDetailModel) checkJSONSource(next tea.Cmd) tea.Cmd {
+	stamp := modTime(m.resumeSource)
+	if stamp.IsZero() || stamp.Equal(m.watchStamp) {
+		return next
+	}
+	// Half-written JSON fails to parse while an editor saves, the next tick
+	// will try again.
+	resume, tex, err := renderJSONResume(m.resumeSource, m.resumeTemplate)
+	if err != nil {
+		return next
+	}
+	m.watchStamp = stamp
+	if tex == m.resumeInput {
+		return next
+	}
+	if err := jsonresume.Save(jsonresume.ProjectPath(m.projectDir), resume); err != nil {
+		return tea.Batch(next, func() tea.Msg {
+			return types.ErrorMsg{Error: fmt.Errorf("failed to copy resume.json: %w", err)}
+		})
+	}
+	m.resumeInput = tex
+	m.resumeNotice = fmt.Sprintf("Base resume reloaded at %s, %d output(s) stale",
+		time.Now().Format("15:04:05"), len(m.staleOutputs()))
+	return tea.Batch(next, m.saveWithMessage("Reload base resume from "+m.resumeSource))
+}
+
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
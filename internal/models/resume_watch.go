@@ -0,0 +1,156 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/generator"
+	"github.com/FabricSoul/auto-resume/internal/jsonresume"
+	"github.com/FabricSoul/auto-resume/internal/latex"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// resumeWatchInterval is how often the imported resume source is polled.
+const resumeWatchInterval = 2 * time.Second
+
+// resumeWatchMsg asks the project screen for projectDir to check its resume
+// source for changes.
+type resumeWatchMsg struct {
+	projectDir string
+}
+
+func watchResume(projectDir string) tea.Cmd {
+	return tea.Tick(resumeWatchInterval, func(time.Time) tea.Msg {
+		return resumeWatchMsg{projectDir: projectDir}
+	})
+}
+
+// seedResumeWatch records the resume source as it is when the project is
+// opened, so only changes made on disk afterwards reload it. The base resume
+// may have been edited in the app since the import.
+func (m *ProjectDetailModel) seedResumeWatch() {
+	if m.resumeSource == "" {
+		return
+	}
+	if jsonresume.IsJSON(m.resumeSource) {
+		m.watchStamp = modTime(m.resumeSource)
+		return
+	}
+	bundle, err := latex.Load(m.resumeSource)
+	if err != nil {
+		return
+	}
+	m.watchBundle = bundle
+	m.watchStamp = bundle.ModTime()
+}
+
+// checkResumeSource re-imports the resume when any file it was built from has
+// changed on disk. It keeps polling for as long as this screen shows the
+// project the tick was scheduled for.
+func (m *ProjectDetailModel) checkResumeSource(msg resumeWatchMsg) tea.Cmd {
+	if msg.projectDir != m.projectDir {
+		return nil
+	}
+	next := watchResume(m.projectDir)
+	if m.resumeSource == "" {
+		return next
+	}
+	if jsonresume.IsJSON(m.resumeSource) {
+		return m.checkJSONSource(next)
+	}
+	if m.watchBundle != nil && m.watchBundle.ModTime().Equal(m.watchStamp) {
+		return next
+	}
+
+	// A missing or half-written file is expected while an editor saves, the
+	// next tick will try again.
+	bundle, err := latex.Load(m.resumeSource)
+	if err != nil {
+		return next
+	}
+	m.watchBundle = bundle
+	m.watchStamp = bundle.ModTime()
+	if bundle.Source == m.resumeInput {
+		return next
+	}
+
+	if err := bundle.CopyAssets(m.projectDir, types.ProjectConfigFile, types.DefaultResumeFile); err != nil {
+		return tea.Batch(next, func() tea.Msg {
+			return types.ErrorMsg{Error: fmt.Errorf("failed to copy resume files: %w", err)}
+		})
+	}
+	m.resumeInput = bundle.Source
+	m.resumeNotice = fmt.Sprintf("Base resume reloaded at %s, %d output(s) stale",
+		time.Now().Format("15:04:05"), len(m.staleOutputs()))
+	return tea.Batch(next, m.saveWithMessage("Reload base resume from "+m.resumeSource))
+}
+
+// resumeFingerprint returns the fingerprint of the current base resume,
+// recomputing it only after the resume changed.
+func (m *ProjectDetailModel) resumeFingerprint() latex.Fingerprint {
+	if m.fingerprintSource != m.resumeInput || m.fingerprint.IsZero() {
+		m.fingerprint = latex.NewFingerprint(m.resumeInput)
+		m.fingerprintSource = m.resumeInput
+	}
+	return m.fingerprint
+}
+
+// staleOutputs returns the indices of outputs generated from an older
+// version of the base resume.
+func (m *ProjectDetailModel) staleOutputs() []int {
+	current := m.resumeFingerprint()
+	var stale []int
+	for i, out := range m.outputs {
+		if _, ok := out.StaleSections(current); ok {
+			stale = append(stale, i)
+		}
+	}
+	return stale
+}
+
+// regenerateStale queues a background job per stale output, regenerating it
+// with the selected model from its own job description.
+func (m *ProjectDetailModel) regenerateStale() tea.Cmd {
+	stale := m.staleOutputs()
+	if len(stale) == 0 {
+		return nil
+	}
+	if len(m.llmOptions) == 0 || m.selectedLLMIndex >= len(m.llmOptions) {
+		return func() tea.Msg {
+			return types.ErrorMsg{Error: fmt.Errorf("no LLM model selected")}
+		}
+	}
+
+	model := m.llmOptions[m.selectedLLMIndex]
+	resume := m.resumeInput
+	fingerprint := latex.NewFingerprint(resume)
+
+	cmds := make([]tea.Cmd, 0, len(stale))
+	for _, i := range stale {
+		out := m.outputs[i]
+		cmds = append(cmds, startGeneration(generationJob{
+			projectDir: m.projectDir,
+			label:      fmt.Sprintf("%s: %s", m.overviewProjectName, out.Name),
+			run: func(ctx context.Context) (func([]types.Output) ([]types.Output, string), error) {
+				response, err := generator.GenerateResume(ctx, model, resume, out.JobDescription, out.Job)
+				if err != nil {
+					return nil, err
+				}
+				return func(outputs []types.Output) ([]types.Output, string) {
+					for i := range outputs {
+						if outputs[i].Slug == out.Slug {
+							outputs[i].GeneratedOutput = response
+							outputs[i].GeneratedAt = time.Now()
+							outputs[i].ResumeFingerprint = fingerprint
+						}
+					}
+					return outputs, fmt.Sprintf("Regenerate stale output %s with %s", out.Name, model.Name)
+				}, nil
+			},
+		}))
+	}
+	m.resumeNotice = fmt.Sprintf("Regenerating %d stale output(s)", len(stale))
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,145 @@
+package models
+
+import (
+	"path/filepath"
+
+	"github.com/FabricSoul/auto-resume/internal/keymap"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/FabricSoul/auto-resume/internal/ui"
+	"github.com/FabricSoul/auto-resume/pkg/config"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// themesLoadedMsg carries the bundled and user themes, loaded in the
+// background.
+type themesLoadedMsg struct {
+	themes []ui.Theme
+	err    error
+}
+
+// SettingsModel picks the theme. Moving through the list shows each theme
+// right away; enter keeps it in the user config and esc goes back to the
+// theme in use before.
+type SettingsModel struct {
+	width, height int
+
+	themes    []ui.Theme
+	themesErr error
+	cursor    int
+	// original is the theme applied when the screen was opened.
+	original ui.Theme
+}
+
+func NewSettingsModel() *SettingsModel {
+	return &SettingsModel{original: ui.Current()}
+}
+
+// themesDir is where the user's theme files live.
+func themesDir() string {
+	return filepath.Join(config.UserDir(), config.ThemesDir)
+}
+
+func (m *SettingsModel) Init() tea.Cmd {
+	dir := themesDir()
+	return func() tea.Msg {
+		themes, err := ui.Themes(dir)
+		return themesLoadedMsg{themes: themes, err: err}
+	}
+}
+
+func (m *SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case themesLoadedMsg:
+		m.themes, m.themesErr = msg.themes, msg.err
+		for i, t := range m.themes {
+			if t.Name == m.original.Name && t.Path == m.original.Path {
+				m.cursor = i
+			}
+		}
+
+	case tea.KeyMsg:
+		switch {
+		case keymap.Matches(msg, keymap.Down):
+			if m.cursor < len(m.themes)-1 {
+				m.cursor++
+				ui.Apply(m.themes[m.cursor])
+			}
+		case keymap.Matches(msg, keymap.Up):
+			if m.cursor > 0 {
+				m.cursor--
+				ui.Apply(m.themes[m.cursor])
+			}
+		case keymap.Matches(msg, keymap.Select):
+			if m.cursor >= len(m.themes) {
+				break
+			}
+			if err := saveTheme(m.themes[m.cursor].Name); err != nil {
+				return m, func() tea.Msg { return types.ErrorMsg{Error: err} }
+			}
+			m.original = m.themes[m.cursor]
+			return m, func() tea.Msg {
+				return types.TransitionMsg{To: types.StateSplash}
+			}
+		case keymap.Matches(msg, keymap.Back), keymap.Matches(msg, keymap.Close):
+			ui.Apply(m.original)
+			return m, func() tea.Msg {
+				return types.TransitionMsg{To: types.StateSplash}
+			}
+		}
+	}
+	return m, nil
+}
+
+// saveTheme records the theme in the user config, keeping the rest of the
+// file as written.
+func saveTheme(name string) error {
+	return config.SetUserTheme(name)
+}
+
+// Bindings returns the actions of the theme list.
+func (m *SettingsModel) Bindings() keymap.Bindings {
+	return keymap.Get(keymap.Down, keymap.Up, keymap.Select, keymap.Back, keymap.Palette, keymap.Help).
+		Describe(keymap.Down, "theme").
+		Describe(keymap.Up, "theme").
+		Describe(keymap.Select, "use theme").
+		Describe(keymap.Back, "cancel")
+}
+
+func (m *SettingsModel) View() string {
+	content := ui.Title.Render("Settings") + "\n\n"
+	content += "Theme:\n"
+	for i, t := range m.themes {
+		name := t.Name
+		if !t.Builtin() {
+			name += " (yours)"
+		}
+		if i == m.cursor {
+			content += ui.SelectedItem.Render("► "+name) + "\n"
+		} else {
+			content += "  " + name + "\n"
+		}
+	}
+	if m.themesErr != nil {
+		content += "\n" + ui.StatusFailed.Render(m.themesErr.Error()) + "\n"
+	}
+
+	sample := lipgloss.JoinVertical(lipgloss.Left,
+		ui.Title.Render("Title"),
+		ui.SelectedItem.Render("► Selected item"),
+		ui.StatusRunning.Render("Running")+"  "+ui.StatusDone.Render("Done")+"  "+ui.StatusFailed.Render("Failed"),
+		ui.Help.Render("help text"),
+	)
+	content += "\n" + ui.BaseDetails.Render(sample) + "\n"
+
+	if ui.NoColor() {
+		content += ui.Help.Render("NO_COLOR is set, themes are shown without colors") + "\n"
+	}
+	content += ui.Help.Render("Add themes as TOML files in " + themesDir())
+	help := ui.Help.Render(keymap.HelpLine(m.Bindings()))
+	return ui.JoinedContainer.Render(lipgloss.JoinVertical(lipgloss.Left, content, help))
+}
@@ -0,0 +1,120 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrNoRasterizer is returned when neither pdftoppm nor mutool is installed.
+var ErrNoRasterizer = errors.New("pdftoppm or mutool not found, install poppler-utils or mupdf-tools to render pages")
+
+// PageCount returns the number of pages of the PDF at path. It asks pdfinfo
+// when installed and reads the file itself otherwise.
+func PageCount(ctx context.Context, path string) (int, error) {
+	if _, err := exec.LookPath("pdfinfo"); err == nil {
+		out, err := exec.CommandContext(ctx, "pdfinfo", path).Output()
+		if err == nil {
+			if m := pagesLine.FindSubmatch(out); m != nil {
+				return strconv.Atoi(string(m[1]))
+			}
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return countPages(data)
+}
+
+var (
+	pagesLine   = regexp.MustCompile(`(?m)^Pages:\s+(\d+)`)
+	pageObject  = regexp.MustCompile(`/Type\s*/Page\b`)
+	streamStart = regexp.MustCompile(`stream\r?\n`)
+)
+
+// countPages counts the page objects of a PDF. Objects are often packed in
+// compressed object streams, so those are inflated and searched as well.
+func countPages(data []byte) (int, error) {
+	if !bytes.HasPrefix(data, []byte("%PDF")) {
+		return 0, fmt.Errorf("not a PDF file")
+	}
+	n := len(pageObject.FindAll(data, -1))
+	for _, loc := range streamStart.FindAllIndex(data, -1) {
+		start := loc[1]
+		end := bytes.Index(data[start:], []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		r, err := zlib.NewReader(bytes.NewReader(data[start : start+end]))
+		if err != nil {
+			continue
+		}
+		inflated, _ := io.ReadAll(r)
+		r.Close()
+		n += len(pageObject.FindAll(inflated, -1))
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("no pages found")
+	}
+	return n, nil
+}
+
+// PageTexts returns the text of each page of the PDF at path, laid out as
+// on the page.
+func PageTexts(ctx context.Context, path string) ([]string, error) {
+	if _, err := exec.LookPath("pdftotext"); err != nil {
+		return nil, ErrNoPdftotext
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "pdftotext", "-enc", "UTF-8", "-layout", path, "-")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("pdftotext failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	// Every page ends with a form feed.
+	pages := strings.Split(strings.TrimSuffix(stdout.String(), "\f"), "\f")
+	return pages, nil
+}
+
+// RenderPage rasterises a page, counted from 1, as PNG at the given
+// resolution with pdftoppm or mutool.
+func RenderPage(ctx context.Context, path string, page, dpi int) ([]byte, error) {
+	var cmd *exec.Cmd
+	res := strconv.Itoa(dpi)
+	n := strconv.Itoa(page)
+	switch {
+	case hasTool("pdftoppm"):
+		cmd = exec.CommandContext(ctx, "pdftoppm", "-png", "-r", res, "-f", n, "-l", n, "-singlefile", path)
+	case hasTool("mutool"):
+		cmd = exec.CommandContext(ctx, "mutool", "draw", "-q", "-F", "png", "-r", res, "-o", "-", path, n)
+	default:
+		return nil, ErrNoRasterizer
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s failed: %s", cmd.Args[0], strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// CanRender reports whether pages can be rasterised.
+func CanRender() bool {
+	return hasTool("pdftoppm") || hasTool("mutool")
+}
+
+func hasTool(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
@@ -0,0 +1,39 @@
+// Package pdf reads PDF files using the poppler command line tools.
+package pdf
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ErrNoPdftotext is returned when pdftotext, which reads the text of PDFs, is
+// not installed.
+var ErrNoPdftotext = errors.New("pdftotext not found, install poppler-utils to read PDF files")
+
+// ErrNoText is returned for PDFs without a text layer, such as scans.
+var ErrNoText = errors.New("PDF has no text layer")
+
+// Text extracts the text layer of the PDF at path.
+func Text(ctx context.Context, path string) (string, error) {
+	if _, err := exec.LookPath("pdftotext"); err != nil {
+		return "", ErrNoPdftotext
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "pdftotext", "-enc", "UTF-8", "-layout", path, "-")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("pdftotext failed: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	text := strings.ReplaceAll(stdout.String(), "\f", "\n")
+	if strings.TrimSpace(text) == "" {
+		return "", ErrNoText
+	}
+	return text, nil
+}
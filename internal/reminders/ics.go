@@ -0,0 +1,64 @@
+package reminders
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"strings"
+	"time"
+)
+
+// WriteICS writes the reminders as all-day events of an iCalendar file that
+// can be imported into calendar applications. Event UIDs are stable, so
+// importing an updated file replaces the earlier entries.
+func WriteICS(w io.Writer, all []Reminder, now time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(s string) {
+		// Lines are folded at 75 octets, continuation lines start with a
+		// space.
+		for len(s) > 75 {
+			cut := 75
+			for cut > 0 && !isRuneStart(s[cut]) {
+				cut--
+			}
+			bw.WriteString(s[:cut] + "\r\n")
+			s = " " + s[cut:]
+		}
+		bw.WriteString(s + "\r\n")
+	}
+
+	stamp := now.UTC().Format("20060102T150405Z")
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//auto-resume//reminders//EN")
+	line("CALSCALE:GREGORIAN")
+	for _, r := range all {
+		line("BEGIN:VEVENT")
+		line("UID:" + uid(r) + "@auto-resume")
+		line("DTSTAMP:" + stamp)
+		line("DTSTART;VALUE=DATE:" + r.Due.Format("20060102"))
+		line("DTEND;VALUE=DATE:" + r.Due.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:" + escapeText(r.Rule.Title()+": "+r.Subject()))
+		line("DESCRIPTION:" + escapeText(r.Project.Name+" – "+r.Reason))
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return bw.Flush()
+}
+
+// uid identifies the reminder of a rule for an output.
+func uid(r Reminder) string {
+	sum := sha1.Sum([]byte(r.Project.Path + "\x00" + r.Output.Slug + "\x00" + r.Rule.Title()))
+	return hex.EncodeToString(sum[:10])
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", "")
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
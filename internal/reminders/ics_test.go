@@ -0,0 +1,119 @@
+package reminders
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/FabricSoul/auto-resume/internal/types"
+)
+
+// unfold joins folded iCalendar lines and splits the result into lines.
+func unfold(t *testing.T, ics string) []string {
+	t.Helper()
+	if !strings.HasSuffix(ics, "\r\n") {
+		t.Fatalf("calendar does not end with CRLF: %q", ics)
+	}
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(ics, "\r\n ", ""), "\r\n"), "\r\n")
+}
+
+func TestWriteICS(t *testing.T) {
+	now := time.Date(2025, 3, 9, 8, 30, 0, 0, time.UTC)
+	due := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	// "SUMMARY:Follow up: x" is 20 octets, so the two-octet runes after it
+	// straddle the 75 octet fold.
+	name := "x" + strings.Repeat("é", 40)
+	reminder := Reminder{
+		Project: types.Project{Name: "Jobs", Path: "/projects/jobs"},
+		Output:  types.Output{Name: name, Slug: "x"},
+		Rule:    types.DefaultReminderRules[0],
+		Due:     due,
+		Reason:  "applied 2025-03-01, last note 2025-03-05",
+	}
+
+	var buf bytes.Buffer
+	if err := WriteICS(&buf, []Reminder{reminder}, now); err != nil {
+		t.Fatal(err)
+	}
+	ics := buf.String()
+
+	for _, l := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		if len(l) > 75 {
+			t.Errorf("line of %d octets: %q", len(l), l)
+		}
+		if !utf8.ValidString(l) {
+			t.Errorf("line splits a character: %q", l)
+		}
+	}
+	if !strings.Contains(ics, "\r\n ") {
+		t.Error("long summary was not folded")
+	}
+
+	lines := unfold(t, ics)
+	want := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//auto-resume//reminders//EN",
+		"CALSCALE:GREGORIAN",
+		"BEGIN:VEVENT",
+		"UID:" + uid(reminder) + "@auto-resume",
+		"DTSTAMP:20250309T083000Z",
+		"DTSTART;VALUE=DATE:20250331",
+		"DTEND;VALUE=DATE:20250401",
+		"SUMMARY:Follow up: " + name,
+		`DESCRIPTION:Jobs – applied 2025-03-01\, last note 2025-03-05`,
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("calendar =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestWriteICSEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteICS(&buf, nil, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); strings.Contains(got, "VEVENT") || !strings.HasSuffix(got, "END:VCALENDAR\r\n") {
+		t.Errorf("calendar = %q", got)
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain text", "plain text"},
+		{"a, b; c", `a\, b\; c`},
+		{`C:\path`, `C:\\path`},
+		{"two\r\nlines\nhere", `two\nlines\nhere`},
+		{`\,`, `\\\,`},
+	}
+	for _, tt := range tests {
+		if got := escapeText(tt.in); got != tt.want {
+			t.Errorf("escapeText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestUIDIsStable(t *testing.T) {
+	r := Reminder{
+		Project: types.Project{Path: "/projects/jobs"},
+		Output:  types.Output{Slug: "acme"},
+		Rule:    types.DefaultReminderRules[0],
+		Due:     time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+	moved := r
+	moved.Due = moved.Due.AddDate(0, 0, 7)
+	if uid(r) != uid(moved) {
+		t.Error("UID changes with the due date")
+	}
+	other := r
+	other.Rule = types.DefaultReminderRules[1]
+	if uid(r) == uid(other) {
+		t.Error("rules of the same output share a UID")
+	}
+}
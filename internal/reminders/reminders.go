@@ -0,0 +1,127 @@
+// Package reminders works out which applications need attention, such as
+// following up a week after applying or finishing an application before its
+// deadline, and exports them as calendar entries.
+package reminders
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/types"
+)
+
+// Reminder is a rule that fires for an output of a project.
+type Reminder struct {
+	Project types.Project
+	Output  types.Output
+	Rule    types.ReminderRule
+	// Due is the start of the day the reminder fires.
+	Due time.Time
+	// Reason explains the reminder, such as "applied 2025-02-06".
+	Reason string
+}
+
+// Overdue reports whether the reminder has fired by now.
+func (r Reminder) Overdue(now time.Time) bool {
+	return !r.Due.After(now)
+}
+
+// Subject names the output and, unless the name already does, the company.
+func (r Reminder) Subject() string {
+	subject := r.Output.Name
+	if company := r.Output.Job.Company; company != "" && !strings.Contains(strings.ToLower(subject), strings.ToLower(company)) {
+		subject += " (" + company + ")"
+	}
+	return subject
+}
+
+// Collect evaluates rules for the outputs of all projects, ordered by due
+// date. Projects whose config cannot be read are skipped and counted.
+func Collect(projects []types.Project, rules []types.ReminderRule) ([]Reminder, int) {
+	var all []Reminder
+	skipped := 0
+	for _, p := range projects {
+		config, err := types.LoadProjectConfig(p.Path)
+		if err != nil {
+			skipped++
+			continue
+		}
+		for _, out := range config.Outputs {
+			all = append(all, ForOutput(p, out, rules)...)
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Due.Before(all[j].Due)
+	})
+	return all, skipped
+}
+
+// ForOutput evaluates rules for a single output.
+func ForOutput(p types.Project, out types.Output, rules []types.ReminderRule) []Reminder {
+	status := out.Application.CurrentStatus()
+	var found []Reminder
+	for _, rule := range rules {
+		if !rule.AppliesTo(status) {
+			continue
+		}
+		r := Reminder{Project: p, Output: out, Rule: rule}
+		switch {
+		case rule.IsDeadline():
+			deadline, ok := out.Job.DeadlineTime()
+			if !ok {
+				continue
+			}
+			r.Due = startOfDay(deadline).AddDate(0, 0, -rule.BeforeDeadline)
+			r.Reason = "deadline " + deadline.Format("2006-01-02")
+		case rule.AfterDays > 0:
+			// Any event, a note such as "sent a follow-up" included,
+			// restarts the wait.
+			last, ok := lastActivity(out.Application)
+			if !ok {
+				continue
+			}
+			r.Due = startOfDay(last).AddDate(0, 0, rule.AfterDays)
+			since := out.Application.Since()
+			r.Reason = fmt.Sprintf("%s %s", status, since.Local().Format("2006-01-02"))
+			if !last.Equal(since) {
+				r.Reason += ", last note " + last.Local().Format("2006-01-02")
+			}
+		default:
+			continue
+		}
+		found = append(found, r)
+	}
+	return found
+}
+
+// Due returns the reminders that have fired by now.
+func Due(all []Reminder, now time.Time) []Reminder {
+	var due []Reminder
+	for _, r := range all {
+		if r.Overdue(now) {
+			due = append(due, r)
+		}
+	}
+	return due
+}
+
+// lastActivity returns the time of the latest event of an application.
+func lastActivity(a types.Application) (time.Time, bool) {
+	if len(a.Events) == 0 {
+		return time.Time{}, false
+	}
+	last := a.Events[0].At
+	for _, e := range a.Events[1:] {
+		if e.At.After(last) {
+			last = e.At
+		}
+	}
+	return last, true
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.Local()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
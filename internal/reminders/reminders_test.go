@@ -0,0 +1,143 @@
+package reminders
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// useZone runs the test in a fixed local time zone east of UTC, so that
+// evening UTC times fall on the next local day.
+func useZone(t *testing.T) *time.Location {
+	t.Helper()
+	saved := time.Local
+	time.Local = time.FixedZone("UTC+9", 9*60*60)
+	t.Cleanup(func() { time.Local = saved })
+	return time.Local
+}
+
+func TestForOutput(t *testing.T) {
+	local := useZone(t)
+	at := func(value string) time.Time {
+		v, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, local)
+	}
+	applied := func(events ...types.ApplicationEvent) types.Application {
+		return types.Application{Status: types.StatusApplied, Events: events}
+	}
+	deadline := types.JobMetadata{Deadline: toml.LocalDate{Year: 2025, Month: 6, Day: 30}}
+
+	tests := []struct {
+		name   string
+		out    types.Output
+		rules  []types.ReminderRule
+		due    []time.Time
+		reason []string
+	}{
+		{
+			name:   "deadline minus the days before it",
+			out:    types.Output{Job: deadline},
+			rules:  types.DefaultReminderRules,
+			due:    []time.Time{day(2025, 6, 28)},
+			reason: []string{"deadline 2025-06-30"},
+		},
+		{
+			name:  "deadline of an application already sent",
+			out:   types.Output{Job: deadline, Application: applied(types.ApplicationEvent{At: at("2025-06-01T09:00:00+09:00"), Status: types.StatusApplied})},
+			rules: []types.ReminderRule{{BeforeDeadline: 2}},
+		},
+		{
+			name:  "deadline rule without a deadline",
+			out:   types.Output{},
+			rules: []types.ReminderRule{{BeforeDeadline: 2}},
+		},
+		{
+			name:   "deadline rule for another status",
+			out:    types.Output{Job: deadline, Application: applied(types.ApplicationEvent{At: at("2025-06-01T09:00:00+09:00"), Status: types.StatusApplied})},
+			rules:  []types.ReminderRule{{Name: "Chase", Status: types.StatusApplied, BeforeDeadline: 5}},
+			due:    []time.Time{day(2025, 6, 25)},
+			reason: []string{"deadline 2025-06-30"},
+		},
+		{
+			name:   "follow up counts from local midnight",
+			out:    types.Output{Application: applied(types.ApplicationEvent{At: at("2025-03-01T20:00:00Z"), Status: types.StatusApplied})},
+			rules:  types.DefaultReminderRules,
+			due:    []time.Time{day(2025, 3, 9)},
+			reason: []string{"applied 2025-03-02"},
+		},
+		{
+			name: "a later note restarts the wait",
+			out: types.Output{Application: applied(
+				types.ApplicationEvent{At: at("2025-03-01T10:00:00+09:00"), Status: types.StatusApplied},
+				types.ApplicationEvent{At: at("2025-03-05T23:30:00+09:00"), Note: "sent a follow-up"},
+			)},
+			rules:  types.DefaultReminderRules,
+			due:    []time.Time{day(2025, 3, 12)},
+			reason: []string{"applied 2025-03-01, last note 2025-03-05"},
+		},
+		{
+			name:  "follow up without any activity",
+			out:   types.Output{},
+			rules: []types.ReminderRule{{Status: types.StatusDrafted, AfterDays: 3}},
+		},
+		{
+			name:  "rule without days",
+			out:   types.Output{Application: applied(types.ApplicationEvent{At: at("2025-03-01T10:00:00Z"), Status: types.StatusApplied})},
+			rules: []types.ReminderRule{{Status: types.StatusApplied}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ForOutput(types.Project{Name: "Backend"}, tt.out, tt.rules)
+			if len(got) != len(tt.due) {
+				t.Fatalf("got %d reminders, want %d: %+v", len(got), len(tt.due), got)
+			}
+			for i, r := range got {
+				if !r.Due.Equal(tt.due[i]) {
+					t.Errorf("due = %s, want %s", r.Due, tt.due[i])
+				}
+				if r.Reason != tt.reason[i] {
+					t.Errorf("reason = %q, want %q", r.Reason, tt.reason[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDue(t *testing.T) {
+	local := useZone(t)
+	now := time.Date(2025, 3, 9, 8, 0, 0, 0, local)
+	all := []Reminder{
+		{Output: types.Output{Name: "yesterday"}, Due: now.AddDate(0, 0, -1)},
+		{Output: types.Output{Name: "today"}, Due: time.Date(2025, 3, 9, 0, 0, 0, 0, local)},
+		{Output: types.Output{Name: "tomorrow"}, Due: time.Date(2025, 3, 10, 0, 0, 0, 0, local)},
+	}
+	got := Due(all, now)
+	if len(got) != 2 || got[0].Output.Name != "yesterday" || got[1].Output.Name != "today" {
+		t.Errorf("due = %+v", got)
+	}
+}
+
+func TestSubject(t *testing.T) {
+	tests := []struct {
+		name, company, want string
+	}{
+		{"Backend Engineer", "Acme", "Backend Engineer (Acme)"},
+		{"Acme backend", "ACME", "Acme backend"},
+		{"Backend Engineer", "", "Backend Engineer"},
+	}
+	for _, tt := range tests {
+		r := Reminder{Output: types.Output{Name: tt.name, Job: types.JobMetadata{Company: tt.company}}}
+		if got := r.Subject(); got != tt.want {
+			t.Errorf("Subject() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+// Package templates provides the LaTeX resumes new projects can start from:
+// a bundled set and the user's own, kept in the templates directory next to
+// the user config as a .tex file or a directory with its assets.
+//
+// Templates declare what they need in TOML comments at the top of the file:
+//
+//	% name = "Jake's Resume"
+//	% description = "Single column, ATS friendly"
+//	% engine = "pdflatex"
+//	% packages = ["titlesec", "enumitem"]
+//
+// Without packages, those loaded with \usepackage are listed.
+package templates
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/FabricSoul/auto-resume/internal/latex"
+	"github.com/FabricSoul/auto-resume/internal/types"
+	"github.com/FabricSoul/auto-resume/pkg/config"
+	"github.com/pelletier/go-toml/v2"
+)
+
+//go:embed builtin/*.tex
+var builtinFS embed.FS
+
+// ErrEngineMissing is returned by Apply when the engine a template needs is
+// not installed.
+var ErrEngineMissing = errors.New("LaTeX engine not installed")
+
+// Dir returns the directory of the user's templates.
+func Dir() string {
+	return filepath.Join(config.UserDir(), config.TemplatesDir)
+}
+
+// Template is a resume a project can be seeded with.
+type Template struct {
+	// ID is the file or directory name without extension.
+	ID          string
+	Name        string
+	Description string
+	// Engine is the LaTeX engine the template needs, empty for any.
+	Engine   string
+	Packages []string
+	// Source is the LaTeX without the declarations.
+	Source string
+	// Path is the file or directory of a user template, empty for bundled
+	// ones.
+	Path string
+
+	bundle *latex.Bundle
+}
+
+// meta holds the declarations at the top of a template.
+type meta struct {
+	Name        string   `toml:"name"`
+	Description string   `toml:"description"`
+	Engine      string   `toml:"engine"`
+	Packages    []string `toml:"packages"`
+}
+
+// metaLine matches a declaration such as `% engine = "xelatex"`.
+var metaLine = regexp.MustCompile(`^%+\s*([a-z_]+\s*=.*)$`)
+
+// Builtin reports whether the template is bundled with auto-resume.
+func (t Template) Builtin() bool {
+	return t.Path == ""
+}
+
+// Class returns the document class of the template.
+func (t Template) Class() string {
+	return latex.DocumentClass(t.Source)
+}
+
+// Requirements lists the class and package files the template needs, such
+// as "moderncv.cls" and "fontspec.sty".
+func (t Template) Requirements() []string {
+	var files []string
+	if class := t.Class(); class != "" {
+		files = append(files, class+".cls")
+	}
+	for _, p := range t.Packages {
+		files = append(files, p+".sty")
+	}
+	return files
+}
+
+// Preamble returns the source of the template before \begin{document}, the
+// document setup without the resume itself.
+func (t Template) Preamble() string {
+	preamble, _, _ := strings.Cut(t.Source, `\begin{document}`)
+	return preamble
+}
+
+// Outline returns the titles of the sections of the template.
+func (t Template) Outline() []string {
+	var titles []string
+	for _, s := range latex.Sections(t.Source) {
+		if s.Title != latex.PreambleSection {
+			titles = append(titles, s.Title)
+		}
+	}
+	return titles
+}
+
+// parse reads the declarations at the top of source and removes them.
+func parse(id, source string) (Template, error) {
+	var decl []string
+	lines := strings.SplitAfter(source, "\n")
+	n := 0
+	for ; n < len(lines); n++ {
+		line := strings.TrimSpace(lines[n])
+		m := metaLine.FindStringSubmatch(line)
+		if m == nil {
+			break
+		}
+		decl = append(decl, m[1])
+	}
+
+	var md meta
+	if err := toml.Unmarshal([]byte(strings.Join(decl, "\n")), &md); err != nil {
+		return Template{}, fmt.Errorf("template %s: invalid declarations: %w", id, err)
+	}
+	if md.Engine != "" && !contains(latex.Engines, md.Engine) {
+		return Template{}, fmt.Errorf("template %s: unknown engine %q, use one of %s",
+			id, md.Engine, strings.Join(latex.Engines, ", "))
+	}
+
+	t := Template{
+		ID:          id,
+		Name:        md.Name,
+		Description: md.Description,
+		Engine:      md.Engine,
+		Packages:    md.Packages,
+		Source:      strings.Join(lines[n:], ""),
+	}
+	if t.Name == "" {
+		t.Name = id
+	}
+	if len(t.Packages) == 0 {
+		t.Packages = latex.Packages(t.Source)
+	}
+	return t, nil
+}
+
+// Builtin returns the bundled templates sorted by name.
+func Builtin() []Template {
+	entries, err := builtinFS.ReadDir("builtin")
+	if err != nil {
+		return nil
+	}
+	var list []Template
+	for _, e := range entries {
+		data, err := builtinFS.ReadFile("builtin/" + e.Name())
+		if err != nil {
+			continue
+		}
+		t, err := parse(strings.TrimSuffix(e.Name(), ".tex"), string(data))
+		if err != nil {
+			// Bundled templates are checked during development.
+			panic(err)
+		}
+		list = append(list, t)
+	}
+	sortByName(list)
+	return list
+}
+
+// User loads the templates in dir, which may not exist. Templates that fail
+// to load are skipped and reported in the error.
+func User(dir string) ([]Template, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates: %w", err)
+	}
+
+	var list []Template
+	var errs []error
+	for _, e := range entries {
+		if !e.IsDir() && !strings.EqualFold(filepath.Ext(e.Name()), ".tex") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		bundle, err := latex.Load(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("template %s: %w", e.Name(), err))
+			continue
+		}
+		t, err := parse(strings.TrimSuffix(e.Name(), filepath.Ext(e.Name())), bundle.Source)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		t.Path = path
+		t.bundle = bundle
+		list = append(list, t)
+	}
+	sortByName(list)
+	return list, errors.Join(errs...)
+}
+
+// List returns the user's templates followed by the bundled ones.
+func List(dir string) ([]Template, error) {
+	user, err := User(dir)
+	return append(user, Builtin()...), err
+}
+
+// Find returns the template with the given ID or name, preferring the
+// user's.
+func Find(dir, id string) (Template, error) {
+	list, _ := List(dir)
+	for _, t := range list {
+		if t.ID == id || strings.EqualFold(t.Name, id) {
+			return t, nil
+		}
+	}
+	return Template{}, fmt.Errorf("unknown template %q", id)
+}
+
+// Apply seeds the project in projectDir with t: its LaTeX becomes the base
+// resume, assets of directory templates are copied and the engine the
+// template needs is configured unless the project already picked one. An
+// engine that is not installed is left out, so compiling falls back to the
+// first one that is, and reported with ErrEngineMissing once the project is
+// seeded.
+func Apply(projectDir string, t Template) error {
+	cfg, err := types.LoadProjectConfig(projectDir)
+	if err != nil {
+		return err
+	}
+	if t.bundle != nil {
+		if err := t.bundle.CopyAssets(projectDir, types.ProjectConfigFile, types.DefaultResumeFile); err != nil {
+			return fmt.Errorf("failed to copy template files: %w", err)
+		}
+	}
+	cfg.ResumeInput = t.Source
+	var missing error
+	if cfg.Engine == "" && t.Engine != "" {
+		if _, err := latex.FindEngine(t.Engine); err == nil {
+			cfg.Engine = t.Engine
+		} else {
+			missing = fmt.Errorf("%w: template %s needs %s, compiling uses the first installed engine instead",
+				ErrEngineMissing, t.Name, t.Engine)
+		}
+	}
+	if err := types.SaveProjectConfig(projectDir, cfg); err != nil {
+		return err
+	}
+	return missing
+}
+
+func sortByName(list []Template) {
+	sort.Slice(list, func(i, j int) bool {
+		return strings.ToLower(list[i].Name) < strings.ToLower(list[j].Name)
+	})
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBuiltinTemplatesParse(t *testing.T) {
+	entries, err := builtinFS.ReadDir("builtin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		data, err := builtinFS.ReadFile("builtin/" + e.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		tmpl, err := parse(strings.TrimSuffix(e.Name(), ".tex"), string(data))
+		if err != nil {
+			t.Errorf("%s: %v", e.Name(), err)
+			continue
+		}
+		if tmpl.Class() == "" {
+			t.Errorf("%s: no document class", e.Name())
+		}
+	}
+	if got := len(Builtin()); got != len(entries) {
+		t.Errorf("Builtin() returned %d templates, want %d", got, len(entries))
+	}
+}
+
+func TestParse(t *testing.T) {
+	const body = "\\documentclass{article}\n\\usepackage{geometry}\n\\usepackage[T1]{fontenc}\n\\begin{document}\nHi\n\\end{document}\n"
+	tests := []struct {
+		name     string
+		source   string
+		wantErr  string
+		wantName string
+		engine   string
+		packages []string
+	}{
+		{
+			name:     "no declarations",
+			source:   body,
+			wantName: "plain",
+			packages: []string{"geometry", "fontenc"},
+		},
+		{
+			name:     "declarations",
+			source:   "% name = \"Plain\"\n%% engine = \"xelatex\"\n% packages = [\"fontspec\"]\n" + body,
+			wantName: "Plain",
+			engine:   "xelatex",
+			packages: []string{"fontspec"},
+		},
+		{
+			name:    "invalid declarations",
+			source:  "% name = Plain\n" + body,
+			wantErr: "invalid declarations",
+		},
+		{
+			name:    "wrong declaration type",
+			source:  "% packages = \"geometry\"\n" + body,
+			wantErr: "invalid declarations",
+		},
+		{
+			name:    "unknown engine",
+			source:  "% engine = \"tex\"\n" + body,
+			wantErr: "unknown engine",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := parse("plain", tt.source)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tmpl.Name != tt.wantName {
+				t.Errorf("name = %q, want %q", tmpl.Name, tt.wantName)
+			}
+			if tmpl.Engine != tt.engine {
+				t.Errorf("engine = %q, want %q", tmpl.Engine, tt.engine)
+			}
+			if !slices.Equal(tmpl.Packages, tt.packages) {
+				t.Errorf("packages = %q, want %q", tmpl.Packages, tt.packages)
+			}
+			if tmpl.Source != body {
+				t.Errorf("source keeps the declarations:\n%s", tmpl.Source)
+			}
+			if want := "\\documentclass{article}\n\\usepackage{geometry}\n\\usepackage[T1]{fontenc}\n"; tmpl.Preamble() != want {
+				t.Errorf("Preamble() = %q, want %q", tmpl.Preamble(), want)
+			}
+		})
+	}
+}
+
+func TestUserSkipsBrokenTemplates(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"good.tex":   "% name = \"Good\"\n\\documentclass{article}\n\\begin{document}\n\\end{document}\n",
+		"broken.tex": "% engine = \"tex\"\n\\documentclass{article}\n",
+		"notes.txt":  "not a template",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	list, err := User(dir)
+	if err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("err = %v, want the broken template reported", err)
+	}
+	if len(list) != 1 || list[0].Name != "Good" || list[0].Builtin() {
+		t.Errorf("User() = %+v, want only the good template", list)
+	}
+
+	if list, err := User(filepath.Join(dir, "missing")); list != nil || err != nil {
+		t.Errorf("User() of a missing directory = %v, %v", list, err)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package termimg
+
+// CellSize returns the size of a terminal cell in pixels, assumed here.
+func CellSize() (int, int) {
+	return defaultCellWidth, defaultCellHeight
+}
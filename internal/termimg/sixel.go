@@ -0,0 +1,91 @@
+package termimg
+
+import (
+	"fmt"
+	"image"
+	"strings"
+)
+
+// levels is the number of shades per channel in the sixel palette, giving
+// 64 colours: plenty for a page of mostly black text.
+const levels = 4
+
+// sixel encodes img with a fixed palette and run length encoding.
+func sixel(img image.Image) string {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	// Palette index of every pixel.
+	idx := make([]uint8, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			r, g, bl, _ := img.At(b.Min.X+x, b.Min.Y+y).RGBA()
+			idx[y*w+x] = uint8(quantize(r)*levels*levels + quantize(g)*levels + quantize(bl))
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\x1bPq\"1;1;%d;%d", w, h)
+	for i := 0; i < levels*levels*levels; i++ {
+		r, g, bl := i/(levels*levels), i/levels%levels, i%levels
+		fmt.Fprintf(&sb, "#%d;2;%d;%d;%d", i, percent(r), percent(g), percent(bl))
+	}
+
+	row := make([]byte, w)
+	for band := 0; band < h; band += 6 {
+		var used [levels * levels * levels]bool
+		for y := band; y < min(band+6, h); y++ {
+			for x := 0; x < w; x++ {
+				used[idx[y*w+x]] = true
+			}
+		}
+		first := true
+		for c, ok := range used {
+			if !ok {
+				continue
+			}
+			if !first {
+				sb.WriteByte('$')
+			}
+			first = false
+			for x := 0; x < w; x++ {
+				var bits byte
+				for dy := 0; dy < 6 && band+dy < h; dy++ {
+					if int(idx[(band+dy)*w+x]) == c {
+						bits |= 1 << dy
+					}
+				}
+				row[x] = '?' + bits
+			}
+			fmt.Fprintf(&sb, "#%d", c)
+			writeRuns(&sb, row)
+		}
+		sb.WriteByte('-')
+	}
+	sb.WriteString("\x1b\\")
+	return sb.String()
+}
+
+// writeRuns writes sixel characters, repeating runs with !n.
+func writeRuns(sb *strings.Builder, row []byte) {
+	for i := 0; i < len(row); {
+		j := i
+		for j < len(row) && row[j] == row[i] {
+			j++
+		}
+		if n := j - i; n > 3 {
+			fmt.Fprintf(sb, "!%d%c", n, row[i])
+		} else {
+			sb.WriteString(strings.Repeat(string(row[i]), n))
+		}
+		i = j
+	}
+}
+
+func quantize(v uint32) int {
+	return int((v*uint32(levels-1) + 0x7fff) / 0xffff)
+}
+
+func percent(level int) int {
+	return level * 100 / (levels - 1)
+}
@@ -0,0 +1,144 @@
+// Package termimg shows images in terminals that support a graphics
+// protocol: kitty, iTerm2 or sixel.
+package termimg
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"strings"
+)
+
+// Fallback size of a terminal cell in pixels when the terminal does not
+// report its pixel size.
+const (
+	defaultCellWidth  = 10
+	defaultCellHeight = 20
+)
+
+// Protocol is a terminal graphics protocol.
+type Protocol string
+
+const (
+	None  Protocol = ""
+	Kitty Protocol = "kitty"
+	ITerm Protocol = "iterm"
+	Sixel Protocol = "sixel"
+)
+
+// EnvOverride names the environment variable that forces a protocol, or
+// disables graphics with "none", when detection guesses wrong.
+const EnvOverride = "AUTO_RESUME_GRAPHICS"
+
+// Detect guesses the graphics protocol of the terminal from the
+// environment. Terminals that do not identify themselves get None.
+func Detect() Protocol {
+	switch p := Protocol(strings.ToLower(os.Getenv(EnvOverride))); p {
+	case Kitty, ITerm, Sixel:
+		return p
+	case "none":
+		return None
+	}
+	term := os.Getenv("TERM")
+	program := os.Getenv("TERM_PROGRAM")
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "", term == "xterm-kitty", term == "xterm-ghostty", program == "ghostty":
+		return Kitty
+	case program == "iTerm.app", os.Getenv("LC_TERMINAL") == "iTerm2", program == "WezTerm":
+		return ITerm
+	case term == "foot" || strings.HasPrefix(term, "foot-"), term == "mlterm", strings.Contains(term, "sixel"):
+		return Sixel
+	}
+	return None
+}
+
+// Title returns the name of the protocol for display.
+func (p Protocol) Title() string {
+	switch p {
+	case Kitty:
+		return "kitty graphics"
+	case ITerm:
+		return "iTerm2 inline images"
+	case Sixel:
+		return "sixel"
+	}
+	return "none"
+}
+
+// Encode returns the escape sequence drawing the PNG image in data at the
+// cursor, scaled to fit cols by rows cells.
+func Encode(p Protocol, data []byte, cols, rows int) (string, error) {
+	switch p {
+	case Kitty:
+		return kitty(data, cols, rows), nil
+	case ITerm:
+		return iterm(data, cols, rows), nil
+	case Sixel:
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return "", fmt.Errorf("failed to decode page image: %w", err)
+		}
+		w, h := CellSize()
+		return sixel(fit(img, cols*w, rows*h)), nil
+	}
+	return "", fmt.Errorf("terminal graphics are not supported")
+}
+
+// Clear returns the sequence removing images drawn with p. Only kitty keeps
+// images apart from the text, the others are overwritten by it.
+func Clear(p Protocol) string {
+	if p == Kitty {
+		return "\x1b_Ga=d,d=A,q=2\x1b\\"
+	}
+	return ""
+}
+
+// kittyChunk is the largest payload of a single kitty escape sequence.
+const kittyChunk = 4096
+
+func kitty(data []byte, cols, rows int) string {
+	payload := base64.StdEncoding.EncodeToString(data)
+	var sb strings.Builder
+	for i := 0; i < len(payload); i += kittyChunk {
+		end := min(i+kittyChunk, len(payload))
+		more := 0
+		if end < len(payload) {
+			more = 1
+		}
+		if i == 0 {
+			// Transmit and display the PNG, quietly, scaled to the cell area.
+			fmt.Fprintf(&sb, "\x1b_Ga=T,f=100,q=2,c=%d,r=%d,m=%d;", cols, rows, more)
+		} else {
+			fmt.Fprintf(&sb, "\x1b_Gm=%d;", more)
+		}
+		sb.WriteString(payload[i:end])
+		sb.WriteString("\x1b\\")
+	}
+	return sb.String()
+}
+
+func iterm(data []byte, cols, rows int) string {
+	return fmt.Sprintf("\x1b]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=1:%s\a",
+		len(data), cols, rows, base64.StdEncoding.EncodeToString(data))
+}
+
+// fit scales img down to fit within width by height pixels, keeping its
+// aspect ratio.
+func fit(img image.Image, width, height int) image.Image {
+	b := img.Bounds()
+	scale := min(float64(width)/float64(b.Dx()), float64(height)/float64(b.Dy()))
+	if scale >= 1 {
+		return img
+	}
+	w, h := max(1, int(float64(b.Dx())*scale)), max(1, int(float64(b.Dy())*scale))
+	out := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			out.Set(x, y, img.At(b.Min.X+int(float64(x)/scale), b.Min.Y+int(float64(y)/scale)))
+		}
+	}
+	return out
+}
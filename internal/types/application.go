@@ -0,0 +1,122 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ApplicationStatus is the stage of the application made with an output.
+type ApplicationStatus string
+
+const (
+	StatusDrafted      ApplicationStatus = "drafted"
+	StatusApplied      ApplicationStatus = "applied"
+	StatusScreening    ApplicationStatus = "screening"
+	StatusInterviewing ApplicationStatus = "interviewing"
+	StatusOffer        ApplicationStatus = "offer"
+	StatusRejected     ApplicationStatus = "rejected"
+	StatusWithdrawn    ApplicationStatus = "withdrawn"
+)
+
+// ApplicationStatuses lists the pipeline in order.
+var ApplicationStatuses = []ApplicationStatus{
+	StatusDrafted, StatusApplied, StatusScreening, StatusInterviewing,
+	StatusOffer, StatusRejected, StatusWithdrawn,
+}
+
+// ParseApplicationStatus accepts a status name or an unambiguous prefix of
+// one, such as "int" for interviewing.
+func ParseApplicationStatus(s string) (ApplicationStatus, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	var found []ApplicationStatus
+	for _, status := range ApplicationStatuses {
+		if string(status) == s {
+			return status, nil
+		}
+		if s != "" && strings.HasPrefix(string(status), s) {
+			found = append(found, status)
+		}
+	}
+	if len(found) == 1 {
+		return found[0], nil
+	}
+	return "", fmt.Errorf("unknown application status %q", s)
+}
+
+// Closed reports whether the application has ended.
+func (s ApplicationStatus) Closed() bool {
+	return s == StatusRejected || s == StatusWithdrawn
+}
+
+// Title returns the status for display, such as "Interviewing".
+func (s ApplicationStatus) Title() string {
+	if s == "" {
+		return ""
+	}
+	return strings.ToUpper(string(s[:1])) + string(s[1:])
+}
+
+// ApplicationEvent is a dated entry in the history of an application. Events
+// without a status are notes.
+type ApplicationEvent struct {
+	At     time.Time         `toml:"at"`
+	Status ApplicationStatus `toml:"status,omitempty"`
+	Note   string            `toml:"note,omitempty"`
+}
+
+// Application tracks where the application made with an output stands.
+type Application struct {
+	Status ApplicationStatus  `toml:"status,omitempty"`
+	Events []ApplicationEvent `toml:"events,omitempty"`
+}
+
+// CurrentStatus returns the status, outputs without one are drafts.
+func (a Application) CurrentStatus() ApplicationStatus {
+	if a.Status == "" {
+		return StatusDrafted
+	}
+	return a.Status
+}
+
+// SetStatus moves the application to status, recording the change with an
+// optional note. It reports whether the status changed.
+func (a *Application) SetStatus(status ApplicationStatus, note string, at time.Time) bool {
+	if status == a.CurrentStatus() {
+		if note != "" {
+			a.AddNote(note, at)
+		}
+		return false
+	}
+	a.Status = status
+	a.Events = append(a.Events, ApplicationEvent{At: at.Truncate(time.Second), Status: status, Note: strings.TrimSpace(note)})
+	return true
+}
+
+// AddNote records a note without changing the status.
+func (a *Application) AddNote(note string, at time.Time) {
+	if note = strings.TrimSpace(note); note != "" {
+		a.Events = append(a.Events, ApplicationEvent{At: at.Truncate(time.Second), Note: note})
+	}
+}
+
+// Since returns when the current status was reached, or the zero time for
+// drafts that never changed status.
+func (a Application) Since() time.Time {
+	for i := len(a.Events) - 1; i >= 0; i-- {
+		if a.Events[i].Status != "" {
+			return a.Events[i].At
+		}
+	}
+	return time.Time{}
+}
+
+// Reached returns when status was first reached.
+func (a Application) Reached(status ApplicationStatus) (time.Time, bool) {
+	for _, e := range a.Events {
+		if e.Status == status {
+			return e.At, true
+		}
+	}
+	return time.Time{}, false
+}
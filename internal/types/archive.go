@@ -0,0 +1,527 @@
+package types
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// ArchiveFormatVersion is bumped whenever the layout of exported archives
+// changes in a way older versions cannot read.
+const ArchiveFormatVersion = 1
+
+const (
+	manifestName  = "manifest.toml"
+	archiveRoot   = "project"
+	maxImportSize = 512 << 20
+)
+
+var ErrUnsupportedArchive = errors.New("unsupported archive format, use .tar.gz, .tgz or .zip")
+
+// ArchiveManifest describes the project stored in an exported archive.
+type ArchiveManifest struct {
+	FormatVersion int       `toml:"format_version"`
+	Name          string    `toml:"name"`
+	CreatedAt     time.Time `toml:"created_at"`
+	ExportedAt    time.Time `toml:"exported_at"`
+	Files         []string  `toml:"files"`
+}
+
+type archiveKind int
+
+const (
+	archiveTarGz archiveKind = iota
+	archiveZip
+)
+
+func archiveKindFor(p string) (archiveKind, error) {
+	lower := strings.ToLower(p)
+	switch {
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return archiveTarGz, nil
+	case strings.HasSuffix(lower, ".zip"):
+		return archiveZip, nil
+	}
+	return 0, ErrUnsupportedArchive
+}
+
+// findProject returns the index of the named project.
+func (pm *ProjectManager) findProject(name string) (int, error) {
+	for i, p := range pm.Projects {
+		if p.Name == name {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("%w: '%s'", ErrProjectNotFound, name)
+}
+
+// ExportProject writes the named project directory, together with a manifest,
+// to a single archive at dest. The format is chosen from the file extension.
+func (pm *ProjectManager) ExportProject(name, dest string) error {
+	idx, err := pm.findProject(name)
+	if err != nil {
+		return err
+	}
+	return WriteArchive(pm.Projects[idx], dest)
+}
+
+// WriteArchive writes the directory of project to an archive at dest like
+// ExportProject. It does not touch the project list, so it can run in the
+// background.
+func WriteArchive(project Project, dest string) error {
+	kind, err := archiveKindFor(dest)
+	if err != nil {
+		return err
+	}
+
+	files, err := projectFiles(project.Path)
+	if err != nil {
+		return fmt.Errorf("failed to list project files: %w", err)
+	}
+
+	manifest := ArchiveManifest{
+		FormatVersion: ArchiveFormatVersion,
+		Name:          project.Name,
+		CreatedAt:     project.CreatedAt,
+		ExportedAt:    time.Now(),
+		Files:         files,
+	}
+	manifestData, err := toml.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	f, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer f.Close()
+
+	switch kind {
+	case archiveZip:
+		err = writeZip(f, project.Path, files, manifestData)
+	default:
+		err = writeTarGz(f, project.Path, files, manifestData)
+	}
+	if err != nil {
+		os.Remove(dest)
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	return f.Close()
+}
+
+// projectFiles lists every regular file below dir as slash-separated paths
+// relative to dir.
+func projectFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// Version control metadata is local to each machine.
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	return files, err
+}
+
+func writeTarGz(w io.Writer, dir string, files []string, manifest []byte) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	now := time.Now()
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    manifestName,
+		Mode:    0644,
+		Size:    int64(len(manifest)),
+		ModTime: now,
+	}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(manifest); err != nil {
+		return err
+	}
+
+	for _, name := range files {
+		if err := addTarFile(tw, dir, name); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func addTarFile(tw *tar.Writer, dir, name string) error {
+	f, err := os.Open(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	hdr, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	hdr.Name = path.Join(archiveRoot, name)
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+func writeZip(w io.Writer, dir string, files []string, manifest []byte) error {
+	zw := zip.NewWriter(w)
+
+	mw, err := zw.Create(manifestName)
+	if err != nil {
+		return err
+	}
+	if _, err := mw.Write(manifest); err != nil {
+		return err
+	}
+
+	for _, name := range files {
+		if err := addZipFile(zw, dir, name); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func addZipFile(zw *zip.Writer, dir, name string) error {
+	f, err := os.Open(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	hdr, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	hdr.Name = path.Join(archiveRoot, name)
+	hdr.Method = zip.Deflate
+	fw, err := zw.CreateHeader(hdr)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fw, f)
+	return err
+}
+
+// ImportProject unpacks an archive created by ExportProject into a new
+// project directory and registers it. If a project with the same name already
+// exists, a numeric suffix is appended to the imported project's name.
+func (pm *ProjectManager) ImportProject(archivePath string) (Project, error) {
+	x, err := pm.ExtractArchive(archivePath)
+	if err != nil {
+		return Project{}, err
+	}
+	return pm.AddExtracted(x)
+}
+
+// ExtractedArchive is a project archive unpacked to a staging directory,
+// waiting to be added to the project list with AddExtracted.
+type ExtractedArchive struct {
+	staging  string
+	manifest ArchiveManifest
+}
+
+// ExtractArchive unpacks and checks an archive created by ExportProject. It
+// does not touch the project list, so the slow part of an import can run in
+// the background.
+func (pm *ProjectManager) ExtractArchive(archivePath string) (*ExtractedArchive, error) {
+	kind, err := archiveKindFor(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	staging, err := os.MkdirTemp(pm.baseDir, ".import-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	x := &ExtractedArchive{staging: staging}
+	if err := x.extract(archivePath, kind); err != nil {
+		x.Discard()
+		return nil, err
+	}
+	return x, nil
+}
+
+func (x *ExtractedArchive) extract(archivePath string, kind archiveKind) error {
+	var manifestData []byte
+	var err error
+	switch kind {
+	case archiveZip:
+		manifestData, err = extractZip(archivePath, x.staging)
+	default:
+		manifestData, err = extractTarGz(archivePath, x.staging)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to extract archive: %w", err)
+	}
+	if manifestData == nil {
+		return errors.New("archive has no manifest")
+	}
+
+	manifest := &x.manifest
+	if err := toml.Unmarshal(manifestData, manifest); err != nil {
+		return fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if manifest.FormatVersion > ArchiveFormatVersion {
+		return fmt.Errorf("archive format version %d is newer than supported version %d",
+			manifest.FormatVersion, ArchiveFormatVersion)
+	}
+	if err := CheckProjectName(manifest.Name); err != nil {
+		return fmt.Errorf("invalid manifest: %w", err)
+	}
+
+	extracted := filepath.Join(x.staging, archiveRoot)
+	if err := checkArchivedConfig(extracted); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(extracted, 0755); err != nil {
+		return fmt.Errorf("failed to create project directory: %w", err)
+	}
+	return nil
+}
+
+// Discard removes the staging directory of an archive that is not added.
+func (x *ExtractedArchive) Discard() {
+	os.RemoveAll(x.staging)
+}
+
+// AddExtracted moves an extracted project into place and registers it, under
+// a new name when its own is taken. The staging directory is removed either
+// way.
+func (pm *ProjectManager) AddExtracted(x *ExtractedArchive) (Project, error) {
+	defer x.Discard()
+
+	projectsDir := filepath.Join(pm.baseDir, "projects")
+	if err := os.MkdirAll(projectsDir, 0755); err != nil {
+		return Project{}, fmt.Errorf("failed to create projects directory: %w", err)
+	}
+
+	name := pm.uniqueProjectName(x.manifest.Name)
+	project := Project{
+		Name:       name,
+		Path:       filepath.Join(projectsDir, name),
+		CreatedAt:  x.manifest.CreatedAt,
+		LastOpened: time.Now(),
+	}
+	if project.CreatedAt.IsZero() {
+		project.CreatedAt = time.Now()
+	}
+
+	if err := os.Rename(filepath.Join(x.staging, archiveRoot), project.Path); err != nil {
+		return Project{}, fmt.Errorf("failed to move project into place: %w", err)
+	}
+
+	// The project config stores its own name, keep it in line with the
+	// possibly renamed project.
+	if config, err := LoadProjectConfig(project.Path); err == nil && config.Name != name {
+		config.Name = name
+		if err := SaveProjectConfig(project.Path, config); err != nil {
+			return Project{}, err
+		}
+	}
+
+	pm.Projects = append(pm.Projects, project)
+	if err := pm.SaveConfig(); err != nil {
+		return Project{}, fmt.Errorf("failed to save config: %w", err)
+	}
+	return project, nil
+}
+
+// checkArchivedConfig rejects an extracted project whose project.toml points
+// outside the project directory, before anything is read or written through
+// it.
+func checkArchivedConfig(dir string) error {
+	data, err := os.ReadFile(filepath.Join(dir, ProjectConfigFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read project config: %w", err)
+	}
+	var config ProjectConfig
+	if err := toml.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to parse project config: %w", err)
+	}
+	if err := config.checkPaths(); err != nil {
+		return fmt.Errorf("invalid project config in archive: %w", err)
+	}
+	return nil
+}
+
+// uniqueProjectName returns name, or name with the lowest free " (n)" suffix
+// if the name or its directory is already taken.
+func (pm *ProjectManager) uniqueProjectName(name string) string {
+	taken := func(candidate string) bool {
+		if _, err := pm.findProject(candidate); err == nil {
+			return true
+		}
+		_, err := os.Stat(filepath.Join(pm.baseDir, "projects", candidate))
+		return err == nil
+	}
+
+	candidate := name
+	for n := 2; taken(candidate); n++ {
+		candidate = fmt.Sprintf("%s (%d)", name, n)
+	}
+	return candidate
+}
+
+// archiveTarget validates an archive entry name and returns where it should
+// be written below dest. Absolute paths and entries escaping dest are
+// rejected.
+func archiveTarget(dest, name string) (string, error) {
+	clean := path.Clean(strings.ReplaceAll(name, "\\", "/"))
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("invalid path in archive: %s", name)
+	}
+	return filepath.Join(dest, filepath.FromSlash(clean)), nil
+}
+
+// writeArchiveFile copies r to target, creating parent directories. It reads
+// at most limit bytes and returns how many were written.
+func writeArchiveFile(target string, r io.Reader, limit int64) (int64, error) {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return 0, err
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	n, err := io.Copy(f, io.LimitReader(r, limit+1))
+	if err != nil {
+		return n, err
+	}
+	if n > limit {
+		return n, errors.New("archive exceeds maximum import size")
+	}
+	return n, f.Close()
+}
+
+func extractTarGz(archivePath, dest string) ([]byte, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	var manifest []byte
+	var total int64
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if hdr.Name == manifestName {
+			if manifest, err = io.ReadAll(io.LimitReader(tr, 1<<20)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		target, err := archiveTarget(dest, hdr.Name)
+		if err != nil {
+			return nil, err
+		}
+		n, err := writeArchiveFile(target, tr, maxImportSize-total)
+		if err != nil {
+			return nil, err
+		}
+		total += n
+	}
+	return manifest, nil
+}
+
+func extractZip(archivePath, dest string) ([]byte, error) {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	var manifest []byte
+	var total int64
+	for _, zf := range zr.File {
+		if !zf.Mode().IsRegular() {
+			continue
+		}
+
+		rc, err := zf.Open()
+		if err != nil {
+			return nil, err
+		}
+		if zf.Name == manifestName {
+			manifest, err = io.ReadAll(io.LimitReader(rc, 1<<20))
+			rc.Close()
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		target, err := archiveTarget(dest, zf.Name)
+		if err != nil {
+			rc.Close()
+			return nil, err
+		}
+		n, err := writeArchiveFile(target, rc, maxImportSize-total)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		total += n
+	}
+	return manifest, nil
+}
@@ -12,6 +12,10 @@ const (
 	StateProjectOverview
 	StateSettings
 	StateLLMManager
+	StateBatch
+	StateCompare
+	StateBoard
+	StatePreview
 )
 
 type Model interface {
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/FabricSoul/auto-resume/internal/latex"
+)
+
+// OutputTexPath returns the path of the generated LaTeX of an output.
+func OutputTexPath(projectDir string, out Output) string {
+	return filepath.Join(OutputDir(projectDir, out.Slug), OutputResumeFile)
+}
+
+// OutputPDFPath returns where the compiled PDF of an output is written.
+func OutputPDFPath(projectDir string, out Output) string {
+	return filepath.Join(OutputDir(projectDir, out.Slug), "resume.pdf")
+}
+
+// CoverLetterTexPath returns the path the LaTeX cover letter of an output is
+// rendered to.
+func CoverLetterTexPath(projectDir string, out Output) string {
+	return filepath.Join(OutputDir(projectDir, out.Slug), "cover_letter.tex")
+}
+
+// AppendOutput adds out to the project stored in projectDir and saves it. The
+// returned output has its slug assigned. The project stays locked from load
+// to save, so concurrent saves in the process are not lost.
+func AppendOutput(projectDir string, out Output) (Output, error) {
+	unlock := lockProject(projectDir)
+	defer unlock()
+	config, err := loadProjectConfig(projectDir, true)
+	if err != nil {
+		return Output{}, fmt.Errorf("failed to load project config: %w", err)
+	}
+	config.Outputs = append(config.Outputs, out)
+	if err := saveProjectConfig(projectDir, config); err != nil {
+		return Output{}, fmt.Errorf("failed to save project config: %w", err)
+	}
+	return config.Outputs[len(config.Outputs)-1], nil
+}
+
+// CompileOutput compiles the generated LaTeX of out to PDF using the
+// project's engine. Files imported alongside the base resume are found
+// through the project directory.
+func CompileOutput(ctx context.Context, projectDir string, config ProjectConfig, out Output) (string, error) {
+	if out.GeneratedOutput == "" {
+		return "", fmt.Errorf("output '%s' has not been generated yet", out.Name)
+	}
+	if out.Slug == "" {
+		return "", fmt.Errorf("output '%s' has not been saved yet", out.Name)
+	}
+
+	texPath := OutputTexPath(projectDir, out)
+	if err := os.MkdirAll(filepath.Dir(texPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := writeFileAtomic(texPath, []byte(out.GeneratedOutput)); err != nil {
+		return "", fmt.Errorf("failed to write generated resume: %w", err)
+	}
+
+	return latex.Compile(ctx, texPath, latex.CompileOptions{
+		Engine:      config.Engine,
+		SearchPaths: []string{projectDir},
+	})
+}
@@ -5,4 +5,7 @@ import "errors"
 var (
 	ErrEmptyProjectName = errors.New("project name cannot be empty")
 	ErrEmptyModelName   = errors.New("model name cannot be empty")
+	ErrProjectNotFound  = errors.New("project not found")
+	ErrModelNotFound    = errors.New("model not found")
+	ErrOutputNotFound   = errors.New("output not found")
 )
@@ -0,0 +1,150 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// Seniority levels from least to most senior, used to sort outputs.
+var SeniorityLevels = []string{"Intern", "Junior", "Mid", "Senior", "Lead", "Staff", "Principal", "Manager", "Director", "Executive"}
+
+// JobMetadata is structured information extracted from a job description.
+// It is stored in project.toml under each output.
+type JobMetadata struct {
+	Company   string      `toml:"company,omitempty"`
+	Title     string      `toml:"title,omitempty"`
+	Location  string      `toml:"location,omitempty"`
+	Seniority string      `toml:"seniority,omitempty"`
+	Salary    SalaryRange `toml:"salary,omitempty"`
+	// Required and NiceToHave list the skills or requirements asked for.
+	Required   []string       `toml:"required,omitempty"`
+	NiceToHave []string       `toml:"nice_to_have,omitempty"`
+	Deadline   toml.LocalDate `toml:"deadline,omitempty"`
+	// ExtractedBy is "rules" or the name of the model that refined the
+	// metadata.
+	ExtractedBy string `toml:"extracted_by,omitempty"`
+}
+
+// SalaryRange is a pay range in whole units of Currency per Period.
+type SalaryRange struct {
+	Min      int    `toml:"min,omitempty"`
+	Max      int    `toml:"max,omitempty"`
+	Currency string `toml:"currency,omitempty"`
+	// Period is "year", "month" or "hour".
+	Period string `toml:"period,omitempty"`
+}
+
+// IsZero reports whether no metadata was extracted.
+func (j JobMetadata) IsZero() bool {
+	return j.Company == "" && j.Title == "" && j.Location == "" && j.Seniority == "" &&
+		j.Salary.IsZero() && len(j.Required) == 0 && len(j.NiceToHave) == 0 && !j.HasDeadline()
+}
+
+// HasDeadline reports whether an application deadline is known.
+func (j JobMetadata) HasDeadline() bool {
+	return j.Deadline != toml.LocalDate{}
+}
+
+// DeadlineTime returns the deadline at the end of that day in local time.
+func (j JobMetadata) DeadlineTime() (time.Time, bool) {
+	if !j.HasDeadline() {
+		return time.Time{}, false
+	}
+	return j.Deadline.AsTime(time.Local).Add(24*time.Hour - time.Second), true
+}
+
+// SeniorityRank orders seniority levels, unknown levels sort first.
+func (j JobMetadata) SeniorityRank() int {
+	for i, level := range SeniorityLevels {
+		if strings.EqualFold(level, j.Seniority) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// Merge returns j with the non-empty fields of other taking precedence.
+func (j JobMetadata) Merge(other JobMetadata) JobMetadata {
+	pick := func(a, b string) string {
+		if b != "" {
+			return b
+		}
+		return a
+	}
+	j.Company = pick(j.Company, other.Company)
+	j.Title = pick(j.Title, other.Title)
+	j.Location = pick(j.Location, other.Location)
+	j.Seniority = pick(j.Seniority, other.Seniority)
+	j.ExtractedBy = pick(j.ExtractedBy, other.ExtractedBy)
+	if !other.Salary.IsZero() {
+		j.Salary = other.Salary
+	}
+	if len(other.Required) > 0 {
+		j.Required = other.Required
+	}
+	if len(other.NiceToHave) > 0 {
+		j.NiceToHave = other.NiceToHave
+	}
+	if other.HasDeadline() {
+		j.Deadline = other.Deadline
+	}
+	return j
+}
+
+func (s SalaryRange) IsZero() bool {
+	return s.Min == 0 && s.Max == 0
+}
+
+// String formats the range like "USD 120k-150k / year".
+func (s SalaryRange) String() string {
+	if s.IsZero() {
+		return ""
+	}
+	amount := formatAmount(s.Min)
+	if s.Max != 0 && s.Max != s.Min {
+		if s.Min == 0 {
+			amount = "up to " + formatAmount(s.Max)
+		} else {
+			amount += "-" + formatAmount(s.Max)
+		}
+	}
+	if s.Currency != "" {
+		amount = s.Currency + " " + amount
+	}
+	if s.Period != "" {
+		amount += " / " + s.Period
+	}
+	return amount
+}
+
+func formatAmount(n int) string {
+	if n >= 1000 && n%1000 == 0 {
+		return fmt.Sprintf("%dk", n/1000)
+	}
+	if n >= 1000 {
+		return fmt.Sprintf("%.1fk", float64(n)/1000)
+	}
+	return fmt.Sprint(n)
+}
+
+// Annual returns the middle of the range per year, used to sort outputs by
+// pay. Currencies are not converted.
+func (s SalaryRange) Annual() int {
+	mid := s.Max
+	switch {
+	case s.Min != 0 && s.Max != 0:
+		mid = (s.Min + s.Max) / 2
+	case s.Min != 0:
+		mid = s.Min
+	}
+	switch s.Period {
+	case "hour":
+		return mid * 2080
+	case "month":
+		return mid * 12
+	}
+	return mid
+}
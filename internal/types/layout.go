@@ -0,0 +1,241 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// On-disk layout of a project directory:
+//
+//	project.toml              metadata and references
+//	resume.tex                base resume
+//	outputs/<slug>/job.md     job description of an output
+//	outputs/<slug>/resume.tex generated resume of an output
+//	outputs/<slug>/cover_letter.txt
+//	                          optional cover letter of an output
+//	outputs/<slug>/resume.{pdf,md,html,txt}
+//	                          exports of the generated resume
+const (
+	ProjectConfigFile = "project.toml"
+	DefaultResumeFile = "resume.tex"
+	OutputsDir        = "outputs"
+	JobFile           = "job.md"
+	OutputResumeFile  = "resume.tex"
+	CoverLetterFile   = "cover_letter.txt"
+
+	// legacyBackupFile keeps the original single-file config after migration.
+	legacyBackupFile = "project.toml.legacy"
+)
+
+// OutputDir returns the directory holding the files of the output with the
+// given slug.
+func OutputDir(projectDir, slug string) string {
+	return filepath.Join(projectDir, OutputsDir, slug)
+}
+
+// ResumePath returns the absolute path of the project's base resume file.
+func (c ProjectConfig) ResumePath(projectDir string) string {
+	name := c.ResumeFile
+	if name == "" {
+		name = DefaultResumeFile
+	}
+	return filepath.Join(projectDir, name)
+}
+
+// ValidSlug reports whether slug names a directory directly inside the
+// outputs directory.
+func ValidSlug(slug string) bool {
+	return filepath.IsLocal(slug) && !strings.ContainsAny(slug, `/\`)
+}
+
+// checkPaths rejects configs whose files would be read or written outside
+// the project directory, as a crafted project.toml could ask for.
+func (c ProjectConfig) checkPaths() error {
+	if c.ResumeFile != "" && !filepath.IsLocal(c.ResumeFile) {
+		return fmt.Errorf("resume file %q is outside the project directory", c.ResumeFile)
+	}
+	for _, out := range c.Outputs {
+		if out.Slug != "" && !ValidSlug(out.Slug) {
+			return fmt.Errorf("invalid slug %q of output %s", out.Slug, out.Name)
+		}
+	}
+	return nil
+}
+
+// legacyProjectConfig is the single-file format where all content was stored
+// inline in project.toml.
+type legacyProjectConfig struct {
+	ResumeInput string `toml:"resume_input"`
+	Outputs     []struct {
+		JobDescription  string `toml:"job_description"`
+		GeneratedOutput string `toml:"output"`
+	} `toml:"outputs"`
+}
+
+// migrateLegacyConfig moves inline content out of a single-file project.toml
+// into the split layout. The original file is kept as project.toml.legacy.
+// The caller holds the project lock.
+func migrateLegacyConfig(projectDir string, data []byte, config ProjectConfig) (ProjectConfig, error) {
+	var legacy legacyProjectConfig
+	if err := toml.Unmarshal(data, &legacy); err != nil {
+		return ProjectConfig{}, fmt.Errorf("failed to parse legacy project config: %w", err)
+	}
+
+	config.ResumeFile = DefaultResumeFile
+	config.ResumeInput = legacy.ResumeInput
+	for i := range config.Outputs {
+		if i < len(legacy.Outputs) {
+			config.Outputs[i].JobDescription = legacy.Outputs[i].JobDescription
+			config.Outputs[i].GeneratedOutput = legacy.Outputs[i].GeneratedOutput
+		}
+	}
+
+	if err := writeFileAtomic(filepath.Join(projectDir, legacyBackupFile), data); err != nil {
+		return ProjectConfig{}, fmt.Errorf("failed to back up legacy project config: %w", err)
+	}
+	if err := saveProjectConfig(projectDir, config); err != nil {
+		return ProjectConfig{}, fmt.Errorf("failed to migrate project config: %w", err)
+	}
+	return config, nil
+}
+
+// readProjectFiles fills the content fields of config from the files it
+// references. Missing files are treated as empty.
+func readProjectFiles(projectDir string, config *ProjectConfig) error {
+	var err error
+	if config.ResumeInput, err = readOptionalFile(config.ResumePath(projectDir)); err != nil {
+		return err
+	}
+	for i := range config.Outputs {
+		out := &config.Outputs[i]
+		if out.Slug == "" {
+			continue
+		}
+		dir := OutputDir(projectDir, out.Slug)
+		if out.JobDescription, err = readOptionalFile(filepath.Join(dir, JobFile)); err != nil {
+			return err
+		}
+		if out.GeneratedOutput, err = readOptionalFile(filepath.Join(dir, OutputResumeFile)); err != nil {
+			return err
+		}
+		if out.CoverLetter, err = readOptionalFile(filepath.Join(dir, CoverLetterFile)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeProjectFiles writes the content fields of config to their files.
+func writeProjectFiles(projectDir string, config ProjectConfig) error {
+	if err := writeFileAtomic(config.ResumePath(projectDir), []byte(config.ResumeInput)); err != nil {
+		return fmt.Errorf("failed to write resume: %w", err)
+	}
+	for _, out := range config.Outputs {
+		dir := OutputDir(projectDir, out.Slug)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		if err := writeFileAtomic(filepath.Join(dir, JobFile), []byte(out.JobDescription)); err != nil {
+			return fmt.Errorf("failed to write job description: %w", err)
+		}
+		if err := writeFileAtomic(filepath.Join(dir, OutputResumeFile), []byte(out.GeneratedOutput)); err != nil {
+			return fmt.Errorf("failed to write generated resume: %w", err)
+		}
+		// The cover letter is optional, so no empty file is left behind.
+		letterPath := filepath.Join(dir, CoverLetterFile)
+		if out.CoverLetter == "" {
+			if err := os.Remove(letterPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("failed to remove cover letter: %w", err)
+			}
+		} else if err := writeFileAtomic(letterPath, []byte(out.CoverLetter)); err != nil {
+			return fmt.Errorf("failed to write cover letter: %w", err)
+		}
+	}
+	return nil
+}
+
+func readOptionalFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+	return string(data), nil
+}
+
+// writeFileAtomic replaces path with data by writing a temporary file in the
+// same directory and renaming it, so a crash never leaves a half-written file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// AssignSlugs gives every output without a slug a unique one derived from its
+// name. Existing slugs are never changed so renaming an output keeps its files.
+func AssignSlugs(outputs []Output) {
+	used := make(map[string]bool)
+	for _, out := range outputs {
+		if out.Slug != "" {
+			used[out.Slug] = true
+		}
+	}
+	for i := range outputs {
+		if outputs[i].Slug != "" {
+			continue
+		}
+		base := Slugify(outputs[i].Name)
+		slug := base
+		for n := 2; used[slug]; n++ {
+			slug = fmt.Sprintf("%s-%d", base, n)
+		}
+		used[slug] = true
+		outputs[i].Slug = slug
+	}
+}
+
+// Slugify turns a name into a lowercase, filesystem-friendly identifier.
+func Slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	slug := strings.TrimSuffix(b.String(), "-")
+	if slug == "" {
+		return "output"
+	}
+	return slug
+}
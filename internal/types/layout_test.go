@@ -0,0 +1,97 @@
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// legacyConfig is a project.toml from before the split layout, with all
+// content inline.
+const legacyConfig = `name = "Legacy"
+model = "Model 1"
+resume_input = '\documentclass{article} % base'
+
+[[outputs]]
+name = "Acme Backend"
+job_description = "Go developer at Acme"
+output = '\documentclass{article} % Acme'
+
+[[outputs]]
+name = "Globex"
+job_description = "Platform engineer"
+output = ""
+`
+
+func TestMigrateLegacyConfig(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ProjectConfigFile), []byte(legacyConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Loading from several goroutines migrates once, every load sees the
+	// migrated project.
+	const n = 4
+	configs := make([]ProjectConfig, n)
+	var wg sync.WaitGroup
+	for i := range configs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var err error
+			if configs[i], err = LoadProjectConfig(dir); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, cfg := range configs {
+		if cfg.Name != "Legacy" || cfg.Model != "Model 1" || cfg.ResumeFile != DefaultResumeFile {
+			t.Errorf("config = %+v", cfg)
+		}
+		if cfg.ResumeInput != `\documentclass{article} % base` {
+			t.Errorf("resume = %q", cfg.ResumeInput)
+		}
+		if len(cfg.Outputs) != 2 {
+			t.Fatalf("loaded %d outputs, want 2", len(cfg.Outputs))
+		}
+		if out := cfg.Outputs[0]; out.Slug == "" || out.JobDescription != "Go developer at Acme" ||
+			out.GeneratedOutput != `\documentclass{article} % Acme` {
+			t.Errorf("output = %+v", out)
+		}
+		if out := cfg.Outputs[1]; out.Slug == "" || out.Slug == cfg.Outputs[0].Slug || out.JobDescription != "Platform engineer" {
+			t.Errorf("output = %+v", out)
+		}
+	}
+
+	backup, err := os.ReadFile(filepath.Join(dir, legacyBackupFile))
+	if err != nil || string(backup) != legacyConfig {
+		t.Errorf("backup = %q, %v", backup, err)
+	}
+	slug := configs[0].Outputs[0].Slug
+	tests := []struct {
+		path string
+		want string
+	}{
+		{DefaultResumeFile, `\documentclass{article} % base`},
+		{filepath.Join(OutputsDir, slug, JobFile), "Go developer at Acme"},
+		{filepath.Join(OutputsDir, slug, OutputResumeFile), `\documentclass{article} % Acme`},
+	}
+	for _, tt := range tests {
+		data, err := os.ReadFile(filepath.Join(dir, tt.path))
+		if err != nil || string(data) != tt.want {
+			t.Errorf("%s = %q, %v, want %q", tt.path, data, err, tt.want)
+		}
+	}
+
+	// Loading again reads the split layout.
+	cfg, err := LoadProjectConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Outputs[0].Slug != slug || cfg.Outputs[0].GeneratedOutput != `\documentclass{article} % Acme` {
+		t.Errorf("reloaded output = %+v", cfg.Outputs[0])
+	}
+}
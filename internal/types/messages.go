@@ -1,5 +1,7 @@
 package types
 
+import tea "github.com/charmbracelet/bubbletea"
+
 type TransitionMsg struct {
 	To     Appstate
 	Params interface{}
@@ -17,7 +19,18 @@ type FloatDismissMsg struct{}
 type ShowFloatInputMsg struct {
 	Prompt       string
 	InitialValue string
-	Callback     func(string)
+	// Multiline switches the input to a multi-line editor where enter
+	// inserts a newline and ctrl+s confirms.
+	Multiline bool
+	Callback  func(string)
+	// Submit, when set, runs after Callback and lets the caller act on the
+	// confirmed value with a command.
+	Submit func(string) tea.Cmd
 }
 
-type GenerationCompleteMsg struct{}
+// EditorFinishedMsg carries the text saved in the external editor back to the
+// field that opened it.
+type EditorFinishedMsg struct {
+	Value    string
+	Callback func(string)
+}
@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/FabricSoul/auto-resume/internal/latex"
 	"github.com/pelletier/go-toml/v2"
 )
 
@@ -27,8 +31,16 @@ type Config struct {
 	UserConfigPath string    `toml:"user_config_path"`
 	Projects       []Project `toml:"projects"`
 	Models         []AIModel `toml:"models"`
+	// Concurrency limits how many generations run at once per provider
+	// during batch generation.
+	Concurrency map[string]int `toml:"concurrency"`
+	// Reminders replace DefaultReminderRules when set.
+	Reminders []ReminderRule `toml:"reminders,omitempty"`
 }
 
+// DefaultConcurrency is used for providers without a configured limit.
+const DefaultConcurrency = 2
+
 type AIModel struct {
 	Name     string `toml:"name"`
 	Provider string `toml:"provider"`
@@ -109,7 +121,9 @@ func (pm *ProjectManager) SaveConfig() error {
 	config := Config{
 		UserConfigPath: "", // Default empty string as shown in spec
 		Projects:       pm.Projects,
-		Models:         []AIModel{}, // Empty for now, will be implemented later
+		Models:         pm.GetModels(),
+		Concurrency:    pm.concurrencyLimits(),
+		Reminders:      pm.configuredReminders(),
 	}
 
 	data, err := toml.Marshal(config)
@@ -124,7 +138,23 @@ func (pm *ProjectManager) SaveConfig() error {
 	return nil
 }
 
+// CheckProjectName reports names that cannot name a directory below the
+// projects directory, such as "" or "..".
+func CheckProjectName(name string) error {
+	if name == "" {
+		return ErrEmptyProjectName
+	}
+	if strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return fmt.Errorf("invalid project name %q, it cannot be . or .. or contain / or \\", name)
+	}
+	return nil
+}
+
 func (pm *ProjectManager) AddProject(name string) error {
+	if err := CheckProjectName(name); err != nil {
+		return err
+	}
+
 	// Check if project with same name exists
 	for _, p := range pm.Projects {
 		if p.Name == name {
@@ -152,12 +182,7 @@ func (pm *ProjectManager) AddProject(name string) error {
 	}
 
 	// Create project-specific config file
-	projectConfig := filepath.Join(projectDir, "project.toml")
-	defaultProjectConfig := fmt.Sprintf(`name = "%s"
-model = ""
-resume_input = ""`, name)
-
-	if err := os.WriteFile(projectConfig, []byte(defaultProjectConfig), 0644); err != nil {
+	if err := SaveProjectConfig(projectDir, ProjectConfig{Name: name}); err != nil {
 		return fmt.Errorf("failed to create project config: %w", err)
 	}
 
@@ -171,6 +196,60 @@ resume_input = ""`, name)
 	return nil
 }
 
+// TouchProject records that the named project was just opened.
+func (pm *ProjectManager) TouchProject(name string) error {
+	for i := range pm.Projects {
+		if pm.Projects[i].Name == name {
+			pm.Projects[i].LastOpened = time.Now()
+			return pm.SaveConfig()
+		}
+	}
+	return fmt.Errorf("%w: '%s'", ErrProjectNotFound, name)
+}
+
+// GetProject returns the project with the given name.
+func (pm *ProjectManager) GetProject(name string) (Project, error) {
+	idx, err := pm.findProject(name)
+	if err != nil {
+		return Project{}, err
+	}
+	return pm.Projects[idx], nil
+}
+
+// DeleteProject removes the named project from the config and, when
+// removeFiles is set, deletes its directory.
+func (pm *ProjectManager) DeleteProject(name string, removeFiles bool) error {
+	idx, err := pm.findProject(name)
+	if err != nil {
+		return err
+	}
+	project := pm.Projects[idx]
+	if removeFiles && !pm.inProjectsDir(project.Path) {
+		return fmt.Errorf("refusing to delete %s, it is not a project directory in %s",
+			project.Path, filepath.Join(pm.baseDir, "projects"))
+	}
+
+	pm.Projects = append(pm.Projects[:idx], pm.Projects[idx+1:]...)
+	if err := pm.SaveConfig(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if removeFiles {
+		if err := os.RemoveAll(project.Path); err != nil {
+			return fmt.Errorf("failed to remove project directory: %w", err)
+		}
+	}
+	return nil
+}
+
+// inProjectsDir reports whether path is a directory directly below the
+// projects directory, the only places DeleteProject removes.
+func (pm *ProjectManager) inProjectsDir(path string) bool {
+	projectsDir := filepath.Join(pm.baseDir, "projects")
+	path = filepath.Clean(path)
+	return filepath.Dir(path) == projectsDir && CheckProjectName(filepath.Base(path)) == nil
+}
+
 func (pm *ProjectManager) LoadProjects() error {
 	// TODO: Implement loading projects from disk
 	return nil
@@ -190,6 +269,48 @@ func (pm *ProjectManager) GetModels() []AIModel {
 	return config.Models
 }
 
+// ProviderConcurrency returns how many generations may run at once against
+// provider.
+func (pm *ProjectManager) ProviderConcurrency(provider string) int {
+	if n := pm.concurrencyLimits()[provider]; n > 0 {
+		return n
+	}
+	return DefaultConcurrency
+}
+
+func (pm *ProjectManager) concurrencyLimits() map[string]int {
+	data, err := os.ReadFile(pm.configPath)
+	if err != nil {
+		return nil
+	}
+	var config Config
+	if err := toml.Unmarshal(data, &config); err != nil {
+		return nil
+	}
+	return config.Concurrency
+}
+
+// ReminderRules returns the reminder rules of config.toml, or the defaults
+// when none are configured.
+func (pm *ProjectManager) ReminderRules() []ReminderRule {
+	if rules := pm.configuredReminders(); len(rules) > 0 {
+		return rules
+	}
+	return DefaultReminderRules
+}
+
+func (pm *ProjectManager) configuredReminders() []ReminderRule {
+	data, err := os.ReadFile(pm.configPath)
+	if err != nil {
+		return nil
+	}
+	var config Config
+	if err := toml.Unmarshal(data, &config); err != nil {
+		return nil
+	}
+	return config.Reminders
+}
+
 func (pm *ProjectManager) SaveModels(models []AIModel) error {
 	data, err := os.ReadFile(pm.configPath)
 	if err != nil {
@@ -213,24 +334,82 @@ func (pm *ProjectManager) SaveModels(models []AIModel) error {
 	return nil
 }
 
-// Output represents a single targeted resume output.
+// Output represents a single targeted resume output. The job description and
+// the generated resume live in files below outputs/<slug>/ rather than in
+// project.toml.
 type Output struct {
-	Name            string `toml:"name"`
-	JobDescription  string `toml:"job_description"`
-	GeneratedOutput string `toml:"output"`
+	Name            string    `toml:"name"`
+	Slug            string    `toml:"slug"`
+	JobDescription  string    `toml:"-"`
+	GeneratedOutput string    `toml:"-"`
+	CoverLetter     string    `toml:"-"`
+	GeneratedAt     time.Time `toml:"generated_at"`
+	// Job holds the details extracted from the job description.
+	Job JobMetadata `toml:"job,omitempty"`
+	// Application tracks the application made with this output.
+	Application Application `toml:"application,omitempty"`
+	// ResumeFingerprint identifies the base resume the output was generated
+	// from, so outputs can be flagged once the base resume changes.
+	ResumeFingerprint latex.Fingerprint `toml:"resume_fingerprint"`
+}
+
+// StaleSections reports whether the output was generated from a different
+// version of resume, and which sections differ. Outputs that were never
+// generated are not stale.
+func (o Output) StaleSections(resume latex.Fingerprint) ([]string, bool) {
+	if o.ResumeFingerprint.IsZero() || o.ResumeFingerprint.Hash == resume.Hash {
+		return nil, false
+	}
+	return o.ResumeFingerprint.ChangedSections(resume), true
 }
 
 // ProjectConfig represents the project-specific configuration that is stored in project.toml.
+// Only metadata and file references are written to project.toml, the LaTeX
+// and job descriptions are stored next to it (see layout.go).
 type ProjectConfig struct {
-	Name        string   `toml:"name"`
-	Model       string   `toml:"model"`
-	ResumeInput string   `toml:"resume_input"`
+	Name       string `toml:"name"`
+	Model      string `toml:"model"`
+	ResumeFile string `toml:"resume_file"`
+	// ResumeSource is the .tex file, directory or resume.json the resume was
+	// imported from, so it can be imported again after it changes.
+	ResumeSource string `toml:"resume_source"`
+	// ResumeTemplate is the template a resume.json is rendered to LaTeX
+	// with, a built-in name or a .tex file. Empty for LaTeX imports.
+	ResumeTemplate string `toml:"resume_template,omitempty"`
+	// Engine is the LaTeX engine used to compile outputs, empty picks the
+	// first one installed.
+	Engine string `toml:"engine"`
+	// PageLimit is the number of pages outputs must fit on. Longer outputs
+	// are shortened by the model after compiling, 0 disables the check.
+	PageLimit   int      `toml:"page_limit,omitempty"`
+	ResumeInput string   `toml:"-"`
 	Outputs     []Output `toml:"outputs"`
 }
 
+// LastGeneratedAt returns the time of the most recent generation, or the zero
+// time if nothing has been generated yet.
+func (c ProjectConfig) LastGeneratedAt() time.Time {
+	var last time.Time
+	for _, out := range c.Outputs {
+		if out.GeneratedAt.After(last) {
+			last = out.GeneratedAt
+		}
+	}
+	return last
+}
+
 // LoadProjectConfig loads the project-specific configuration from project.toml in the given directory.
+// Projects still using the single-file format, with all content inline, are
+// migrated to the split layout on first load, with the project locked like
+// SaveProjectConfig does.
 func LoadProjectConfig(projectDir string) (ProjectConfig, error) {
-	configPath := filepath.Join(projectDir, "project.toml")
+	return loadProjectConfig(projectDir, false)
+}
+
+// loadProjectConfig is LoadProjectConfig, locked tells whether the caller
+// holds the project lock already.
+func loadProjectConfig(projectDir string, locked bool) (ProjectConfig, error) {
+	configPath := filepath.Join(projectDir, ProjectConfigFile)
 	data, err := os.ReadFile(configPath)
 	if err != nil {
 		return ProjectConfig{}, fmt.Errorf("failed to read project config: %w", err)
@@ -239,26 +418,111 @@ func LoadProjectConfig(projectDir string) (ProjectConfig, error) {
 	if err := toml.Unmarshal(data, &config); err != nil {
 		return ProjectConfig{}, fmt.Errorf("failed to parse project config: %w", err)
 	}
+	if err := config.checkPaths(); err != nil {
+		return ProjectConfig{}, fmt.Errorf("invalid project config: %w", err)
+	}
+
+	if config.ResumeFile == "" {
+		if !locked {
+			// Read again once locked, a writer waited for may have
+			// migrated the project already.
+			unlock := lockProject(projectDir)
+			defer unlock()
+			return loadProjectConfig(projectDir, true)
+		}
+		return migrateLegacyConfig(projectDir, data, config)
+	}
+
+	if err := readProjectFiles(projectDir, &config); err != nil {
+		return ProjectConfig{}, err
+	}
 	return config, nil
 }
 
+// projectLocks serialises writes of the same project within the process,
+// such as a batch adding outputs while the project screen saves.
+var projectLocks sync.Map // cleaned project directory -> *sync.Mutex
+
+// lockProject locks the project in projectDir and returns the unlock.
+func lockProject(projectDir string) func() {
+	v, _ := projectLocks.LoadOrStore(filepath.Clean(projectDir), &sync.Mutex{})
+	mu := v.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
 // SaveProjectConfig saves the given ProjectConfig to project.toml in the specified directory.
+// Resume and output content is written to separate files first. Outputs
+// without a slug are given one; because Outputs is a slice, the assigned
+// slugs are visible to the caller as well. Outputs saved by someone else
+// since config was loaded are kept, see mergeSavedOutputs.
 func SaveProjectConfig(projectDir string, config ProjectConfig) error {
-	configPath := filepath.Join(projectDir, "project.toml")
+	unlock := lockProject(projectDir)
+	defer unlock()
+	return saveProjectConfig(projectDir, config)
+}
+
+// saveProjectConfig is SaveProjectConfig for callers holding the lock.
+func saveProjectConfig(projectDir string, config ProjectConfig) error {
+	if config.ResumeFile == "" {
+		config.ResumeFile = DefaultResumeFile
+	}
+	own := len(config.Outputs)
+	config.Outputs = mergeSavedOutputs(projectDir, config.Outputs)
+	AssignSlugs(config.Outputs)
+	if err := config.checkPaths(); err != nil {
+		return fmt.Errorf("invalid project config: %w", err)
+	}
+
+	// Merged outputs are listed but their files, written by whoever added
+	// them, are left alone.
+	files := config
+	files.Outputs = config.Outputs[:own]
+	if err := writeProjectFiles(projectDir, files); err != nil {
+		return err
+	}
+
 	data, err := toml.Marshal(config)
 	if err != nil {
 		return fmt.Errorf("failed to marshal project config: %w", err)
 	}
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
+	if err := writeFileAtomic(filepath.Join(projectDir, ProjectConfigFile), data); err != nil {
 		return fmt.Errorf("failed to write project config: %w", err)
 	}
 	return nil
 }
 
+// mergeSavedOutputs appends the outputs of the project.toml on disk that are
+// missing from outputs. Outputs are never removed, so those were added since
+// outputs was loaded, by a batch or another process, and saving must not
+// drop them. Their content is not read, only their entries are kept.
+func mergeSavedOutputs(projectDir string, outputs []Output) []Output {
+	data, err := os.ReadFile(filepath.Join(projectDir, ProjectConfigFile))
+	if err != nil {
+		return outputs
+	}
+	var saved ProjectConfig
+	if err := toml.Unmarshal(data, &saved); err != nil || saved.checkPaths() != nil {
+		return outputs
+	}
+	known := make(map[string]bool, len(outputs))
+	for _, out := range outputs {
+		known[out.Slug] = true
+	}
+	// Clipped so appending never writes into the caller's array.
+	merged := slices.Clip(outputs)
+	for _, out := range saved.Outputs {
+		if out.Slug != "" && !known[out.Slug] {
+			merged = append(merged, out)
+		}
+	}
+	return merged
+}
+
 // Add this method to ProjectManager
 func (pm *ProjectManager) CreateProject(name string) error {
-	if name == "" {
-		return ErrEmptyProjectName
+	if err := CheckProjectName(name); err != nil {
+		return err
 	}
 
 	project := Project{
@@ -0,0 +1,150 @@
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// TestSaveKeepsAddedOutputs saves a project loaded before a batch added an
+// output, which must not drop the batch's output or its files.
+func TestSaveKeepsAddedOutputs(t *testing.T) {
+	dir := t.TempDir()
+	if err := SaveProjectConfig(dir, ProjectConfig{Name: "p", Outputs: []Output{{Name: "First", GeneratedOutput: "first"}}}); err != nil {
+		t.Fatal(err)
+	}
+	stale, err := LoadProjectConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AppendOutput(dir, Output{Name: "Batch", GeneratedOutput: "batch"}); err != nil {
+		t.Fatal(err)
+	}
+
+	stale.Outputs[0].GeneratedOutput = "first, edited"
+	if err := SaveProjectConfig(dir, stale); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := LoadProjectConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"First": "first, edited", "Batch": "batch"}
+	if len(saved.Outputs) != len(want) {
+		t.Fatalf("saved %d outputs, want %d", len(saved.Outputs), len(want))
+	}
+	for _, out := range saved.Outputs {
+		if out.GeneratedOutput != want[out.Name] {
+			t.Errorf("output %s = %q, want %q", out.Name, out.GeneratedOutput, want[out.Name])
+		}
+	}
+}
+
+func TestConcurrentAppendOutput(t *testing.T) {
+	dir := t.TempDir()
+	if err := SaveProjectConfig(dir, ProjectConfig{Name: "p"}); err != nil {
+		t.Fatal(err)
+	}
+	const n = 8
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := AppendOutput(dir, Output{Name: "Job", GeneratedOutput: "tex"}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	saved, err := LoadProjectConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Outputs) != n {
+		t.Errorf("saved %d outputs, want %d", len(saved.Outputs), n)
+	}
+	slugs := make(map[string]bool)
+	for _, out := range saved.Outputs {
+		if slugs[out.Slug] {
+			t.Errorf("slug %s used twice", out.Slug)
+		}
+		slugs[out.Slug] = true
+	}
+}
+
+func TestLoadRejectsPathsOutsideProject(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ProjectConfig
+	}{
+		{"resume file", ProjectConfig{Name: "p", ResumeFile: "../resume.tex"}},
+		{"output slug", ProjectConfig{Name: "p", Outputs: []Output{{Name: "o", Slug: "../o"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SaveProjectConfig(t.TempDir(), tt.config); err == nil {
+				t.Error("saved a config pointing outside the project")
+			}
+		})
+	}
+}
+
+func TestAddProjectRejectsInvalidNames(t *testing.T) {
+	base := t.TempDir()
+	pm := &ProjectManager{baseDir: base, configPath: filepath.Join(base, "config.toml")}
+	for _, name := range []string{"", ".", "..", "../x", "a/b", `a\b`} {
+		t.Run(name, func(t *testing.T) {
+			if err := pm.AddProject(name); err == nil {
+				t.Errorf("AddProject(%q) succeeded", name)
+			}
+		})
+	}
+	if len(pm.Projects) != 0 {
+		t.Errorf("added %d projects", len(pm.Projects))
+	}
+	if _, err := os.Stat(pm.configPath); !os.IsNotExist(err) {
+		t.Errorf("config written for invalid names: %v", err)
+	}
+}
+
+func TestDeleteProjectOutsideProjectsDir(t *testing.T) {
+	base := t.TempDir()
+	pm := &ProjectManager{baseDir: base, configPath: filepath.Join(base, "config.toml")}
+	if err := pm.AddProject("kept"); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"data directory", filepath.Join(base, "projects", "..")},
+		{"projects directory", filepath.Join(base, "projects")},
+		{"nested directory", filepath.Join(base, "projects", "kept", "outputs")},
+		{"elsewhere", t.TempDir()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.MkdirAll(tt.path, 0755); err != nil {
+				t.Fatal(err)
+			}
+			pm.Projects = append(pm.Projects, Project{Name: tt.name, Path: tt.path})
+			if err := pm.DeleteProject(tt.name, true); err == nil {
+				t.Error("deleted a directory that is not a project")
+			}
+			if _, err := os.Stat(tt.path); err != nil {
+				t.Errorf("directory removed: %v", err)
+			}
+		})
+	}
+
+	if err := pm.DeleteProject("kept", true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(base, "projects", "kept")); !os.IsNotExist(err) {
+		t.Errorf("project directory left behind: %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package types
+
+// ReminderRule configures when an output needs attention. A rule with
+// AfterDays fires that many days after the last activity on applications in
+// Status; a rule with BeforeDeadline fires that many days before the job
+// deadline of applications in Status, drafted if unset.
+type ReminderRule struct {
+	Name           string            `toml:"name,omitempty"`
+	Status         ApplicationStatus `toml:"status,omitempty"`
+	AfterDays      int               `toml:"after_days,omitempty"`
+	BeforeDeadline int               `toml:"before_deadline,omitempty"`
+}
+
+// DefaultReminderRules are used when config.toml has no reminders.
+var DefaultReminderRules = []ReminderRule{
+	{Name: "Follow up", Status: StatusApplied, AfterDays: 7},
+	{Name: "Deadline", BeforeDeadline: 2},
+}
+
+// IsDeadline reports whether the rule is about the job deadline.
+func (r ReminderRule) IsDeadline() bool {
+	return r.BeforeDeadline > 0
+}
+
+// Title returns the name of the rule for display.
+func (r ReminderRule) Title() string {
+	switch {
+	case r.Name != "":
+		return r.Name
+	case r.IsDeadline():
+		return "Deadline"
+	}
+	return "Follow up"
+}
+
+// AppliesTo reports whether the rule covers applications in status.
+func (r ReminderRule) AppliesTo(status ApplicationStatus) bool {
+	if r.Status == "" && r.IsDeadline() {
+		return status == StatusDrafted
+	}
+	return r.Status == status
+}
@@ -2,24 +2,77 @@ package ui
 
 import "github.com/charmbracelet/lipgloss"
 
+// The colors and styles of the current theme, set by Apply.
 var (
 	// Base colors
-	Subtle    = lipgloss.AdaptiveColor{Light: "#D9DCCF", Dark: "#383838"}
-	Highlight = lipgloss.AdaptiveColor{Light: "#874BFD", Dark: "#7D56F4"}
-	Special   = lipgloss.AdaptiveColor{Light: "#43BF6D", Dark: "#73F59F"}
-	Error     = lipgloss.AdaptiveColor{Light: "#FF0000", Dark: "#FF4444"}
+	Subtle    lipgloss.TerminalColor
+	Highlight lipgloss.TerminalColor
+	Special   lipgloss.TerminalColor
+	Error     lipgloss.TerminalColor
 
 	// Base styles
+	BaseList     lipgloss.Style
+	BaseDetails  lipgloss.Style
+	Title        lipgloss.Style
+	Help         lipgloss.Style
+	SelectedItem lipgloss.Style
+	ErrorBox     lipgloss.Style
+	ErrorTitle   lipgloss.Style
+	Input        lipgloss.Style
+
+	// AppBackground style to be applied across the entire app. It keeps the
+	// terminal's colors unless the theme sets them.
+	AppBackground lipgloss.Style
+
+	FloatBox        lipgloss.Style
+	JoinedContainer lipgloss.Style
+
+	// Job status styles used by progress tables.
+	StatusQueued  lipgloss.Style
+	StatusRunning lipgloss.Style
+	StatusDone    lipgloss.Style
+	StatusFailed  lipgloss.Style
+
+	// Columns of the application board.
+	BoardColumn       lipgloss.Style
+	BoardColumnActive lipgloss.Style
+)
+
+// current is the theme last applied.
+var current Theme
+
+func init() {
+	Apply(Presets()[0])
+}
+
+// Current returns the theme in use.
+func Current() Theme {
+	return current
+}
+
+// Apply switches the styles to the colors of t. With NO_COLOR set, styles
+// keep their borders and emphasis but no colors.
+func Apply(t Theme) {
+	current = t
+	if NoColor() {
+		t = t.colorless()
+	}
+
+	Subtle = t.Subtle
+	Highlight = t.Highlight
+	Special = t.Special
+	Error = t.Error
+
 	BaseList = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(Subtle).
-			Padding(1).
-			MarginRight(2)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(Subtle).
+		Padding(1).
+		MarginRight(2)
 
 	BaseDetails = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(Subtle).
-			Padding(1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(Subtle).
+		Padding(1)
 
 	Title = lipgloss.NewStyle().
 		Foreground(Highlight).
@@ -32,37 +85,49 @@ var (
 		MarginTop(1)
 
 	SelectedItem = lipgloss.NewStyle().
-			Foreground(Special).
-			Bold(true)
+		Foreground(Special).
+		Bold(true)
 
 	ErrorBox = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(Error).
-			Padding(1).
-			Width(50)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(Error).
+		Padding(1).
+		Width(50)
 
 	ErrorTitle = lipgloss.NewStyle().
-			Foreground(Error).
-			Bold(true).
-			MarginBottom(1)
+		Foreground(Error).
+		Bold(true).
+		MarginBottom(1)
 
 	Input = lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
 		BorderForeground(Subtle).
 		Padding(1)
 
-	// AppBackground style to be applied across the entire app.
 	AppBackground = lipgloss.NewStyle().
-			Background(lipgloss.Color("#1E1E1E")). // A solid dark color.
-			Foreground(lipgloss.Color("#FFFFFF"))  // Use white text for high contrast.
+		Background(t.Background).
+		Foreground(t.Foreground)
 
 	FloatBox = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(Highlight).
-			Padding(2).
-			Width(60)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(Highlight).
+		Padding(2).
+		Width(60)
 
 	JoinedContainer = lipgloss.NewStyle().
-			Background(lipgloss.Color("#1E1E1E")).
-			Padding(1)
-)
+		Background(t.Background).
+		Padding(1)
+
+	StatusQueued = lipgloss.NewStyle().Foreground(Subtle)
+	StatusRunning = lipgloss.NewStyle().Foreground(Highlight).Bold(true)
+	StatusDone = lipgloss.NewStyle().Foreground(Special)
+	StatusFailed = lipgloss.NewStyle().Foreground(Error)
+
+	BoardColumn = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(Subtle).
+		Padding(0, 1)
+
+	BoardColumnActive = BoardColumn.
+		BorderForeground(Highlight)
+}
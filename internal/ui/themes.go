@@ -0,0 +1,278 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	catppuccin "github.com/catppuccin/go"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// Theme holds the colors the styles are built from.
+type Theme struct {
+	Name string
+	// Path is the file of a user theme, empty for the bundled ones.
+	Path string
+
+	Subtle    lipgloss.TerminalColor // borders and help
+	Highlight lipgloss.TerminalColor // titles and focus
+	Special   lipgloss.TerminalColor // selection and success
+	Error     lipgloss.TerminalColor
+
+	// Foreground and Background paint the whole screen. Bundled themes
+	// leave them unset so the terminal's colors show through.
+	Foreground lipgloss.TerminalColor
+	Background lipgloss.TerminalColor
+}
+
+// Builtin reports whether the theme is bundled with auto-resume.
+func (t Theme) Builtin() bool {
+	return t.Path == ""
+}
+
+// colorless returns t without any colors, for NO_COLOR.
+func (t Theme) colorless() Theme {
+	none := lipgloss.NoColor{}
+	t.Subtle, t.Highlight, t.Special, t.Error = none, none, none, none
+	t.Foreground, t.Background = none, none
+	return t
+}
+
+// NoColor reports whether the NO_COLOR environment variable asks for output
+// without colors.
+func NoColor() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// adaptive picks light or dark by the terminal's background.
+func adaptive(light, dark string) lipgloss.TerminalColor {
+	return lipgloss.AdaptiveColor{Light: light, Dark: dark}
+}
+
+// catppuccinColor picks the Latte color on light terminals and the Mocha
+// one on dark terminals.
+func catppuccinColor(c func(catppuccin.Flavour) catppuccin.Color) lipgloss.TerminalColor {
+	return adaptive(c(catppuccin.Latte).Hex, c(catppuccin.Mocha).Hex)
+}
+
+// Presets returns the bundled themes, the first is used when the user config
+// does not pick one.
+func Presets() []Theme {
+	none := lipgloss.NoColor{}
+	themes := []Theme{
+		{
+			// auto follows the terminal: light colors on a light
+			// background, dark ones otherwise.
+			Name:      "auto",
+			Subtle:    adaptive("#9B9B9B", "#626262"),
+			Highlight: adaptive("#874BFD", "#7D56F4"),
+			Special:   adaptive("#2E9E52", "#73F59F"),
+			Error:     adaptive("#D70000", "#FF4444"),
+		},
+		{
+			Name:      "light",
+			Subtle:    lipgloss.Color("#9B9B9B"),
+			Highlight: lipgloss.Color("#874BFD"),
+			Special:   lipgloss.Color("#2E9E52"),
+			Error:     lipgloss.Color("#D70000"),
+		},
+		{
+			Name:      "dark",
+			Subtle:    lipgloss.Color("#626262"),
+			Highlight: lipgloss.Color("#7D56F4"),
+			Special:   lipgloss.Color("#73F59F"),
+			Error:     lipgloss.Color("#FF4444"),
+		},
+		{
+			Name:      "high-contrast",
+			Subtle:    adaptive("#3A3A3A", "#D0D0D0"),
+			Highlight: adaptive("#0000D7", "#FFFF00"),
+			Special:   adaptive("#005F00", "#00FF00"),
+			Error:     adaptive("#AF0000", "#FF5F5F"),
+		},
+		{
+			Name:      "catppuccin",
+			Subtle:    catppuccinColor(catppuccin.Flavour.Overlay0),
+			Highlight: catppuccinColor(catppuccin.Flavour.Mauve),
+			Special:   catppuccinColor(catppuccin.Flavour.Green),
+			Error:     catppuccinColor(catppuccin.Flavour.Red),
+		},
+	}
+	for i := range themes {
+		themes[i].Foreground, themes[i].Background = none, none
+	}
+	return themes
+}
+
+// themeKeys are the colors a theme file may set.
+var themeKeys = []string{"subtle", "highlight", "special", "error", "foreground", "background"}
+
+// hexColor matches #RGB and #RRGGBB.
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// ParseTheme reads a theme file. Colors are hex values or ANSI color numbers,
+// or a table with a light and a dark color picked by the terminal's
+// background. Colors the file leaves out come from the preset named by
+// base, auto unless set.
+func ParseTheme(name string, data []byte) (Theme, error) {
+	var raw map[string]any
+	if err := toml.Unmarshal(data, &raw); err != nil {
+		return Theme{}, fmt.Errorf("theme %s: %w", name, err)
+	}
+
+	t := Presets()[0]
+	if base, ok := raw["base"]; ok {
+		s, _ := base.(string)
+		p, ok := preset(s)
+		if !ok {
+			return Theme{}, fmt.Errorf("theme %s: unknown base %q", name, s)
+		}
+		t = p
+		delete(raw, "base")
+	}
+	t.Name = name
+
+	fields := map[string]*lipgloss.TerminalColor{
+		"subtle":     &t.Subtle,
+		"highlight":  &t.Highlight,
+		"special":    &t.Special,
+		"error":      &t.Error,
+		"foreground": &t.Foreground,
+		"background": &t.Background,
+	}
+	var unknown []string
+	for key, value := range raw {
+		field, ok := fields[key]
+		if !ok {
+			unknown = append(unknown, key)
+			continue
+		}
+		c, err := parseColor(value)
+		if err != nil {
+			return Theme{}, fmt.Errorf("theme %s: %s: %w", name, key, err)
+		}
+		*field = c
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return Theme{}, fmt.Errorf("theme %s: unknown keys %s, use base or %s",
+			name, strings.Join(unknown, ", "), strings.Join(themeKeys, ", "))
+	}
+	return t, nil
+}
+
+// parseColor reads a color string or a light/dark table.
+func parseColor(value any) (lipgloss.TerminalColor, error) {
+	switch v := value.(type) {
+	case string:
+		if err := checkColor(v); err != nil {
+			return nil, err
+		}
+		return lipgloss.Color(v), nil
+	case map[string]any:
+		light, _ := v["light"].(string)
+		dark, _ := v["dark"].(string)
+		if len(v) != 2 || light == "" || dark == "" {
+			return nil, errors.New("a table needs exactly a light and a dark color")
+		}
+		for _, c := range []string{light, dark} {
+			if err := checkColor(c); err != nil {
+				return nil, err
+			}
+		}
+		return adaptive(light, dark), nil
+	}
+	return nil, fmt.Errorf("expected a color, got %v", value)
+}
+
+// checkColor accepts hex colors and ANSI color numbers.
+func checkColor(c string) error {
+	if hexColor.MatchString(c) {
+		return nil
+	}
+	if n, err := strconv.Atoi(c); err == nil && n >= 0 && n <= 255 {
+		return nil
+	}
+	return fmt.Errorf("invalid color %q, use #RRGGBB or 0-255", c)
+}
+
+// preset returns a bundled theme by name.
+func preset(name string) (Theme, bool) {
+	for _, t := range Presets() {
+		if strings.EqualFold(t.Name, name) {
+			return t, true
+		}
+	}
+	return Theme{}, false
+}
+
+// UserThemes loads the *.toml themes in dir, which may not exist. Themes
+// that fail to load are skipped and reported in the error.
+func UserThemes(dir string) ([]Theme, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read themes: %w", err)
+	}
+
+	var list []Theme
+	var errs []error
+	for _, e := range entries {
+		if e.IsDir() || !strings.EqualFold(filepath.Ext(e.Name()), ".toml") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read theme: %w", err))
+			continue
+		}
+		t, err := ParseTheme(strings.TrimSuffix(e.Name(), filepath.Ext(e.Name())), data)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		t.Path = path
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return strings.ToLower(list[i].Name) < strings.ToLower(list[j].Name)
+	})
+	return list, errors.Join(errs...)
+}
+
+// Themes returns the bundled themes followed by the user's.
+func Themes(dir string) ([]Theme, error) {
+	user, err := UserThemes(dir)
+	return append(Presets(), user...), err
+}
+
+// FindTheme returns the theme with the given name, preferring the user's.
+// An empty name is the default theme.
+func FindTheme(dir, name string) (Theme, error) {
+	if name == "" {
+		return Presets()[0], nil
+	}
+	user, err := UserThemes(dir)
+	for _, t := range user {
+		if strings.EqualFold(t.Name, name) {
+			return t, nil
+		}
+	}
+	if t, ok := preset(name); ok {
+		return t, nil
+	}
+	if err != nil {
+		return Theme{}, err
+	}
+	return Theme{}, fmt.Errorf("unknown theme %q", name)
+}
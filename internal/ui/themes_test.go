@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestParseTheme(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		wantErr   string
+		highlight lipgloss.TerminalColor
+		subtle    lipgloss.TerminalColor
+	}{
+		{
+			name:      "defaults to auto",
+			data:      `highlight = "#FF0000"`,
+			highlight: lipgloss.Color("#FF0000"),
+			subtle:    adaptive("#9B9B9B", "#626262"),
+		},
+		{
+			name:      "base preset",
+			data:      "base = \"dark\"\nhighlight = \"212\"",
+			highlight: lipgloss.Color("212"),
+			subtle:    lipgloss.Color("#626262"),
+		},
+		{
+			name:      "light and dark",
+			data:      `highlight = { light = "#000", dark = "#fff" }`,
+			highlight: adaptive("#000", "#fff"),
+		},
+		{name: "invalid TOML", data: `highlight = `, wantErr: "theme mine"},
+		{name: "unknown base", data: `base = "solarized"`, wantErr: `unknown base "solarized"`},
+		{name: "base is not a string", data: `base = 1`, wantErr: "unknown base"},
+		{name: "unknown keys", data: "accent = \"#fff\"\nborder = \"#000\"", wantErr: "unknown keys accent, border"},
+		{name: "invalid color", data: `error = "red"`, wantErr: `error: invalid color "red"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme, err := ParseTheme("mine", []byte(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if theme.Name != "mine" {
+				t.Errorf("name = %q, want mine", theme.Name)
+			}
+			if theme.Highlight != tt.highlight {
+				t.Errorf("highlight = %v, want %v", theme.Highlight, tt.highlight)
+			}
+			if tt.subtle != nil && theme.Subtle != tt.subtle {
+				t.Errorf("subtle = %v, want %v", theme.Subtle, tt.subtle)
+			}
+		})
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    lipgloss.TerminalColor
+		wantErr bool
+	}{
+		{name: "short hex", value: "#abc", want: lipgloss.Color("#abc")},
+		{name: "hex", value: "#A0B1C2", want: lipgloss.Color("#A0B1C2")},
+		{name: "ANSI", value: "0", want: lipgloss.Color("0")},
+		{name: "ANSI 255", value: "255", want: lipgloss.Color("255")},
+		{name: "table", value: map[string]any{"light": "#fff", "dark": "16"}, want: adaptive("#fff", "16")},
+		{name: "ANSI 256", value: "256", wantErr: true},
+		{name: "negative", value: "-1", wantErr: true},
+		{name: "hex without #", value: "A0B1C2", wantErr: true},
+		{name: "four hex digits", value: "#abcd", wantErr: true},
+		{name: "table without dark", value: map[string]any{"light": "#fff"}, wantErr: true},
+		{name: "table with extra key", value: map[string]any{"light": "#fff", "dark": "#000", "ansi": "1"}, wantErr: true},
+		{name: "invalid color in table", value: map[string]any{"light": "#fff", "dark": "black"}, wantErr: true},
+		{name: "number", value: int64(12), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseColor(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseColor(%v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
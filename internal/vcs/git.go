@@ -0,0 +1,214 @@
+// Package vcs keeps project directories under version control by driving the
+// locally installed git binary.
+package vcs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrGitNotFound = errors.New("git executable not found in PATH")
+
+// Fallback identity used when the user has not configured git, so automatic
+// commits never fail on a fresh machine.
+const (
+	fallbackName  = "auto-resume"
+	fallbackEmail = "auto-resume@localhost"
+)
+
+// Commit is a single entry of a repository's history.
+type Commit struct {
+	Hash      string
+	ShortHash string
+	Author    string
+	Date      time.Time
+	Subject   string
+}
+
+// Repo is a git repository rooted at a project directory.
+type Repo struct {
+	Dir string
+}
+
+// Available reports whether a git executable can be found.
+func Available() bool {
+	_, err := exec.LookPath("git")
+	return err == nil
+}
+
+// IsRepo reports whether dir is the root of a git repository.
+func IsRepo(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// Open returns the repository rooted at dir, or nil if dir is not under
+// version control or git is not installed.
+func Open(dir string) *Repo {
+	if !IsRepo(dir) || !Available() {
+		return nil
+	}
+	return &Repo{Dir: dir}
+}
+
+// Init turns dir into a git repository and records its current contents as
+// the first commit.
+func Init(dir string) (*Repo, error) {
+	if !Available() {
+		return nil, ErrGitNotFound
+	}
+	r := &Repo{Dir: dir}
+	if !IsRepo(dir) {
+		if _, err := r.git("init", "--quiet"); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.CommitAll("Initialise project history"); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// CommitAll stages every change in the working tree and commits it with the
+// given message. It is a no-op when there is nothing to commit.
+func (r *Repo) CommitAll(message string) error {
+	if _, err := r.git("add", "--all"); err != nil {
+		return err
+	}
+	status, err := r.git("status", "--porcelain")
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(status) == "" {
+		return nil
+	}
+	_, err = r.git(append(r.identity(), "commit", "--quiet", "-m", message)...)
+	return err
+}
+
+// Log returns up to limit commits, newest first.
+func (r *Repo) Log(limit int) ([]Commit, error) {
+	out, err := r.git("log", "-n", strconv.Itoa(limit), "--format=%H%x1f%h%x1f%an%x1f%at%x1f%s")
+	if err != nil {
+		// A repository without commits has no history yet.
+		if strings.Contains(err.Error(), "does not have any commits") {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var commits []Commit
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) != 5 {
+			continue
+		}
+		unix, _ := strconv.ParseInt(fields[3], 10, 64)
+		commits = append(commits, Commit{
+			Hash:      fields[0],
+			ShortHash: fields[1],
+			Author:    fields[2],
+			Date:      time.Unix(unix, 0),
+			Subject:   fields[4],
+		})
+	}
+	return commits, nil
+}
+
+// Restore checks out the given paths as they were at rev. The restored files
+// are left staged but not committed.
+func (r *Repo) Restore(rev string, paths ...string) error {
+	args := append([]string{"checkout", rev, "--"}, paths...)
+	_, err := r.git(args...)
+	return err
+}
+
+// identity returns extra arguments that supply a committer identity when the
+// user has not configured one.
+func (r *Repo) identity() []string {
+	if name, err := r.git("config", "user.name"); err == nil && strings.TrimSpace(name) != "" {
+		if email, err := r.git("config", "user.email"); err == nil && strings.TrimSpace(email) != "" {
+			return nil
+		}
+	}
+	return []string{"-c", "user.name=" + fallbackName, "-c", "user.email=" + fallbackEmail}
+}
+
+func (r *Repo) git(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.Dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", subcommand(args), msg)
+	}
+	return stdout.String(), nil
+}
+
+// subcommand returns the git command args run, skipping the -c options
+// before it.
+func subcommand(args []string) string {
+	for i := 0; i < len(args); i++ {
+		if args[i] == "-c" {
+			i++
+			continue
+		}
+		return args[i]
+	}
+	return ""
+}
+
+// CommitIfEnabled commits every change in dir with message when dir is under
+// version control, and does nothing otherwise.
+func CommitIfEnabled(dir, message string) error {
+	repo := Open(dir)
+	if repo == nil {
+		return nil
+	}
+	return repo.CommitAll(message)
+}
+
+// Exclude keeps files matching pattern out of the history of the repository
+// in dir without touching tracked files, by listing it in .git/info/exclude.
+// It does nothing when dir is not a repository.
+func Exclude(dir, pattern string) error {
+	if !IsRepo(dir) {
+		return nil
+	}
+	path := filepath.Join(dir, ".git", "info", "exclude")
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == pattern {
+			return nil
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if len(data) > 0 && !bytes.HasSuffix(data, []byte("\n")) {
+		pattern = "\n" + pattern
+	}
+	_, err = fmt.Fprintln(f, pattern)
+	return err
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// UserFile is the name of the user config inside UserDir.
+const UserFile = "config.toml"
+
+// ThemesDir is the directory of theme files inside UserDir.
+const ThemesDir = "themes"
+
+// TemplatesDir is the directory of resume templates inside UserDir.
+const TemplatesDir = "templates"
+
+// User holds the preferences of the user config, kept apart from the
+// application config with projects and models.
+type User struct {
+	// Keymap is the preset keys start from: default, vim or emacs.
+	Keymap string `toml:"keymap,omitempty"`
+	// Theme names a bundled theme or a file in ThemesDir without its
+	// extension.
+	Theme string `toml:"theme,omitempty"`
+	// Keys remaps actions to keys, such as add = ["a", "n"].
+	Keys map[string][]string `toml:"keys,omitempty"`
+}
+
+// UserDir returns the directory of the user config, auto-resume below
+// $XDG_CONFIG_HOME or ~/.config.
+func UserDir() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "auto-resume")
+	}
+	return filepath.Join(ExpandHome("~/.config"), "auto-resume")
+}
+
+// LoadUser reads the user config. A missing file is an empty config.
+func LoadUser() (User, error) {
+	var u User
+	data, err := os.ReadFile(filepath.Join(UserDir(), UserFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return u, nil
+	}
+	if err != nil {
+		return u, fmt.Errorf("failed to read user config: %w", err)
+	}
+	if err := toml.Unmarshal(data, &u); err != nil {
+		return u, fmt.Errorf("failed to parse user config: %w", err)
+	}
+	return u, nil
+}
+
+// themeLine matches the theme setting of the user config with the value
+// and any trailing comment.
+var themeLine = regexp.MustCompile(`^(\s*theme\s*=\s*)("(?:[^"\\]|\\.)*"|'[^']*')(.*)$`)
+
+// SetUserTheme sets the theme of the user config, creating the file and its
+// directory. Only the theme line is changed, the rest of an existing file is
+// kept as written, comments included.
+func SetUserTheme(name string) error {
+	dir := UserDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	path := filepath.Join(dir, UserFile)
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read user config: %w", err)
+	}
+
+	updated, err := setTheme(string(data), name)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+		return fmt.Errorf("failed to write user config: %w", err)
+	}
+	return nil
+}
+
+// setTheme returns the user config document with its theme set to name.
+// The setting is replaced where it is, or added before the first table.
+func setTheme(doc, name string) (string, error) {
+	value, err := toml.Marshal(map[string]string{"theme": name})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode user config: %w", err)
+	}
+	setting := strings.TrimSpace(string(value))
+
+	lines := strings.Split(doc, "\n")
+	table := len(lines)
+	replaced := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			table = i
+			break
+		}
+		if m := themeLine.FindStringSubmatch(line); m != nil {
+			_, quoted, _ := strings.Cut(setting, "=")
+			lines[i] = m[1] + strings.TrimSpace(quoted) + m[3]
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		// Keep blank lines between the settings and the first table.
+		at := table
+		for at > 0 && strings.TrimSpace(lines[at-1]) == "" {
+			at--
+		}
+		lines = slices.Insert(lines, at, setting)
+	}
+	updated := strings.Join(lines, "\n")
+	if !strings.HasSuffix(updated, "\n") {
+		updated += "\n"
+	}
+
+	var u User
+	if err := toml.Unmarshal([]byte(updated), &u); err != nil || u.Theme != name {
+		return "", fmt.Errorf("failed to set the theme in %s, set theme = %q by hand", UserFile, name)
+	}
+	return updated, nil
+}
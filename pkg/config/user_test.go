@@ -0,0 +1,65 @@
+package config
+
+import "testing"
+
+func TestSetTheme(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{
+			name: "empty file",
+			doc:  "",
+			want: "theme = 'dark'\n",
+		},
+		{
+			name: "replaces the setting",
+			doc:  "keymap = \"vim\"\ntheme = \"light\" # picked in settings\n",
+			want: "keymap = \"vim\"\ntheme = 'dark' # picked in settings\n",
+		},
+		{
+			name: "adds before the first table",
+			doc:  "# my config\nkeymap = \"vim\"\n\n[keys]\ntheme = [\"t\"]\n",
+			want: "# my config\nkeymap = \"vim\"\ntheme = 'dark'\n\n[keys]\ntheme = [\"t\"]\n",
+		},
+		{
+			name: "only tables",
+			doc:  "[keys]\nadd = [\"a\"]",
+			want: "theme = 'dark'\n[keys]\nadd = [\"a\"]\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := setTheme(tt.doc, "dark")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("setTheme() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetThemeInvalidConfig(t *testing.T) {
+	if _, err := setTheme("keymap = ", "dark"); err == nil {
+		t.Error("expected an error for a config that does not parse")
+	}
+}
+
+func TestSetUserTheme(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	for _, name := range []string{"light", "dark"} {
+		if err := SetUserTheme(name); err != nil {
+			t.Fatal(err)
+		}
+		u, err := LoadUser()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.Theme != name {
+			t.Errorf("theme = %q, want %q", u.Theme, name)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Package diff computes line based differences between two texts.
+package diff
+
+import "strings"
+
+// Op is the kind of change of a line.
+type Op int
+
+const (
+	Equal Op = iota
+	Insert
+	Delete
+)
+
+// Line is a single line of a diff.
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// String renders the line with a unified diff prefix.
+func (l Line) String() string {
+	switch l.Op {
+	case Insert:
+		return "+ " + l.Text
+	case Delete:
+		return "- " + l.Text
+	default:
+		return "  " + l.Text
+	}
+}
+
+// maxCells bounds the size of the comparison table. Larger inputs are
+// compared after trimming the common prefix and suffix only, with the rest
+// reported as replaced.
+const maxCells = 4_000_000
+
+// Lines returns the line diff turning a into b.
+func Lines(a, b string) []Line {
+	return Compute(splitLines(a), splitLines(b))
+}
+
+// Compute returns the diff turning a into b using the longest common
+// subsequence of lines.
+func Compute(a, b []string) []Line {
+	// Common prefix and suffix are equal lines, no need to compare them.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var out []Line
+	for _, l := range a[:prefix] {
+		out = append(out, Line{Equal, l})
+	}
+	out = append(out, middle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, l := range a[len(a)-suffix:] {
+		out = append(out, Line{Equal, l})
+	}
+	return out
+}
+
+func middle(a, b []string) []Line {
+	var out []Line
+	if len(a)*len(b) > maxCells {
+		for _, l := range a {
+			out = append(out, Line{Delete, l})
+		}
+		for _, l := range b {
+			out = append(out, Line{Insert, l})
+		}
+		return out
+	}
+
+	// lcs[i][j] is the length of the common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, Line{Equal, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, Line{Delete, a[i]})
+			i++
+		default:
+			out = append(out, Line{Insert, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, Line{Delete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		out = append(out, Line{Insert, b[j]})
+	}
+	return out
+}
+
+// Stats counts inserted and deleted lines.
+func Stats(lines []Line) (inserted, deleted int) {
+	for _, l := range lines {
+		switch l.Op {
+		case Insert:
+			inserted++
+		case Delete:
+			deleted++
+		}
+	}
+	return inserted, deleted
+}
+
+func splitLines(s string) []string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
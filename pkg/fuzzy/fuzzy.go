@@ -0,0 +1,78 @@
+// Package fuzzy implements a small subsequence matcher used to filter lists
+// incrementally as the user types.
+package fuzzy
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Match reports whether every rune of pattern appears in target in order,
+// ignoring case. The returned score is higher for tighter matches: runes that
+// follow each other directly or start a word are rewarded, gaps are penalised.
+func Match(pattern, target string) (int, bool) {
+	if pattern == "" {
+		return 0, true
+	}
+
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(target)
+
+	score := 0
+	pi := 0
+	last := -1
+	for ti, r := range t {
+		if pi == len(p) {
+			break
+		}
+		if unicode.ToLower(r) != p[pi] {
+			continue
+		}
+
+		switch {
+		case last == ti-1:
+			score += 5
+		case last >= 0:
+			score -= ti - last - 1
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 3
+		}
+		score++
+
+		last = ti
+		pi++
+	}
+
+	if pi < len(p) {
+		return 0, false
+	}
+	return score, true
+}
+
+// Filter returns the indices of items matching pattern, best match first.
+// Items with equal scores keep their original order.
+func Filter(pattern string, items []string) []int {
+	type hit struct {
+		index, score int
+	}
+
+	var hits []hit
+	for i, item := range items {
+		if score, ok := Match(pattern, item); ok {
+			hits = append(hits, hit{index: i, score: score})
+		}
+	}
+	if pattern != "" {
+		sort.SliceStable(hits, func(a, b int) bool {
+			return hits[a].score > hits[b].score
+		})
+	}
+
+	indices := make([]int, len(hits))
+	for i, h := range hits {
+		indices[i] = h.index
+	}
+	return indices
+}
@@ -0,0 +1,411 @@
+// Package textedit implements the editing logic behind the text input
+// component: a rune-based buffer with cursor movement, word-wise deletion
+// and undo. It knows nothing about rendering or key handling.
+package textedit
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxUndo bounds the number of snapshots kept for undo.
+const maxUndo = 200
+
+// editKind classifies edits so consecutive typing can be undone as one step.
+type editKind int
+
+const (
+	editNone editKind = iota
+	editInsert
+	editDelete
+	editOther
+)
+
+type snapshot struct {
+	lines    [][]rune
+	row, col int
+}
+
+// Buffer holds editable text as lines of runes, so every operation is UTF-8
+// safe. In single-line mode newlines are replaced by spaces.
+type Buffer struct {
+	lines     [][]rune
+	row, col  int
+	multiline bool
+
+	undo     []snapshot
+	redo     []snapshot
+	lastEdit editKind
+}
+
+// New returns a buffer holding value with the cursor at its end.
+func New(value string, multiline bool) *Buffer {
+	b := &Buffer{multiline: multiline}
+	b.setValue(value)
+	b.row = len(b.lines) - 1
+	b.col = len(b.lines[b.row])
+	return b
+}
+
+// Multiline reports whether the buffer accepts newlines.
+func (b *Buffer) Multiline() bool {
+	return b.multiline
+}
+
+// Value returns the buffer content.
+func (b *Buffer) Value() string {
+	parts := make([]string, len(b.lines))
+	for i, l := range b.lines {
+		parts[i] = string(l)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// SetValue replaces the content and moves the cursor to the end. The change
+// can be undone.
+func (b *Buffer) SetValue(value string) {
+	b.checkpoint(editOther)
+	b.setValue(value)
+	b.row = len(b.lines) - 1
+	b.col = len(b.lines[b.row])
+}
+
+func (b *Buffer) setValue(value string) {
+	value = normalize(value, b.multiline)
+	b.lines = nil
+	for _, l := range strings.Split(value, "\n") {
+		b.lines = append(b.lines, []rune(l))
+	}
+}
+
+// Lines returns the content split into lines. The result must not be
+// modified.
+func (b *Buffer) Lines() [][]rune {
+	return b.lines
+}
+
+// Cursor returns the zero-based line and column of the cursor.
+func (b *Buffer) Cursor() (row, col int) {
+	return b.row, b.col
+}
+
+// RuneCount returns the number of characters, counting newlines.
+func (b *Buffer) RuneCount() int {
+	n := len(b.lines) - 1
+	for _, l := range b.lines {
+		n += len(l)
+	}
+	return n
+}
+
+// LineCount returns the number of lines.
+func (b *Buffer) LineCount() int {
+	return len(b.lines)
+}
+
+// Insert inserts s at the cursor. Pasted text with CRLF line endings is
+// normalised.
+func (b *Buffer) Insert(s string) {
+	s = normalize(s, b.multiline)
+	if s == "" {
+		return
+	}
+	kind := editInsert
+	if strings.ContainsRune(s, '\n') || len([]rune(s)) > 1 {
+		// Pastes and new lines are their own undo step.
+		kind = editOther
+	}
+	b.checkpoint(kind)
+
+	parts := strings.Split(s, "\n")
+	line := b.lines[b.row]
+	tail := append([]rune{}, line[b.col:]...)
+	head := append(line[:b.col:b.col], []rune(parts[0])...)
+
+	if len(parts) == 1 {
+		b.lines[b.row] = append(head, tail...)
+		b.col = len(head)
+		return
+	}
+
+	newLines := [][]rune{head}
+	for _, p := range parts[1 : len(parts)-1] {
+		newLines = append(newLines, []rune(p))
+	}
+	last := []rune(parts[len(parts)-1])
+	newLines = append(newLines, append(last, tail...))
+
+	b.lines = append(b.lines[:b.row], append(newLines, b.lines[b.row+1:]...)...)
+	b.row += len(parts) - 1
+	b.col = len(last)
+}
+
+// Backspace deletes the character before the cursor, joining lines at the
+// start of a line.
+func (b *Buffer) Backspace() {
+	if b.col == 0 && b.row == 0 {
+		return
+	}
+	b.checkpoint(editDelete)
+	if b.col == 0 {
+		b.joinWithPrevious()
+		return
+	}
+	line := b.lines[b.row]
+	b.lines[b.row] = append(line[:b.col-1], line[b.col:]...)
+	b.col--
+}
+
+// Delete deletes the character under the cursor, joining lines at the end of
+// a line.
+func (b *Buffer) Delete() {
+	line := b.lines[b.row]
+	if b.col == len(line) {
+		if b.row == len(b.lines)-1 {
+			return
+		}
+		b.checkpoint(editDelete)
+		b.row++
+		b.col = 0
+		b.joinWithPrevious()
+		return
+	}
+	b.checkpoint(editDelete)
+	b.lines[b.row] = append(line[:b.col], line[b.col+1:]...)
+}
+
+// DeleteWordBackward deletes from the start of the previous word to the
+// cursor.
+func (b *Buffer) DeleteWordBackward() {
+	if b.col == 0 {
+		b.Backspace()
+		return
+	}
+	b.checkpoint(editOther)
+	line := b.lines[b.row]
+	start := wordStart(line, b.col)
+	b.lines[b.row] = append(line[:start], line[b.col:]...)
+	b.col = start
+}
+
+// DeleteWordForward deletes from the cursor to the end of the next word.
+func (b *Buffer) DeleteWordForward() {
+	line := b.lines[b.row]
+	if b.col == len(line) {
+		b.Delete()
+		return
+	}
+	b.checkpoint(editOther)
+	end := wordEnd(line, b.col)
+	b.lines[b.row] = append(line[:b.col], line[end:]...)
+}
+
+// DeleteToLineStart deletes from the start of the line to the cursor.
+func (b *Buffer) DeleteToLineStart() {
+	if b.col == 0 {
+		return
+	}
+	b.checkpoint(editOther)
+	b.lines[b.row] = append([]rune{}, b.lines[b.row][b.col:]...)
+	b.col = 0
+}
+
+// DeleteToLineEnd deletes from the cursor to the end of the line.
+func (b *Buffer) DeleteToLineEnd() {
+	if b.col == len(b.lines[b.row]) {
+		return
+	}
+	b.checkpoint(editOther)
+	b.lines[b.row] = b.lines[b.row][:b.col]
+}
+
+func (b *Buffer) joinWithPrevious() {
+	prev := b.lines[b.row-1]
+	b.col = len(prev)
+	b.lines[b.row-1] = append(prev, b.lines[b.row]...)
+	b.lines = append(b.lines[:b.row], b.lines[b.row+1:]...)
+	b.row--
+}
+
+// Left moves the cursor one character left, wrapping to the previous line.
+func (b *Buffer) Left() {
+	b.lastEdit = editNone
+	switch {
+	case b.col > 0:
+		b.col--
+	case b.row > 0:
+		b.row--
+		b.col = len(b.lines[b.row])
+	}
+}
+
+// Right moves the cursor one character right, wrapping to the next line.
+func (b *Buffer) Right() {
+	b.lastEdit = editNone
+	switch {
+	case b.col < len(b.lines[b.row]):
+		b.col++
+	case b.row < len(b.lines)-1:
+		b.row++
+		b.col = 0
+	}
+}
+
+// Up moves the cursor to the previous line.
+func (b *Buffer) Up() {
+	b.lastEdit = editNone
+	if b.row > 0 {
+		b.row--
+		b.clampCol()
+	}
+}
+
+// Down moves the cursor to the next line.
+func (b *Buffer) Down() {
+	b.lastEdit = editNone
+	if b.row < len(b.lines)-1 {
+		b.row++
+		b.clampCol()
+	}
+}
+
+// LineStart moves the cursor to the start of the line.
+func (b *Buffer) LineStart() {
+	b.lastEdit = editNone
+	b.col = 0
+}
+
+// LineEnd moves the cursor to the end of the line.
+func (b *Buffer) LineEnd() {
+	b.lastEdit = editNone
+	b.col = len(b.lines[b.row])
+}
+
+// Top moves the cursor to the start of the buffer.
+func (b *Buffer) Top() {
+	b.lastEdit = editNone
+	b.row, b.col = 0, 0
+}
+
+// Bottom moves the cursor to the end of the buffer.
+func (b *Buffer) Bottom() {
+	b.lastEdit = editNone
+	b.row = len(b.lines) - 1
+	b.col = len(b.lines[b.row])
+}
+
+// WordLeft moves the cursor to the start of the previous word.
+func (b *Buffer) WordLeft() {
+	if b.col == 0 {
+		b.Left()
+		return
+	}
+	b.lastEdit = editNone
+	b.col = wordStart(b.lines[b.row], b.col)
+}
+
+// WordRight moves the cursor to the end of the next word.
+func (b *Buffer) WordRight() {
+	if b.col == len(b.lines[b.row]) {
+		b.Right()
+		return
+	}
+	b.lastEdit = editNone
+	b.col = wordEnd(b.lines[b.row], b.col)
+}
+
+func (b *Buffer) clampCol() {
+	if b.col > len(b.lines[b.row]) {
+		b.col = len(b.lines[b.row])
+	}
+}
+
+// Undo reverts the last edit. It reports whether there was anything to undo.
+func (b *Buffer) Undo() bool {
+	if len(b.undo) == 0 {
+		return false
+	}
+	b.redo = append(b.redo, b.snapshot())
+	b.restore(b.undo[len(b.undo)-1])
+	b.undo = b.undo[:len(b.undo)-1]
+	return true
+}
+
+// Redo re-applies the last undone edit. It reports whether there was
+// anything to redo.
+func (b *Buffer) Redo() bool {
+	if len(b.redo) == 0 {
+		return false
+	}
+	b.undo = append(b.undo, b.snapshot())
+	b.restore(b.redo[len(b.redo)-1])
+	b.redo = b.redo[:len(b.redo)-1]
+	return true
+}
+
+// checkpoint records the current state before an edit of the given kind.
+// Runs of single-character inserts or deletes share one checkpoint.
+func (b *Buffer) checkpoint(kind editKind) {
+	if kind != editOther && kind == b.lastEdit {
+		return
+	}
+	b.lastEdit = kind
+	b.redo = nil
+	b.undo = append(b.undo, b.snapshot())
+	if len(b.undo) > maxUndo {
+		b.undo = b.undo[len(b.undo)-maxUndo:]
+	}
+}
+
+func (b *Buffer) snapshot() snapshot {
+	lines := make([][]rune, len(b.lines))
+	for i, l := range b.lines {
+		lines[i] = append([]rune{}, l...)
+	}
+	return snapshot{lines: lines, row: b.row, col: b.col}
+}
+
+func (b *Buffer) restore(s snapshot) {
+	b.lines = s.lines
+	b.row, b.col = s.row, s.col
+	b.lastEdit = editNone
+}
+
+func normalize(s string, multiline bool) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	if !multiline {
+		s = strings.ReplaceAll(s, "\n", " ")
+	}
+	return s
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// wordStart returns the index of the start of the word before col, skipping
+// any separators directly before the cursor.
+func wordStart(line []rune, col int) int {
+	i := col
+	for i > 0 && !isWordRune(line[i-1]) {
+		i--
+	}
+	for i > 0 && isWordRune(line[i-1]) {
+		i--
+	}
+	return i
+}
+
+// wordEnd returns the index just past the word after col, skipping any
+// separators directly after the cursor.
+func wordEnd(line []rune, col int) int {
+	i := col
+	for i < len(line) && !isWordRune(line[i]) {
+		i++
+	}
+	for i < len(line) && isWordRune(line[i]) {
+		i++
+	}
+	return i
+}
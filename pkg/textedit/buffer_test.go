@@ -0,0 +1,185 @@
+package textedit
+
+import "testing"
+
+// op is an editing step applied to a buffer in the tables below.
+type op func(*Buffer)
+
+func insert(s string) op { return func(b *Buffer) { b.Insert(s) } }
+
+func undo(b *Buffer) { b.Undo() }
+
+func redo(b *Buffer) { b.Redo() }
+
+func TestBuffer(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		multiline bool
+		ops       []op
+		want      string
+		row, col  int
+	}{
+		{
+			name:      "right wraps past a multi-byte line end",
+			value:     "héllo\nwörld",
+			multiline: true,
+			ops:       []op{(*Buffer).Top, (*Buffer).LineEnd, (*Buffer).Right, (*Buffer).Right, insert("X")},
+			want:      "héllo\nwXörld",
+			row:       1, col: 2,
+		},
+		{
+			name:      "left wraps to the end of a line ending in an emoji",
+			value:     "ab😀\ncd",
+			multiline: true,
+			ops:       []op{(*Buffer).Bottom, (*Buffer).LineStart, (*Buffer).Left, (*Buffer).Backspace},
+			want:      "ab\ncd",
+			row:       0, col: 2,
+		},
+		{
+			name:      "backspace joins lines of CJK runes",
+			value:     "日本\n語",
+			multiline: true,
+			ops:       []op{(*Buffer).LineStart, (*Buffer).Backspace},
+			want:      "日本語",
+			row:       0, col: 2,
+		},
+		{
+			name:      "delete joins at a multi-byte line end",
+			value:     "café\nbar",
+			multiline: true,
+			ops:       []op{(*Buffer).Top, (*Buffer).LineEnd, (*Buffer).Delete},
+			want:      "cafébar",
+			row:       0, col: 4,
+		},
+		{
+			name:      "up clamps to a shorter multi-byte line",
+			value:     "ñ\nlonger line",
+			multiline: true,
+			ops:       []op{(*Buffer).Up, insert("!")},
+			want:      "ñ!\nlonger line",
+			row:       0, col: 2,
+		},
+		{
+			name:  "word delete stops at punctuation",
+			value: "foo, bar.baz",
+			ops:   []op{(*Buffer).DeleteWordBackward},
+			want:  "foo, bar.",
+			row:   0, col: 9,
+		},
+		{
+			name:  "word delete skips punctuation before the word",
+			value: "foo, bar.baz",
+			ops:   []op{(*Buffer).DeleteWordBackward, (*Buffer).DeleteWordBackward},
+			want:  "foo, ",
+			row:   0, col: 5,
+		},
+		{
+			name:  "word delete of accented words",
+			value: "naïve café",
+			ops:   []op{(*Buffer).DeleteWordBackward},
+			want:  "naïve ",
+			row:   0, col: 6,
+		},
+		{
+			name:  "forward word delete across punctuation",
+			value: "  --héllo wörld",
+			ops:   []op{(*Buffer).LineStart, (*Buffer).DeleteWordForward},
+			want:  " wörld",
+			row:   0, col: 0,
+		},
+		{
+			name:      "backward word delete at a line start joins lines",
+			value:     "one\ntwo",
+			multiline: true,
+			ops:       []op{(*Buffer).LineStart, (*Buffer).DeleteWordBackward},
+			want:      "onetwo",
+			row:       0, col: 3,
+		},
+		{
+			name:      "undo after joining lines",
+			value:     "one\ntwo",
+			multiline: true,
+			ops:       []op{(*Buffer).LineStart, (*Buffer).Backspace, insert("-"), undo, undo},
+			want:      "one\ntwo",
+			row:       1, col: 0,
+		},
+		{
+			name:      "redo a join",
+			value:     "one\ntwo",
+			multiline: true,
+			ops:       []op{(*Buffer).Top, (*Buffer).LineEnd, (*Buffer).Delete, undo, redo},
+			want:      "onetwo",
+			row:       0, col: 3,
+		},
+		{
+			name: "typing is undone as one step",
+			ops:  []op{insert("a"), insert("é"), insert("c"), undo},
+			want: "",
+		},
+		{
+			name: "moving starts a new undo step",
+			ops:  []op{insert("a"), insert("b"), (*Buffer).Left, insert("c"), undo},
+			want: "ab",
+			row:  0, col: 1,
+		},
+		{
+			name: "an edit clears redo",
+			ops:  []op{insert("a"), undo, insert("b"), redo},
+			want: "b",
+			row:  0, col: 1,
+		},
+		{
+			name: "single line replaces pasted newlines",
+			ops:  []op{insert("x\r\ny\nz")},
+			want: "x y z",
+			row:  0, col: 5,
+		},
+		{
+			name:      "multi-line paste",
+			value:     "[]",
+			multiline: true,
+			ops:       []op{(*Buffer).Left, insert("α\r\nβ\nγ")},
+			want:      "[α\nβ\nγ]",
+			row:       2, col: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(tt.value, tt.multiline)
+			for _, op := range tt.ops {
+				op(b)
+			}
+			if got := b.Value(); got != tt.want {
+				t.Errorf("value = %q, want %q", got, tt.want)
+			}
+			if row, col := b.Cursor(); row != tt.row || col != tt.col {
+				t.Errorf("cursor = %d:%d, want %d:%d", row, col, tt.row, tt.col)
+			}
+		})
+	}
+}
+
+func TestUndoRedoEmpty(t *testing.T) {
+	b := New("text", false)
+	if b.Undo() || b.Redo() {
+		t.Error("undo or redo reported a change on a fresh buffer")
+	}
+}
+
+func TestRuneCount(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{"", 0},
+		{"héllo", 5},
+		{"日本\n語", 4},
+		{"😀\n\n", 3},
+	}
+	for _, tt := range tests {
+		if got := New(tt.value, true).RuneCount(); got != tt.want {
+			t.Errorf("RuneCount(%q) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}